@@ -10,6 +10,28 @@ mockgen -source=./webook/internal/service/code.go \
   -package=svcmocks -destination=./webook/internal/service/mocks/code_mock.go
 mockgen -source=./webook/internal/service/article.go \
   -package=svcmocks -destination=./webook/internal/service/mocks/article_mock.go
+mockgen -source=./webook/internal/service/search.go \
+  -package=svcmocks -destination=./webook/internal/service/mocks/search_mock.go
+mockgen -source=./webook/internal/service/article_trash.go \
+  -package=svcmocks -destination=./webook/internal/service/mocks/article_trash_mock.go
+mockgen -source=./webook/internal/service/upload.go \
+  -package=svcmocks -destination=./webook/internal/service/mocks/upload_mock.go
+mockgen -source=./webook/internal/service/series.go \
+  -package=svcmocks -destination=./webook/internal/service/mocks/series_mock.go
+mockgen -source=./webook/internal/service/collaborator.go \
+  -package=svcmocks -destination=./webook/internal/service/mocks/collaborator_mock.go
+mockgen -source=./webook/internal/service/feed.go \
+  -package=svcmocks -destination=./webook/internal/service/mocks/feed_mock.go
+mockgen -source=./webook/internal/service/moderation.go \
+  -package=svcmocks -destination=./webook/internal/service/mocks/moderation_mock.go
+mockgen -source=./webook/internal/service/article_archive.go \
+  -package=svcmocks -destination=./webook/internal/service/mocks/article_archive_mock.go
+mockgen -source=./webook/internal/service/article_share.go \
+  -package=svcmocks -destination=./webook/internal/service/mocks/article_share_mock.go
+mockgen -source=./webook/internal/service/collection.go \
+  -package=svcmocks -destination=./webook/internal/service/mocks/collection_mock.go
+mockgen -source=./webook/internal/service/like.go \
+  -package=svcmocks -destination=./webook/internal/service/mocks/like_mock.go
 mockgen -source=./webook/internal/service/interactive.go \
   -package=svcmocks -destination=./webook/internal/service/mocks/interactive_mock.go
 mockgen -source=./webook/internal/service/sms/types.go \
@@ -22,16 +44,64 @@ mockgen -source=./webook/internal/repository/code.go \
   -package=repomocks -destination=./webook/internal/repository/mocks/code_mock.go
 mockgen -source=./webook/internal/repository/user.go \
   -package=repomocks -destination=./webook/internal/repository/mocks/user_mock.go
-mockgen -source=./webook/internal/repository/article.go \
-  -package=repomocks -destination=./webook/internal/repository/mocks/article_mock.go
+mockgen -source=./webook/internal/repository/article_author.go \
+  -package=repomocks -destination=./webook/internal/repository/mocks/article_author_mock.go
+mockgen -source=./webook/internal/repository/article_reader.go \
+  -package=repomocks -destination=./webook/internal/repository/mocks/article_reader_mock.go
+mockgen -source=./webook/internal/repository/article_revision.go \
+  -package=repomocks -destination=./webook/internal/repository/mocks/article_revision_mock.go
+mockgen -source=./webook/internal/repository/interactive.go \
+  -package=repomocks -destination=./webook/internal/repository/mocks/interactive_mock.go
+mockgen -source=./webook/internal/repository/comment.go \
+  -package=repomocks -destination=./webook/internal/repository/mocks/comment_mock.go
+mockgen -source=./webook/internal/repository/ranking.go \
+  -package=repomocks -destination=./webook/internal/repository/mocks/ranking_mock.go
+mockgen -source=./webook/internal/repository/upload.go \
+  -package=repomocks -destination=./webook/internal/repository/mocks/upload_mock.go
+mockgen -source=./webook/internal/repository/series.go \
+  -package=repomocks -destination=./webook/internal/repository/mocks/series_mock.go
+mockgen -source=./webook/internal/repository/collaborator.go \
+  -package=repomocks -destination=./webook/internal/repository/mocks/collaborator_mock.go
+mockgen -source=./webook/internal/repository/feed.go \
+  -package=repomocks -destination=./webook/internal/repository/mocks/feed_mock.go
+mockgen -source=./webook/internal/repository/moderation.go \
+  -package=repomocks -destination=./webook/internal/repository/mocks/moderation_mock.go
+mockgen -source=./webook/internal/repository/article_share.go \
+  -package=repomocks -destination=./webook/internal/repository/mocks/article_share_mock.go
+mockgen -source=./webook/internal/repository/collection.go \
+  -package=repomocks -destination=./webook/internal/repository/mocks/collection_mock.go
 
 # dao
 mockgen -source=./webook/internal/repository/dao/user.go \
   -package=daomocks -destination=./webook/internal/repository/dao/mocks/user_mock.go
-mockgen -source=./webook/internal/repository/dao/article.go \
-  -package=daomocks -destination=./webook/internal/repository/dao/mocks/article_mock.go
+mockgen -source=./webook/internal/repository/dao/article_author.go \
+  -package=daomocks -destination=./webook/internal/repository/dao/mocks/article_author_mock.go
+mockgen -source=./webook/internal/repository/dao/article_reader.go \
+  -package=daomocks -destination=./webook/internal/repository/dao/mocks/article_reader_mock.go
+mockgen -source=./webook/internal/repository/dao/upload.go \
+  -package=daomocks -destination=./webook/internal/repository/dao/mocks/upload_mock.go
+mockgen -source=./webook/internal/repository/dao/object_storage.go \
+  -package=daomocks -destination=./webook/internal/repository/dao/mocks/object_storage_mock.go
+mockgen -source=./webook/internal/repository/dao/series.go \
+  -package=daomocks -destination=./webook/internal/repository/dao/mocks/series_mock.go
+mockgen -source=./webook/internal/repository/dao/collaborator.go \
+  -package=daomocks -destination=./webook/internal/repository/dao/mocks/collaborator_mock.go
+mockgen -source=./webook/internal/repository/dao/moderation.go \
+  -package=daomocks -destination=./webook/internal/repository/dao/mocks/moderation_mock.go
+mockgen -source=./webook/internal/repository/dao/collection.go \
+  -package=daomocks -destination=./webook/internal/repository/dao/mocks/collection_mock.go
+mockgen -source=./webook/internal/repository/dao/interactive.go \
+  -package=daomocks -destination=./webook/internal/repository/dao/mocks/interactive_mock.go
 mockgen -source=./webook/internal/repository/cache/user.go \
   -package=cachemocks -destination=./webook/internal/repository/cache/mocks/user_mock.go
+mockgen -source=./webook/internal/repository/cache/article_reader.go \
+  -package=cachemocks -destination=./webook/internal/repository/cache/mocks/article_reader_mock.go
+mockgen -source=./webook/internal/repository/cache/feed.go \
+  -package=cachemocks -destination=./webook/internal/repository/cache/mocks/feed_mock.go
+mockgen -source=./webook/internal/repository/cache/interactive.go \
+  -package=cachemocks -destination=./webook/internal/repository/cache/mocks/interactive_mock.go
+mockgen -source=./webook/internal/repository/cache/unique_view.go \
+  -package=cachemocks -destination=./webook/internal/repository/cache/mocks/unique_view_mock.go
 
 # limiter
 mockgen -source=./webook/pkg/limiter/types.go \
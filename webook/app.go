@@ -4,10 +4,13 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/robfig/cron/v3"
 	"webook/webook/internal/events"
+	"webook/webook/internal/repository"
 )
 
 type App struct {
 	server    *gin.Engine
 	consumers []events.Consumer
 	cron      *cron.Cron
+	// views is flushed on shutdown
+	views *repository.ViewCountAggregator
 }
@@ -1,13 +1,35 @@
 package domain
 
-import "time"
+import (
+	"time"
+	"webook/webook/pkg/markdown"
+)
+
+// AbstractLength is the max runes of Article.Abstract,
+// configured by article.abstractLength
+var AbstractLength = 100
 
 type Article struct {
-	Id      int64
-	Title   string
+	Id    int64
+	Title string
+	// Content is markdown
 	Content string
 	Author  Author
 	Status  ArticleStatus
+	Tags    []string
+
+	// HTML is the sanitized html rendered from Content,
+	// it's only filled for the published article
+	HTML string
+
+	// PublishAt is the time a scheduled article goes public
+	PublishAt time.Time
+	// Version increases on every update of the draft,
+	// an update based on a stale version is rejected
+	Version int64
+	// DeletedAt is the time the article was moved to the trash,
+	// zero if it's not deleted
+	DeletedAt time.Time
 
 	Ctime time.Time
 	Utime time.Time
@@ -23,12 +45,15 @@ func (a ArticleList) Ids() []int64 {
 	return ids
 }
 
+// Abstract is taken from the plain text of the rendered content
 func (a Article) Abstract() string {
-	str := []rune(a.Content)
-	if len(str) > 100 {
-		return string(str[:100])
-	}
-	return string(str)
+	return markdown.Abstract(a.Content, AbstractLength)
+}
+
+type Tag struct {
+	Id         int64
+	Name       string
+	ArticleCnt int64
 }
 
 type Author struct {
@@ -43,4 +68,26 @@ const (
 	ArticleStatusUnpublished = iota
 	ArticleStatusPublished   = iota
 	ArticleStatusPrivate     = iota
+	ArticleStatusScheduled   = iota
+	// ArticleStatusUnlisted is published but only reachable by the share links,
+	// it's never listed, ranked or searched
+	ArticleStatusUnlisted = iota
 )
+
+// String is the name of the status in the exported archives
+func (s ArticleStatus) String() string {
+	switch s {
+	case ArticleStatusUnpublished:
+		return "draft"
+	case ArticleStatusPublished:
+		return "published"
+	case ArticleStatusPrivate:
+		return "private"
+	case ArticleStatusScheduled:
+		return "scheduled"
+	case ArticleStatusUnlisted:
+		return "unlisted"
+	default:
+		return "unknown"
+	}
+}
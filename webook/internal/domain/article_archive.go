@@ -0,0 +1,9 @@
+package domain
+
+// ArticleImportResult is the result of a file in the imported archive,
+// Id is the draft created if Err is nil
+type ArticleImportResult struct {
+	File string
+	Id   int64
+	Err  error
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+type ArticleRevision struct {
+	Id        int64
+	ArticleId int64
+	Title     string
+	Content   string
+	Author    Author
+	Status    ArticleStatus
+
+	Ctime time.Time
+}
+
+type ArticleRevisionDiff struct {
+	From  ArticleRevision
+	To    ArticleRevision
+	Lines []DiffLine
+}
+
+type DiffLine struct {
+	Op   string
+	Text string
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// ArticleShare is a secret link to the article, anyone holding the token
+// can read it until it's revoked or expired
+type ArticleShare struct {
+	Id        int64
+	ArticleId int64
+	Token     string
+	// Draft links show the author's latest version,
+	// the others show the published one
+	Draft bool
+	// ExpireAt is zero if the link never expires
+	ExpireAt time.Time
+
+	Ctime time.Time
+}
+
+func (s ArticleShare) Expired(now time.Time) bool {
+	return !s.ExpireAt.IsZero() && !now.Before(s.ExpireAt)
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// CollaboratorRole is ordered, a role can do what the lower ones can do
+type CollaboratorRole uint8
+
+const (
+	CollaboratorRoleUnknown CollaboratorRole = iota
+	// CollaboratorRoleViewer can read the draft and its revisions
+	CollaboratorRoleViewer
+	// CollaboratorRoleEditor can save the draft
+	CollaboratorRoleEditor
+	// CollaboratorRoleOwner is the author, who can publish, withdraw
+	// and manage the collaborators
+	CollaboratorRoleOwner
+)
+
+func (r CollaboratorRole) Valid() bool {
+	return r >= CollaboratorRoleViewer && r <= CollaboratorRoleOwner
+}
+
+// Collaborator is invited by the owner of the article,
+// the role takes effect after it's accepted
+type Collaborator struct {
+	ArticleId int64
+	Uid       int64
+	Role      CollaboratorRole
+	Accepted  bool
+
+	Ctime time.Time
+	Utime time.Time
+}
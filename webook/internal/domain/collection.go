@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// Collection is a folder of the collected items, the private ones are only
+// seen by the owner. Cid 0 is the default folder every user has, it's private
+type Collection struct {
+	Id     int64
+	Uid    int64
+	Name   string
+	Public bool
+
+	Ctime time.Time
+	Utime time.Time
+}
+
+type CollectionItem struct {
+	Cid   int64
+	Biz   string
+	BizId int64
+
+	Ctime time.Time
+}
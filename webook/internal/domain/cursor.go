@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// Cursor is the position of the last item of a page,
+// lists are ordered by (Utime, Id) desc.
+// The zero Cursor means the first page
+type Cursor struct {
+	Utime time.Time
+	Id    int64
+}
+
+func (c Cursor) IsZero() bool {
+	return c.Id == 0 && c.Utime.IsZero()
+}
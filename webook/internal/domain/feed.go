@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+type FeedFormat string
+
+const (
+	FeedFormatRSS  FeedFormat = "rss"
+	FeedFormatAtom FeedFormat = "atom"
+)
+
+func (f FeedFormat) Valid() bool {
+	return f == FeedFormatRSS || f == FeedFormatAtom
+}
+
+// Feed is a rendered feed document of the latest published articles,
+// of the whole site or of an author
+type Feed struct {
+	Format  FeedFormat
+	Content []byte
+	// ETag is the hash of Content
+	ETag string
+	// Utime is the last update time of the articles in it
+	Utime time.Time
+}
@@ -1,11 +1,76 @@
 package domain
 
+import "time"
+
+// ReactionLike is the default reaction, the likes before the reactions
+// are all of it
+const ReactionLike = "like"
+
 type InteractiveCount struct {
-	BizId      int64
-	ViewCnt    int64
+	BizId int64
+	// ViewCnt is the raw page views, UniqueViewCnt is the sum of the daily
+	// unique visitors, which is an estimate
+	ViewCnt       int64
+	UniqueViewCnt int64
+	// LikeCnt is the users reacting in any way, Reactions is the count of
+	// each reaction, it's only filled for a single resource
 	LikeCnt    int64
+	Reactions  map[string]int64
 	CollectCnt int64
 
+	// Liked is true if the user reacts, Reaction is the one
 	Liked     bool
+	Reaction  string
 	Collected bool
 }
+
+// Like is the reaction of a user to a resource, it's listed by
+// (Utime, Id) desc, so the latest one comes first
+type Like struct {
+	Id       int64
+	Biz      string
+	BizId    int64
+	Uid      int64
+	Reaction string
+	Utime    time.Time
+
+	// Nickname is only filled in the users who like a resource
+	Nickname string
+}
+
+// LikedArticle is an article liked by the user
+type LikedArticle struct {
+	Like    Like
+	Article Article
+}
+
+// CounterReactionPrefix is followed by the reaction in CounterDiff.Counter
+const CounterReactionPrefix = "reaction:"
+
+// CounterDiff is a counter different from the one recomputed from the
+// likes and the collections, Counter is "like_cnt", "collect_cnt"
+// or CounterReactionPrefix with the reaction
+type CounterDiff struct {
+	Biz     string
+	BizId   int64
+	Counter string
+	Old     int64
+	New     int64
+}
+
+// ReconcileBatch is a batch of the counts checked, ordered by the id
+// of the counts. LastId is where the next batch starts
+type ReconcileBatch struct {
+	LastId  int64
+	Scanned int
+	Diffs   []CounterDiff
+}
+
+// ReconcileReport is the result of checking all the counts of a biz,
+// Diffs only keeps the first ones, DiffCnts is the number of each counter
+type ReconcileReport struct {
+	DryRun   bool
+	Scanned  int
+	DiffCnts map[string]int
+	Diffs    []CounterDiff
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+	"webook/webook/pkg/moderation"
+)
+
+type ModerationStatus uint8
+
+const (
+	ModerationStatusUnknown ModerationStatus = iota
+	ModerationStatusPending
+	ModerationStatusApproved
+	ModerationStatusRejected
+)
+
+// ModerationItem is a text waiting for the manual review,
+// it's published or created when it's approved
+type ModerationItem struct {
+	Id int64
+	// Biz is "article" or "comment", BizId is the article of both
+	Biz   string
+	BizId int64
+	Uid   int64
+	Title string
+	// Content is a snapshot of the text as it's submitted
+	Content string
+	// Version is the draft version of the article submitted,
+	// an article edited after submitting is not published on approval
+	Version int64
+	// Words is the sensitive words found
+	Words []string
+	// Unlisted is true if the article is published unlisted on approval
+	Unlisted bool
+	Status   ModerationStatus
+	Reviewer int64
+
+	Ctime time.Time
+	Utime time.Time
+}
+
+// ModerationResult is the result of checking some texts together
+type ModerationResult struct {
+	// Action is the strictest one of the texts
+	Action moderation.Action
+	// Texts is the texts checked, the words of the mask categories are masked
+	Texts []string
+	Words []string
+}
@@ -0,0 +1,15 @@
+package domain
+
+type ArticleSearchHit struct {
+	Id     int64
+	Author Author
+	Score  float64
+	// Title and Snippet are html escaped with the matched terms in <em>
+	Title   string
+	Snippet string
+}
+
+type ArticleSearchResult struct {
+	Total int
+	Hits  []ArticleSearchHit
+}
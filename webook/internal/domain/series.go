@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// Series is a list of articles of the same author, such as a multi-part tutorial
+type Series struct {
+	Id       int64
+	Title    string
+	AuthorId int64
+	// ArticleIds is the chapters in order
+	ArticleIds []int64
+
+	Ctime time.Time
+	Utime time.Time
+}
+
+// SeriesNav is where an article is in its series,
+// Prev and Next are zero if there is no such chapter
+type SeriesNav struct {
+	SeriesId int64
+	Title    string
+	Prev     Article
+	Next     Article
+}
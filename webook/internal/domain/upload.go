@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// Upload is a file uploaded for an article, such as an image in the content
+type Upload struct {
+	Id  int64
+	Key string
+	// URL is stable as long as the upload is kept
+	URL         string
+	ArticleId   int64
+	Uid         int64
+	Size        int64
+	ContentType string
+
+	Ctime time.Time
+}
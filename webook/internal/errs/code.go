@@ -8,6 +8,26 @@ const (
 )
 
 const (
-	ArticleInvalidInput        = 402001
-	ArticleInternalServerError = 502001
+	ArticleInvalidInput           = 402001
+	ArticleRevisionNotFound       = 402002
+	ArticleNotScheduled           = 402003
+	ArticleVersionConflict        = 402004
+	ArticleNotInTrash             = 402005
+	ArticleUploadTooLarge         = 402006
+	ArticleUploadTypeInvalid      = 402007
+	ArticleSeriesNotFound         = 402008
+	ArticleSeriesInvalid          = 402009
+	ArticlePermissionDenied       = 402010
+	ArticleInvitationNotFound     = 402011
+	ArticleContentRejected        = 402012
+	ArticleContentUnderReview     = 402013
+	ArticleModerationNotFound     = 402014
+	ArticleNotFound               = 402015
+	ArticleShareNotFound          = 402016
+	ArticleCollectionNotFound     = 402017
+	ArticleCollectionItemNotFound = 402018
+	ArticleDiffTooLarge           = 402019
+	ArticleReconcileRunning       = 402020
+	ArticleReconcileNoReport      = 402021
+	ArticleInternalServerError    = 502001
 )
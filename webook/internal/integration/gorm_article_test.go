@@ -27,7 +27,8 @@ type Result[T any] struct {
 
 func TestArticleHandler_Edit(t *testing.T) {
 	mysqldb := startup.InitMysql()
-	hdl := startup.InitArticleHandler(dao.NewGORMArticleDAO(mysqldb))
+	hdl := startup.InitArticleHandler(dao.NewGORMArticleAuthorDAO(mysqldb),
+		dao.NewGORMArticleReaderDAO(mysqldb))
 	server := gin.Default()
 	server.Use(func(ctx *gin.Context) {
 		ctx.Set("userclaim", ijwt.UserClaims{
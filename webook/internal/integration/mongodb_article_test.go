@@ -12,6 +12,7 @@ import (
 	"net/http/httptest"
 	"testing"
 	"time"
+	"webook/webook/internal/domain"
 	"webook/webook/internal/integration/startup"
 	"webook/webook/internal/repository/dao"
 	ijwt "webook/webook/internal/web/jwt"
@@ -32,8 +33,8 @@ func TestMongoArticleHandler_Edit(t *testing.T) {
 	// Init Handler
 	node, err := snowflake.NewNode(1)
 	assert.NoError(t, err)
-	mDao := dao.NewMongoDBArticleDAO(mongodb, node)
-	hdl := startup.InitArticleHandler(mDao)
+	hdl := startup.InitArticleHandler(dao.NewMongoDBArticleAuthorDAO(mongodb, node),
+		dao.NewMongoDBArticleReaderDAO(mongodb, dao.NewGORMUserDAO(startup.InitMysql())))
 
 	// Start Server
 	server := gin.Default()
@@ -209,3 +210,220 @@ func TestMongoArticleHandler_Edit(t *testing.T) {
 		})
 	}
 }
+
+// initMongoArticleDAO return the daos and a function clearing the test data
+func initMongoArticleDAO(t *testing.T) (dao.ArticleAuthorDAO,
+	dao.ArticleReaderDAO, func()) {
+	mongodb := startup.InitMongoDB()
+	err := dao.InitCollection(mongodb)
+	assert.NoError(t, err)
+	node, err := snowflake.NewNode(1)
+	assert.NoError(t, err)
+	mysqldb := startup.InitMysql()
+	authorDAO := dao.NewMongoDBArticleAuthorDAO(mongodb, node)
+	readerDAO := dao.NewMongoDBArticleReaderDAO(mongodb, dao.NewGORMUserDAO(mysqldb))
+	return authorDAO, readerDAO, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_, err := mongodb.Collection("articles").DeleteMany(ctx, bson.D{})
+		assert.NoError(t, err)
+		_, err = mongodb.Collection("published_articles").DeleteMany(ctx, bson.D{})
+		assert.NoError(t, err)
+		mysqldb.Exec("truncate table `users`")
+	}
+}
+
+func TestMongoDBArticleAuthorDAO(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+	defer cancel()
+	authorDAO, _, clear := initMongoArticleDAO(t)
+	defer clear()
+
+	// Insert And GetById
+	id, err := authorDAO.Insert(ctx, dao.Article{
+		Title:    "My Title",
+		Content:  "My Content",
+		AuthorId: 123,
+		Status:   domain.ArticleStatusUnpublished,
+		Tags:     []string{"go"},
+	})
+	assert.NoError(t, err)
+	arti, err := authorDAO.GetById(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, "My Title", arti.Title)
+	assert.Equal(t, []string{"go"}, arti.Tags)
+	assert.True(t, arti.Ctime > 0)
+
+	// UpdateById
+	err = authorDAO.UpdateById(ctx, dao.Article{
+		Id:       id,
+		Title:    "New Title",
+		Content:  "New Content",
+		AuthorId: 123,
+		Status:   domain.ArticleStatusUnpublished,
+		Tags:     []string{"go", "mongodb"},
+	})
+	assert.NoError(t, err)
+	// Stale version
+	err = authorDAO.UpdateById(ctx, dao.Article{Id: id, AuthorId: 123})
+	assert.Error(t, err)
+	arti, err = authorDAO.GetById(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, "New Title", arti.Title)
+	assert.Equal(t, []string{"go", "mongodb"}, arti.Tags)
+
+	// UpdateStatus
+	err = authorDAO.UpdateStatus(ctx, id+1000, domain.ArticleStatusPrivate)
+	assert.Error(t, err)
+	err = authorDAO.UpdateStatus(ctx, id, domain.ArticleStatusPrivate)
+	assert.NoError(t, err)
+	arti, err = authorDAO.GetById(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(domain.ArticleStatusPrivate), arti.Status)
+
+	// GetByAuthor
+	id2, err := authorDAO.Insert(ctx, dao.Article{Title: "Second", AuthorId: 123})
+	assert.NoError(t, err)
+	_, err = authorDAO.Insert(ctx, dao.Article{Title: "Other", AuthorId: 234})
+	assert.NoError(t, err)
+	artis, err := authorDAO.GetByAuthor(ctx, 123, 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, artis, 2)
+	assert.Equal(t, id2, artis[0].Id)
+	artis, err = authorDAO.GetByAuthor(ctx, 123, 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, artis, 1)
+	assert.Equal(t, id, artis[0].Id)
+
+	_, err = authorDAO.GetById(ctx, -1)
+	assert.Equal(t, dao.ErrRecordNotFound, err)
+}
+
+func TestMongoDBArticleAuthorDAO_Scheduled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+	defer cancel()
+	authorDAO, _, clear := initMongoArticleDAO(t)
+	defer clear()
+
+	now := time.Now().UnixMilli()
+	due, err := authorDAO.Insert(ctx, dao.Article{
+		Title:     "Due",
+		AuthorId:  123,
+		Status:    domain.ArticleStatusScheduled,
+		PublishAt: now - 1000,
+	})
+	assert.NoError(t, err)
+	later, err := authorDAO.Insert(ctx, dao.Article{
+		Title:     "Later",
+		AuthorId:  123,
+		Status:    domain.ArticleStatusScheduled,
+		PublishAt: now + 3600*1000,
+	})
+	assert.NoError(t, err)
+
+	// ListScheduled
+	artis, err := authorDAO.ListScheduled(ctx, now, 10)
+	assert.NoError(t, err)
+	assert.Len(t, artis, 1)
+	assert.Equal(t, due, artis[0].Id)
+
+	// PublishScheduled only once
+	arti, ok, err := authorDAO.PublishScheduled(ctx, due, now)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "Due", arti.Title)
+	assert.Equal(t, uint8(domain.ArticleStatusPublished), arti.Status)
+	_, ok, err = authorDAO.PublishScheduled(ctx, due, now)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	_, ok, err = authorDAO.PublishScheduled(ctx, later, now)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// CancelSchedule
+	err = authorDAO.CancelSchedule(ctx, 234, later)
+	assert.Equal(t, dao.ErrArticleNotScheduled, err)
+	err = authorDAO.CancelSchedule(ctx, 123, later)
+	assert.NoError(t, err)
+	arti, err = authorDAO.GetById(ctx, later)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(domain.ArticleStatusUnpublished), arti.Status)
+	err = authorDAO.CancelSchedule(ctx, 123, later)
+	assert.Equal(t, dao.ErrArticleNotScheduled, err)
+}
+
+func TestMongoDBArticleReaderDAO(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+	defer cancel()
+	_, readerDAO, clear := initMongoArticleDAO(t)
+	defer clear()
+	err := startup.InitMysql().Create(&dao.User{Id: 123, NickName: "Tom"}).Error
+	assert.NoError(t, err)
+
+	// Upsert
+	err = readerDAO.Upsert(ctx, dao.PublicArticle{
+		Id:       1,
+		Title:    "My Title",
+		Content:  "My Content",
+		AuthorId: 123,
+		Status:   domain.ArticleStatusPublished,
+		Tags:     []string{"go"},
+	})
+	assert.NoError(t, err)
+	err = readerDAO.Upsert(ctx, dao.PublicArticle{
+		Id:       2,
+		Title:    "Second",
+		AuthorId: 123,
+		Status:   domain.ArticleStatusPublished,
+		Tags:     []string{"go", "mongodb"},
+	})
+	assert.NoError(t, err)
+	err = readerDAO.Upsert(ctx, dao.PublicArticle{
+		Id:       1,
+		Title:    "New Title",
+		Content:  "New Content",
+		AuthorId: 123,
+		Status:   domain.ArticleStatusPublished,
+		Tags:     []string{"go"},
+	})
+	assert.NoError(t, err)
+
+	// GetPubById
+	pub, err := readerDAO.GetPubById(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "New Content", pub.Content)
+	assert.Equal(t, uint8(domain.ArticleStatusPublished), pub.Status)
+	assert.True(t, pub.Ctime > 0)
+	_, err = readerDAO.GetPubById(ctx, -1)
+	assert.Equal(t, dao.ErrRecordNotFound, err)
+
+	// ListPub with author name
+	artis, err := readerDAO.ListPub(ctx, time.Now().Add(time.Second), 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, artis, 2)
+	assert.Equal(t, int64(1), artis[0].Id)
+	assert.Equal(t, "Tom", artis[0].AuthorName)
+
+	// ListPubByTag And ListPopularTags
+	artis, err = readerDAO.ListPubByTag(ctx, "mongodb",
+		time.Now().Add(time.Second), 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, artis, 1)
+	assert.Equal(t, int64(2), artis[0].Id)
+	tags, err := readerDAO.ListPopularTags(ctx, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []dao.TagCount{
+		{Name: "go", Cnt: 2},
+		{Name: "mongodb", Cnt: 1},
+	}, tags)
+
+	// UpdateStatus
+	err = readerDAO.UpdateStatus(ctx, 1, domain.ArticleStatusPrivate)
+	assert.NoError(t, err)
+	pub, err = readerDAO.GetPubById(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(domain.ArticleStatusPrivate), pub.Status)
+	artis, err = readerDAO.ListPub(ctx, time.Now().Add(time.Second), 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, artis, 1)
+}
@@ -26,7 +26,6 @@ func InitWebServer() *gin.Engine {
 		ioc.InitSMSService,
 
 		dao.NewGORMUserDAO,
-		dao.NewGORMArticleDAO,
 
 		cache.NewRedisUserCache,
 		cache.NewRedisCodeCache,
@@ -49,10 +48,12 @@ func InitWebServer() *gin.Engine {
 	return gin.Default()
 }
 
-func InitArticleHandler(dao dao.ArticleDAO) *web.ArticleHandler {
+func InitArticleHandler(authorDAO dao.ArticleAuthorDAO,
+	readerDAO dao.ArticleReaderDAO) *web.ArticleHandler {
 	wire.Build(
 		thirdPartySet,
-		repository.NewCachedArticleRepository,
+		repository.NewCachedArticleAuthorRepository,
+		repository.NewCachedArticleReaderRepository,
 		service.NewImplArticleService,
 		web.NewArticleHandler,
 	)
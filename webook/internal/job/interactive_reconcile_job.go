@@ -0,0 +1,122 @@
+package job
+
+import (
+	"context"
+	rlock "github.com/gotomicro/redis-lock"
+	"github.com/prometheus/client_golang/prometheus"
+	"strconv"
+	"sync"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/service"
+	"webook/webook/pkg/logger"
+)
+
+// InteractiveReconcileJob recompute the like and collect counts of the
+// articles and repair the drifted ones. Start runs it at once for the
+// admins, under the same lock, and LastReport is the report of the last run.
+// It's a prometheus.Collector of the counts checked and the diffs found
+type InteractiveReconcileJob struct {
+	svc service.InteractiveService
+	biz string
+
+	l logger.Logger
+
+	locked *lockedJob
+
+	reportLock sync.Mutex
+	lastReport *domain.ReconcileReport
+
+	scanned *prometheus.CounterVec
+	diffs   *prometheus.CounterVec
+}
+
+func NewInteractiveReconcileJob(svc service.InteractiveService,
+	timeout time.Duration, lockClient *rlock.Client, l logger.Logger) *InteractiveReconcileJob {
+	return &InteractiveReconcileJob{
+		svc:    svc,
+		biz:    "article",
+		l:      l,
+		locked: newLockedJob("job:interactive_reconcile", timeout, lockClient, l),
+		scanned: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "webook",
+			Subsystem: "interactive",
+			Name:      "reconcile_scanned_total",
+			Help:      "The interactive counts checked",
+		}, []string{"dry_run"}),
+		diffs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "webook",
+			Subsystem: "interactive",
+			Name:      "reconcile_diffs_total",
+			Help:      "The counters different from the recomputed ones",
+		}, []string{"counter", "dry_run"}),
+	}
+}
+
+func (j *InteractiveReconcileJob) Name() string {
+	return "InteractiveReconcileJob"
+}
+
+func (j *InteractiveReconcileJob) Run() error {
+	return j.locked.run(func(ctx context.Context) error {
+		_, err := j.reconcile(ctx, false)
+		return err
+	})
+}
+
+// Start run the job in the background, it's a dry run if dryRun.
+// It returns false if the job is running, or held by another instance
+func (j *InteractiveReconcileJob) Start(dryRun bool) bool {
+	return j.locked.start(func(ctx context.Context) error {
+		_, err := j.reconcile(ctx, dryRun)
+		return err
+	})
+}
+
+// LastReport return the report of the last run, false if there is none
+// since the instance started
+func (j *InteractiveReconcileJob) LastReport() (domain.ReconcileReport, bool) {
+	j.reportLock.Lock()
+	defer j.reportLock.Unlock()
+	if j.lastReport == nil {
+		return domain.ReconcileReport{}, false
+	}
+	return *j.lastReport, true
+}
+
+// reconcile check the counts, the diffs are repaired unless dryRun.
+// The diffs are logged and counted
+func (j *InteractiveReconcileJob) reconcile(ctx context.Context,
+	dryRun bool) (domain.ReconcileReport, error) {
+	report, err := j.svc.ReconcileCounts(ctx, j.biz, dryRun)
+	dry := strconv.FormatBool(dryRun)
+	j.scanned.WithLabelValues(dry).Add(float64(report.Scanned))
+	for counter, cnt := range report.DiffCnts {
+		j.diffs.WithLabelValues(counter, dry).Add(float64(cnt))
+	}
+	for _, diff := range report.Diffs {
+		j.l.Warn("interactive counter drifted",
+			logger.String("biz", diff.Biz),
+			logger.Int64("bizId", diff.BizId),
+			logger.String("counter", diff.Counter),
+			logger.Int64("old", diff.Old),
+			logger.Int64("new", diff.New),
+			logger.Bool("dryRun", dryRun))
+	}
+	if err == nil {
+		j.reportLock.Lock()
+		j.lastReport = &report
+		j.reportLock.Unlock()
+	}
+	return report, err
+}
+
+func (j *InteractiveReconcileJob) Describe(ch chan<- *prometheus.Desc) {
+	j.scanned.Describe(ch)
+	j.diffs.Describe(ch)
+}
+
+func (j *InteractiveReconcileJob) Collect(ch chan<- prometheus.Metric) {
+	j.scanned.Collect(ch)
+	j.diffs.Collect(ch)
+}
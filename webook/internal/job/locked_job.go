@@ -0,0 +1,113 @@
+package job
+
+import (
+	"context"
+	rlock "github.com/gotomicro/redis-lock"
+	"sync"
+	"time"
+	"webook/webook/pkg/logger"
+)
+
+// lockedJob run a job only on the instance holding the distributed lock
+// of key. The lock is kept and refreshed once it's got, so the same
+// instance keeps running the job until refreshing fails. The runs on the
+// instance don't overlap either
+type lockedJob struct {
+	key        string
+	timeout    time.Duration
+	lockClient *rlock.Client
+
+	l logger.Logger
+
+	localLock sync.Mutex
+	lock      *rlock.Lock
+	running   bool
+}
+
+func newLockedJob(key string, timeout time.Duration,
+	lockClient *rlock.Client, l logger.Logger) *lockedJob {
+	return &lockedJob{
+		key:        key,
+		timeout:    timeout,
+		lockClient: lockClient,
+		l:          l,
+	}
+}
+
+// run call fn with a context of the timeout, it's skipped
+// if the lock is held by another instance
+func (j *lockedJob) run(fn func(ctx context.Context) error) error {
+	_, err := j.tryRun(fn)
+	return err
+}
+
+// tryRun is run returning false if fn is skipped, because the lock is
+// held by another instance or it's running on this one
+func (j *lockedJob) tryRun(fn func(ctx context.Context) error) (bool, error) {
+	if !j.acquire() {
+		return false, nil
+	}
+	return true, j.runAcquired(fn)
+}
+
+// start is tryRun in the background, it returns once fn is started
+// or skipped, and the error of fn is logged
+func (j *lockedJob) start(fn func(ctx context.Context) error) bool {
+	if !j.acquire() {
+		return false
+	}
+	go func() {
+		err := j.runAcquired(fn)
+		if err != nil {
+			j.l.Error("failed to run job",
+				logger.String("key", j.key), logger.Error(err))
+		}
+	}()
+	return true
+}
+
+func (j *lockedJob) runAcquired(fn func(ctx context.Context) error) error {
+	defer j.release()
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+	return fn(ctx)
+}
+
+func (j *lockedJob) acquire() bool {
+	j.localLock.Lock()
+	defer j.localLock.Unlock()
+	if j.running {
+		return false
+	}
+	if j.lock == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
+		defer cancel()
+		lock, err := j.lockClient.Lock(ctx, j.key, j.timeout,
+			&rlock.FixIntervalRetry{
+				Interval: 100 * time.Millisecond,
+				Max:      3,
+			}, time.Second)
+		if err != nil {
+			j.l.Warn("failed to get job lock",
+				logger.String("key", j.key), logger.Error(err))
+			return false
+		}
+		j.lock = lock
+		go func() {
+			er := lock.AutoRefresh(j.timeout/2, j.timeout)
+			if er != nil {
+				j.localLock.Lock()
+				j.lock = nil
+				j.localLock.Unlock()
+			}
+		}()
+	}
+	j.running = true
+	return true
+}
+
+func (j *lockedJob) release() {
+	j.localLock.Lock()
+	j.running = false
+	j.localLock.Unlock()
+}
@@ -3,33 +3,25 @@ package job
 import (
 	"context"
 	rlock "github.com/gotomicro/redis-lock"
-	"sync"
 	"time"
 	"webook/webook/internal/service"
 	"webook/webook/pkg/logger"
 )
 
 type RankingJob struct {
-	svc        service.RankingService
-	timeout    time.Duration
-	lockClient *rlock.Client
-	key        string
+	svc service.RankingService
 
 	l logger.Logger
 
-	localLock *sync.Mutex
-	lock      *rlock.Lock
+	locked *lockedJob
 }
 
 func NewRankingJob(svc service.RankingService,
 	timeout time.Duration, lockClient *rlock.Client, l logger.Logger) *RankingJob {
 	return &RankingJob{
-		svc:        svc,
-		timeout:    timeout,
-		lockClient: lockClient,
-		key:        "job:ranking",
-		l:          l,
-		localLock:  &sync.Mutex{},
+		svc:    svc,
+		l:      l,
+		locked: newLockedJob("job:ranking", timeout, lockClient, l),
 	}
 }
 
@@ -38,35 +30,9 @@ func (j *RankingJob) Name() string {
 }
 
 func (j *RankingJob) Run() error {
-	j.localLock.Lock()
-	lock := j.lock
-	if lock == nil {
-		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
-		defer cancel()
-		lock, err := j.lockClient.Lock(ctx, j.key, j.timeout,
-			&rlock.FixIntervalRetry{
-				Interval: 100 * time.Millisecond,
-				Max:      3,
-			}, time.Second)
-		if err != nil {
-			j.localLock.Unlock()
-			j.l.Warn("failed to get ranking job lock", logger.Error(err))
-			return nil
-		}
-		j.lock = lock
-		j.localLock.Unlock()
-		go func() {
-			er := lock.AutoRefresh(j.timeout/2, j.timeout)
-			if er != nil {
-				j.localLock.Lock()
-				j.lock = nil
-				j.localLock.Unlock()
-			}
-		}()
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
-	defer cancel()
-	return j.svc.TopN(ctx, 100)
+	return j.locked.run(func(ctx context.Context) error {
+		return j.svc.TopN(ctx, 100)
+	})
 }
 
 //func (j *RankingJob) Run() error {
@@ -0,0 +1,45 @@
+package job
+
+import (
+	"context"
+	rlock "github.com/gotomicro/redis-lock"
+	"time"
+	"webook/webook/internal/service"
+	"webook/webook/pkg/logger"
+)
+
+// ScheduledPublishJob publish the scheduled articles which are due
+type ScheduledPublishJob struct {
+	svc       service.ArticleService
+	batchSize int64
+
+	locked *lockedJob
+}
+
+func NewScheduledPublishJob(svc service.ArticleService,
+	timeout time.Duration, lockClient *rlock.Client, l logger.Logger) *ScheduledPublishJob {
+	return &ScheduledPublishJob{
+		svc:       svc,
+		batchSize: 100,
+		locked:    newLockedJob("job:scheduled_publish", timeout, lockClient, l),
+	}
+}
+
+func (j *ScheduledPublishJob) Name() string {
+	return "ScheduledPublishJob"
+}
+
+func (j *ScheduledPublishJob) Run() error {
+	return j.locked.run(func(ctx context.Context) error {
+		now := time.Now()
+		for {
+			cnt, err := j.svc.PublishDue(ctx, now, j.batchSize)
+			if err != nil {
+				return err
+			}
+			if int64(cnt) < j.batchSize {
+				return nil
+			}
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package job
+
+import (
+	"context"
+	"time"
+	"webook/webook/internal/service"
+)
+
+// SearchIndexJob rebuild the in-memory search index periodically.
+// Each instance keeps its own index, so it runs on every instance without lock,
+// and catches up the changes made through the other instances
+type SearchIndexJob struct {
+	svc     service.SearchService
+	timeout time.Duration
+}
+
+func NewSearchIndexJob(svc service.SearchService, timeout time.Duration) *SearchIndexJob {
+	return &SearchIndexJob{
+		svc:     svc,
+		timeout: timeout,
+	}
+}
+
+func (j *SearchIndexJob) Name() string {
+	return "SearchIndexJob"
+}
+
+func (j *SearchIndexJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+	return j.svc.Rebuild(ctx)
+}
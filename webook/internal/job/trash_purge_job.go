@@ -0,0 +1,46 @@
+package job
+
+import (
+	"context"
+	rlock "github.com/gotomicro/redis-lock"
+	"time"
+	"webook/webook/internal/service"
+	"webook/webook/pkg/logger"
+)
+
+// TrashPurgeJob purge the articles which stay in the trash longer than
+// the retention
+type TrashPurgeJob struct {
+	svc       service.ArticleTrashService
+	batchSize int64
+
+	locked *lockedJob
+}
+
+func NewTrashPurgeJob(svc service.ArticleTrashService,
+	timeout time.Duration, lockClient *rlock.Client, l logger.Logger) *TrashPurgeJob {
+	return &TrashPurgeJob{
+		svc:       svc,
+		batchSize: 100,
+		locked:    newLockedJob("job:trash_purge", timeout, lockClient, l),
+	}
+}
+
+func (j *TrashPurgeJob) Name() string {
+	return "TrashPurgeJob"
+}
+
+func (j *TrashPurgeJob) Run() error {
+	return j.locked.run(func(ctx context.Context) error {
+		now := time.Now()
+		for {
+			cnt, err := j.svc.PurgeExpired(ctx, now, j.batchSize)
+			if err != nil {
+				return err
+			}
+			if int64(cnt) < j.batchSize {
+				return nil
+			}
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package job
+
+import (
+	"context"
+	rlock "github.com/gotomicro/redis-lock"
+	"time"
+	"webook/webook/internal/service"
+	"webook/webook/pkg/logger"
+)
+
+// UniqueViewJob fold the daily unique views of the articles into the counts.
+// Yesterday is folded as well, so the views after the last run of
+// yesterday are not lost
+type UniqueViewJob struct {
+	svc service.InteractiveService
+	biz string
+
+	locked *lockedJob
+}
+
+func NewUniqueViewJob(svc service.InteractiveService,
+	timeout time.Duration, lockClient *rlock.Client, l logger.Logger) *UniqueViewJob {
+	return &UniqueViewJob{
+		svc:    svc,
+		biz:    "article",
+		locked: newLockedJob("job:unique_view", timeout, lockClient, l),
+	}
+}
+
+func (j *UniqueViewJob) Name() string {
+	return "UniqueViewJob"
+}
+
+func (j *UniqueViewJob) Run() error {
+	return j.locked.run(func(ctx context.Context) error {
+		now := time.Now()
+		for _, day := range []time.Time{now.AddDate(0, 0, -1), now} {
+			_, err := j.svc.FoldUniqueViews(ctx, j.biz, day)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,46 @@
+package job
+
+import (
+	"context"
+	rlock "github.com/gotomicro/redis-lock"
+	"time"
+	"webook/webook/internal/service"
+	"webook/webook/pkg/logger"
+)
+
+// UploadGCJob remove the uploads which are no longer referred by their
+// articles
+type UploadGCJob struct {
+	svc service.UploadService
+	// grace keeps the fresh uploads, which may be referred
+	// by a draft not saved yet
+	grace time.Duration
+
+	l logger.Logger
+
+	locked *lockedJob
+}
+
+func NewUploadGCJob(svc service.UploadService,
+	timeout time.Duration, lockClient *rlock.Client, l logger.Logger) *UploadGCJob {
+	return &UploadGCJob{
+		svc:    svc,
+		grace:  24 * time.Hour,
+		l:      l,
+		locked: newLockedJob("job:upload_gc", timeout, lockClient, l),
+	}
+}
+
+func (j *UploadGCJob) Name() string {
+	return "UploadGCJob"
+}
+
+func (j *UploadGCJob) Run() error {
+	return j.locked.run(func(ctx context.Context) error {
+		cnt, err := j.svc.GC(ctx, time.Now().Add(-j.grace))
+		if cnt > 0 {
+			j.l.Info("removed unreferenced uploads", logger.Int64("cnt", int64(cnt)))
+		}
+		return err
+	})
+}
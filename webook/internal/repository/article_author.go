@@ -1,4 +1,272 @@
 package repository
 
+import (
+	"context"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository/cache"
+	"webook/webook/internal/repository/dao"
+)
+
+// ArticleAuthorRepository owns the drafts in articles,
+// all the writes of the author go through it
 type ArticleAuthorRepository interface {
+	Create(ctx context.Context, arti domain.Article) (int64, error)
+	Update(ctx context.Context, arti domain.Article) error
+	UpdateStatus(ctx context.Context, uid int64, id int64, status domain.ArticleStatus) error
+	GetByAuthor(ctx context.Context, uid int64, offset int64, limit int64) ([]domain.Article, error)
+	// GetByAuthorAfter return the page after the cursor, ordered as GetByAuthor
+	GetByAuthorAfter(ctx context.Context, uid int64, cursor domain.Cursor, limit int64) ([]domain.Article, error)
+	// ScanByAuthor is GetByAuthorAfter without the cache, the cached
+	// first page only keeps the abstracts
+	ScanByAuthor(ctx context.Context, uid int64, cursor domain.Cursor, limit int64) ([]domain.Article, error)
+	GetById(ctx context.Context, id int64) (domain.Article, error)
+	ListScheduled(ctx context.Context, before time.Time, limit int64) ([]domain.Article, error)
+	// PublishScheduled mark a due scheduled article as published and return it,
+	// false if it's not scheduled or not due
+	PublishScheduled(ctx context.Context, id int64, now time.Time) (domain.Article, bool, error)
+	CancelSchedule(ctx context.Context, uid int64, id int64) error
+	// Delete move the article to the trash
+	Delete(ctx context.Context, uid int64, id int64, now time.Time) error
+	// Restore move the article out of the trash if it's deleted after the
+	// given time, otherwise return ErrArticleNotInTrash
+	Restore(ctx context.Context, uid int64, id int64, after time.Time) error
+	ListTrash(ctx context.Context, uid int64, offset int64, limit int64) ([]domain.Article, error)
+	// ListExpired return the articles deleted before the given time
+	ListExpired(ctx context.Context, before time.Time, limit int64) ([]domain.Article, error)
+	Purge(ctx context.Context, ids []int64) error
+}
+
+type CachedArticleAuthorRepository struct {
+	dao   dao.ArticleAuthorDAO
+	cache cache.ArticleAuthorCache
+}
+
+func NewCachedArticleAuthorRepository(dao dao.ArticleAuthorDAO,
+	cache cache.ArticleAuthorCache) ArticleAuthorRepository {
+	return &CachedArticleAuthorRepository{
+		dao:   dao,
+		cache: cache,
+	}
+}
+
+func (r *CachedArticleAuthorRepository) Create(ctx context.Context,
+	arti domain.Article) (int64, error) {
+	id, err := r.dao.Insert(ctx, toArticleEntity(arti))
+	if err != nil {
+		return 0, err
+	}
+
+	// Delete cache
+	err = r.cache.DelFirstPage(ctx, arti.Author.Id)
+	if err != nil {
+		// log
+	}
+	return id, nil
+}
+
+func (r *CachedArticleAuthorRepository) Update(ctx context.Context,
+	arti domain.Article) error {
+	err := r.dao.UpdateById(ctx, toArticleEntity(arti))
+	if err != nil {
+		return err
+	}
+
+	r.delCache(ctx, arti.Author.Id, arti.Id)
+	return nil
+}
+
+func (r *CachedArticleAuthorRepository) UpdateStatus(ctx context.Context,
+	uid int64, id int64, status domain.ArticleStatus) error {
+	err := r.dao.UpdateStatus(ctx, id, uint8(status))
+	if err != nil {
+		return err
+	}
+
+	r.delCache(ctx, uid, id)
+	return nil
+}
+
+func (r *CachedArticleAuthorRepository) GetByAuthor(ctx context.Context,
+	uid int64, offset int64, limit int64) ([]domain.Article, error) {
+	// Get page from cache
+	if offset == 0 && limit == 100 {
+		res, err := r.cache.GetFirstPage(ctx, uid)
+		if err == nil {
+			return res, nil
+		} else {
+			// log
+		}
+	}
+
+	// Get page from database
+	artis, err := r.dao.GetByAuthor(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := toArticleDomains(artis)
+
+	// Set cache, it's only the page read from it
+	if offset == 0 && limit == 100 {
+		err = r.cache.SetFirstPage(ctx, uid, res)
+		if err != nil {
+			// log
+		}
+	}
+
+	r.preCache(ctx, res)
+
+	return res, nil
+}
+
+func (r *CachedArticleAuthorRepository) GetByAuthorAfter(ctx context.Context,
+	uid int64, cursor domain.Cursor, limit int64) ([]domain.Article, error) {
+	// The first page is the same as offset 0, share the cache with it
+	if cursor.IsZero() {
+		return r.GetByAuthor(ctx, uid, 0, limit)
+	}
+
+	utime, id := toCursorArgs(cursor)
+	artis, err := r.dao.GetByAuthorAfter(ctx, uid, utime, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := toArticleDomains(artis)
+	r.preCache(ctx, res)
+	return res, nil
+}
+
+func (r *CachedArticleAuthorRepository) ScanByAuthor(ctx context.Context,
+	uid int64, cursor domain.Cursor, limit int64) ([]domain.Article, error) {
+	utime, id := toCursorArgs(cursor)
+	artis, err := r.dao.GetByAuthorAfter(ctx, uid, utime, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toArticleDomains(artis), nil
+}
+
+func (r *CachedArticleAuthorRepository) GetById(ctx context.Context,
+	id int64) (domain.Article, error) {
+	// Get from cache
+	res, err := r.cache.Get(ctx, id)
+	if err == nil {
+		return res, nil
+	}
+
+	// Get from database
+	arti, err := r.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	res = toArticleDomain(arti)
+
+	// Set cache
+	err = r.cache.Set(ctx, res)
+	if err != nil {
+		// log
+	}
+
+	return res, nil
+}
+
+func (r *CachedArticleAuthorRepository) ListScheduled(ctx context.Context,
+	before time.Time, limit int64) ([]domain.Article, error) {
+	artis, err := r.dao.ListScheduled(ctx, before.UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return toArticleDomains(artis), nil
+}
+
+func (r *CachedArticleAuthorRepository) PublishScheduled(ctx context.Context,
+	id int64, now time.Time) (domain.Article, bool, error) {
+	arti, ok, err := r.dao.PublishScheduled(ctx, id, now.UnixMilli())
+	if err != nil || !ok {
+		return domain.Article{}, ok, err
+	}
+
+	r.delCache(ctx, arti.AuthorId, id)
+	return toArticleDomain(arti), true, nil
+}
+
+func (r *CachedArticleAuthorRepository) CancelSchedule(ctx context.Context,
+	uid int64, id int64) error {
+	err := r.dao.CancelSchedule(ctx, uid, id)
+	if err != nil {
+		return err
+	}
+
+	r.delCache(ctx, uid, id)
+	return nil
+}
+
+func (r *CachedArticleAuthorRepository) delCache(ctx context.Context,
+	uid int64, id int64) {
+	err := r.cache.DelFirstPage(ctx, uid)
+	if err != nil {
+		// log
+	}
+	err = r.cache.Del(ctx, id)
+	if err != nil {
+		// log
+	}
+}
+
+func (r *CachedArticleAuthorRepository) preCache(ctx context.Context,
+	arti []domain.Article) {
+	const maxlen = 1024 * 1024
+	if len(arti) > 0 && len(arti[0].Content) < maxlen {
+		err := r.cache.Set(ctx, arti[0])
+		if err != nil {
+			// log
+		}
+	}
+}
+
+func (r *CachedArticleAuthorRepository) Delete(ctx context.Context,
+	uid int64, id int64, now time.Time) error {
+	err := r.dao.Delete(ctx, uid, id, now.UnixMilli())
+	if err != nil {
+		return err
+	}
+
+	r.delCache(ctx, uid, id)
+	return nil
+}
+
+func (r *CachedArticleAuthorRepository) Restore(ctx context.Context,
+	uid int64, id int64, after time.Time) error {
+	err := r.dao.Restore(ctx, uid, id, after.UnixMilli())
+	if err != nil {
+		return err
+	}
+
+	r.delCache(ctx, uid, id)
+	return nil
+}
+
+func (r *CachedArticleAuthorRepository) ListTrash(ctx context.Context,
+	uid int64, offset int64, limit int64) ([]domain.Article, error) {
+	artis, err := r.dao.ListDeleted(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toArticleDomains(artis), nil
+}
+
+func (r *CachedArticleAuthorRepository) ListExpired(ctx context.Context,
+	before time.Time, limit int64) ([]domain.Article, error) {
+	artis, err := r.dao.ListExpired(ctx, before.UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return toArticleDomains(artis), nil
+}
+
+// Purge does not touch the cache, the deleted articles are evicted
+// from it when they are moved to the trash
+func (r *CachedArticleAuthorRepository) Purge(ctx context.Context,
+	ids []int64) error {
+	return r.dao.Purge(ctx, ids)
 }
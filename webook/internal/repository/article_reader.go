@@ -1,4 +1,226 @@
 package repository
 
+import (
+	"context"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository/cache"
+	"webook/webook/internal/repository/dao"
+	"webook/webook/pkg/markdown"
+)
+
+// ArticleReaderRepository owns the published articles in public_articles,
+// the readers only read through it
 type ArticleReaderRepository interface {
+	// Save create or overwrite the published copy of the article
+	Save(ctx context.Context, arti domain.Article) error
+	UpdateStatus(ctx context.Context, id int64, status domain.ArticleStatus) error
+	GetPubById(ctx context.Context, id int64) (domain.Article, error)
+	// ListPubByIds return the published copies of ids in the statuses,
+	// the order is not kept
+	ListPubByIds(ctx context.Context, ids []int64, statuses []domain.ArticleStatus) ([]domain.Article, error)
+	ListPub(ctx context.Context, start time.Time, offset int64, limit int64) ([]domain.Article, error)
+	// ListPubAfter return the page after the cursor, ordered as ListPub
+	ListPubAfter(ctx context.Context, cursor domain.Cursor, limit int64) ([]domain.Article, error)
+	ListPubByTag(ctx context.Context, tag string, start time.Time, offset int64, limit int64) ([]domain.Article, error)
+	ListPubByAuthor(ctx context.Context, uid int64, start time.Time, offset int64, limit int64) ([]domain.Article, error)
+	ListPopularTags(ctx context.Context, limit int64) ([]domain.Tag, error)
+	// Delete hide the published copy until it's restored or purged
+	Delete(ctx context.Context, id int64, now time.Time) error
+	Restore(ctx context.Context, id int64) error
+	Purge(ctx context.Context, ids []int64) error
+}
+
+type CachedArticleReaderRepository struct {
+	dao   dao.ArticleReaderDAO
+	cache cache.ArticleReaderCache
+
+	userRepo UserRepository
+}
+
+func NewCachedArticleReaderRepository(dao dao.ArticleReaderDAO,
+	cache cache.ArticleReaderCache,
+	userRepo UserRepository) ArticleReaderRepository {
+	return &CachedArticleReaderRepository{
+		dao:      dao,
+		cache:    cache,
+		userRepo: userRepo,
+	}
+}
+
+func (r *CachedArticleReaderRepository) Save(ctx context.Context,
+	arti domain.Article) error {
+	err := r.dao.Upsert(ctx, dao.PublicArticle(toArticleEntity(arti)))
+	if err != nil {
+		return err
+	}
+
+	// Set cache, the article just published is likely to be read soon
+	arti.HTML = markdown.Render(arti.Content)
+	user, err := r.userRepo.FindByID(ctx, arti.Author.Id)
+	if err != nil {
+		// log
+		err = r.cache.Del(ctx, arti.Id)
+		if err != nil {
+			// log
+		}
+		return nil
+	}
+	arti.Author.Name = user.NickName
+	arti.Utime = time.Now()
+	err = r.cache.Set(ctx, arti)
+	if err != nil {
+		// log
+	}
+	return nil
+}
+
+func (r *CachedArticleReaderRepository) UpdateStatus(ctx context.Context,
+	id int64, status domain.ArticleStatus) error {
+	err := r.dao.UpdateStatus(ctx, id, uint8(status))
+	if err != nil {
+		return err
+	}
+
+	// Delete cache
+	err = r.cache.Del(ctx, id)
+	if err != nil {
+		// log
+	}
+	return nil
+}
+
+func (r *CachedArticleReaderRepository) GetPubById(ctx context.Context,
+	id int64) (domain.Article, error) {
+	// Get from cache
+	res, err := r.cache.Get(ctx, id)
+	if err == nil {
+		if res.HTML == "" && res.Content != "" {
+			// The html is evicted alone
+			res.HTML = markdown.Render(res.Content)
+			err = r.cache.Set(ctx, res)
+			if err != nil {
+				// log
+			}
+		}
+		return res, nil
+	}
+
+	// Get article from database
+	arti, err := r.dao.GetPubById(ctx, id)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	res = toArticleDomain(dao.Article(arti))
+
+	// Get author's name from user repository
+	author, err := r.userRepo.FindByID(ctx, arti.AuthorId)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	res.Author.Name = author.NickName
+	res.HTML = markdown.Render(res.Content)
+
+	// Set cache
+	err = r.cache.Set(ctx, res)
+	if err != nil {
+		// log
+	}
+
+	return res, nil
+}
+
+func (r *CachedArticleReaderRepository) ListPub(ctx context.Context,
+	start time.Time, offset int64, limit int64) ([]domain.Article, error) {
+	artis, err := r.dao.ListPub(ctx, start, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toArticleDomains(artis), nil
+}
+
+func (r *CachedArticleReaderRepository) ListPubAfter(ctx context.Context,
+	cursor domain.Cursor, limit int64) ([]domain.Article, error) {
+	utime, id := toCursorArgs(cursor)
+	artis, err := r.dao.ListPubAfter(ctx, utime, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toArticleDomains(artis), nil
+}
+
+func (r *CachedArticleReaderRepository) ListPubByTag(ctx context.Context,
+	tag string, start time.Time, offset int64, limit int64) ([]domain.Article, error) {
+	artis, err := r.dao.ListPubByTag(ctx, tag, start, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toArticleDomains(artis), nil
+}
+
+func (r *CachedArticleReaderRepository) ListPubByIds(ctx context.Context,
+	ids []int64, statuses []domain.ArticleStatus) ([]domain.Article, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	ss := make([]uint8, 0, len(statuses))
+	for _, status := range statuses {
+		ss = append(ss, uint8(status))
+	}
+	artis, err := r.dao.ListPubByIds(ctx, ids, ss)
+	if err != nil {
+		return nil, err
+	}
+	return toArticleDomains(artis), nil
+}
+
+func (r *CachedArticleReaderRepository) ListPubByAuthor(ctx context.Context,
+	uid int64, start time.Time, offset int64, limit int64) ([]domain.Article, error) {
+	artis, err := r.dao.ListPubByAuthor(ctx, uid, start, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toArticleDomains(artis), nil
+}
+
+func (r *CachedArticleReaderRepository) ListPopularTags(ctx context.Context,
+	limit int64) ([]domain.Tag, error) {
+	tags, err := r.dao.ListPopularTags(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Tag, 0, len(tags))
+	for _, tag := range tags {
+		res = append(res, domain.Tag{
+			Id:         tag.Id,
+			Name:       tag.Name,
+			ArticleCnt: tag.Cnt,
+		})
+	}
+	return res, nil
+}
+
+func (r *CachedArticleReaderRepository) Delete(ctx context.Context,
+	id int64, now time.Time) error {
+	err := r.dao.Delete(ctx, id, now.UnixMilli())
+	if err != nil {
+		return err
+	}
+
+	// Delete cache
+	err = r.cache.Del(ctx, id)
+	if err != nil {
+		// log
+	}
+	return nil
+}
+
+func (r *CachedArticleReaderRepository) Restore(ctx context.Context,
+	id int64) error {
+	return r.dao.Restore(ctx, id)
+}
+
+func (r *CachedArticleReaderRepository) Purge(ctx context.Context,
+	ids []int64) error {
+	return r.dao.Purge(ctx, ids)
 }
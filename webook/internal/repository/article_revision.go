@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository/dao"
+)
+
+var ErrArticleRevisionNotFound = dao.ErrRecordNotFound
+
+type ArticleRevisionRepository interface {
+	Create(ctx context.Context, arti domain.Article) (int64, error)
+	GetByArticle(ctx context.Context, aid int64, uid int64, offset int64, limit int64) ([]domain.ArticleRevision, error)
+	GetById(ctx context.Context, id int64) (domain.ArticleRevision, error)
+	DeleteByArticleIds(ctx context.Context, aids []int64) error
+}
+
+type ArticleRevisionRepo struct {
+	dao dao.ArticleRevisionDAO
+}
+
+func NewArticleRevisionRepo(dao dao.ArticleRevisionDAO) ArticleRevisionRepository {
+	return &ArticleRevisionRepo{
+		dao: dao,
+	}
+}
+
+// Create snapshot the given article as a new revision
+func (r *ArticleRevisionRepo) Create(ctx context.Context,
+	arti domain.Article) (int64, error) {
+	return r.dao.Insert(ctx, dao.ArticleRevision{
+		ArticleId: arti.Id,
+		AuthorId:  arti.Author.Id,
+		Title:     arti.Title,
+		Content:   arti.Content,
+		Status:    uint8(arti.Status),
+	})
+}
+
+func (r *ArticleRevisionRepo) GetByArticle(ctx context.Context,
+	aid int64, uid int64, offset int64, limit int64) ([]domain.ArticleRevision, error) {
+	revs, err := r.dao.GetByArticle(ctx, aid, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.ArticleRevision, 0, len(revs))
+	for _, rev := range revs {
+		res = append(res, r.toDomain(rev))
+	}
+	return res, nil
+}
+
+func (r *ArticleRevisionRepo) DeleteByArticleIds(ctx context.Context,
+	aids []int64) error {
+	return r.dao.DeleteByArticleIds(ctx, aids)
+}
+
+func (r *ArticleRevisionRepo) GetById(ctx context.Context,
+	id int64) (domain.ArticleRevision, error) {
+	rev, err := r.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.ArticleRevision{}, err
+	}
+	return r.toDomain(rev), nil
+}
+
+func (r *ArticleRevisionRepo) toDomain(rev dao.ArticleRevision) domain.ArticleRevision {
+	return domain.ArticleRevision{
+		Id:        rev.Id,
+		ArticleId: rev.ArticleId,
+		Title:     rev.Title,
+		Content:   rev.Content,
+		Author: domain.Author{
+			Id: rev.AuthorId,
+		},
+		Status: domain.ArticleStatus(rev.Status),
+		Ctime:  time.UnixMilli(rev.Ctime),
+	}
+}
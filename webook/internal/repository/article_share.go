@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository/dao"
+)
+
+var ErrArticleShareNotFound = dao.ErrRecordNotFound
+
+// ArticleShareRepository the token is not stored, it's up to the service
+type ArticleShareRepository interface {
+	Create(ctx context.Context, s domain.ArticleShare) (int64, error)
+	GetById(ctx context.Context, id int64) (domain.ArticleShare, error)
+	List(ctx context.Context, aid int64) ([]domain.ArticleShare, error)
+	// Revoke return ErrArticleShareNotFound if the article has no such share
+	Revoke(ctx context.Context, aid int64, id int64) error
+	DeleteByArticleIds(ctx context.Context, aids []int64) error
+}
+
+type ArticleShareRepo struct {
+	dao dao.ArticleShareDAO
+}
+
+func NewArticleShareRepo(dao dao.ArticleShareDAO) ArticleShareRepository {
+	return &ArticleShareRepo{
+		dao: dao,
+	}
+}
+
+func (r *ArticleShareRepo) Create(ctx context.Context, s domain.ArticleShare) (int64, error) {
+	var expireAt int64
+	if !s.ExpireAt.IsZero() {
+		expireAt = s.ExpireAt.UnixMilli()
+	}
+	return r.dao.Insert(ctx, dao.ArticleShare{
+		ArticleId: s.ArticleId,
+		Draft:     s.Draft,
+		ExpireAt:  expireAt,
+	})
+}
+
+func (r *ArticleShareRepo) GetById(ctx context.Context, id int64) (domain.ArticleShare, error) {
+	s, err := r.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.ArticleShare{}, err
+	}
+	return r.toDomain(s), nil
+}
+
+func (r *ArticleShareRepo) List(ctx context.Context, aid int64) ([]domain.ArticleShare, error) {
+	ss, err := r.dao.ListByArticleId(ctx, aid)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.ArticleShare, 0, len(ss))
+	for _, s := range ss {
+		res = append(res, r.toDomain(s))
+	}
+	return res, nil
+}
+
+func (r *ArticleShareRepo) Revoke(ctx context.Context, aid int64, id int64) error {
+	return r.dao.Delete(ctx, aid, id)
+}
+
+func (r *ArticleShareRepo) DeleteByArticleIds(ctx context.Context, aids []int64) error {
+	return r.dao.DeleteByArticleIds(ctx, aids)
+}
+
+func (r *ArticleShareRepo) toDomain(s dao.ArticleShare) domain.ArticleShare {
+	res := domain.ArticleShare{
+		Id:        s.Id,
+		ArticleId: s.ArticleId,
+		Draft:     s.Draft,
+		Ctime:     time.UnixMilli(s.Ctime),
+	}
+	if s.ExpireAt > 0 {
+		res.ExpireAt = time.UnixMilli(s.ExpireAt)
+	}
+	return res
+}
@@ -1,29 +1,176 @@
 package repository
 
 import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"testing"
 	"webook/webook/internal/domain"
+	"webook/webook/internal/repository/cache"
+	cachemocks "webook/webook/internal/repository/cache/mocks"
 	"webook/webook/internal/repository/dao"
+	daomocks "webook/webook/internal/repository/dao/mocks"
+	repomocks "webook/webook/internal/repository/mocks"
 )
 
-func TestCachedArticleRepository_Sync(t *testing.T) {
+func TestCachedArticleReaderRepository_Save(t *testing.T) {
 	testCases := []struct {
 		name string
 
-		mock func(ctrl *gomock.Controller) (dao.ArticleAuthorDAO,
-			dao.ArticleReaderDAO)
+		mock func(ctrl *gomock.Controller) (dao.ArticleReaderDAO,
+			cache.ArticleReaderCache, UserRepository)
 
 		arti domain.Article
 
-		wantId  int64
 		wantErr error
 	}{
-		{},
+		{
+			name: "save and set cache",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleReaderDAO,
+				cache.ArticleReaderCache, UserRepository) {
+				d := daomocks.NewMockArticleReaderDAO(ctrl)
+				c := cachemocks.NewMockArticleReaderCache(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				d.EXPECT().Upsert(gomock.Any(), dao.PublicArticle{
+					Id:       1,
+					Title:    "My title",
+					Content:  "My content",
+					AuthorId: 123,
+					Status:   domain.ArticleStatusPublished,
+				}).Return(nil)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(123)).
+					Return(domain.User{NickName: "Tom"}, nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, arti domain.Article) error {
+						assert.Equal(t, "Tom", arti.Author.Name)
+						assert.Equal(t, "<p>My content</p>\n", arti.HTML)
+						return nil
+					})
+				return d, c, userRepo
+			},
+			arti: domain.Article{
+				Id:      1,
+				Title:   "My title",
+				Content: "My content",
+				Author:  domain.Author{Id: 123},
+				Status:  domain.ArticleStatusPublished,
+			},
+		},
+		{
+			name: "author not found, delete cache",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleReaderDAO,
+				cache.ArticleReaderCache, UserRepository) {
+				d := daomocks.NewMockArticleReaderDAO(ctrl)
+				c := cachemocks.NewMockArticleReaderCache(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				d.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(nil)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(123)).
+					Return(domain.User{}, errors.New("not found"))
+				c.EXPECT().Del(gomock.Any(), int64(1)).Return(nil)
+				return d, c, userRepo
+			},
+			arti: domain.Article{
+				Id:     1,
+				Author: domain.Author{Id: 123},
+			},
+		},
+		{
+			name: "db error",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleReaderDAO,
+				cache.ArticleReaderCache, UserRepository) {
+				d := daomocks.NewMockArticleReaderDAO(ctrl)
+				d.EXPECT().Upsert(gomock.Any(), gomock.Any()).
+					Return(errors.New("db error"))
+				return d, nil, nil
+			},
+			arti: domain.Article{
+				Id:     1,
+				Author: domain.Author{Id: 123},
+			},
+			wantErr: errors.New("db error"),
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo := NewCachedArticleReaderRepository(tc.mock(ctrl))
+			err := repo.Save(context.Background(), tc.arti)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestCachedArticleReaderRepository_GetPubById(t *testing.T) {
+	testCases := []struct {
+		name string
 
+		mock func(ctrl *gomock.Controller) (dao.ArticleReaderDAO,
+			cache.ArticleReaderCache, UserRepository)
+
+		wantArti domain.Article
+		wantErr  error
+	}{
+		{
+			name: "cache hit",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleReaderDAO,
+				cache.ArticleReaderCache, UserRepository) {
+				c := cachemocks.NewMockArticleReaderCache(ctrl)
+				c.EXPECT().Get(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Content: "*hi*", HTML: "<p><em>hi</em></p>\n"}, nil)
+				return nil, c, nil
+			},
+			wantArti: domain.Article{Id: 1, Content: "*hi*", HTML: "<p><em>hi</em></p>\n"},
+		},
+		{
+			name: "html evicted, render again",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleReaderDAO,
+				cache.ArticleReaderCache, UserRepository) {
+				c := cachemocks.NewMockArticleReaderCache(ctrl)
+				c.EXPECT().Get(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Content: "*hi*"}, nil)
+				c.EXPECT().Set(gomock.Any(), domain.Article{
+					Id: 1, Content: "*hi*", HTML: "<p><em>hi</em></p>\n",
+				}).Return(nil)
+				return nil, c, nil
+			},
+			wantArti: domain.Article{Id: 1, Content: "*hi*", HTML: "<p><em>hi</em></p>\n"},
+		},
+		{
+			name: "cache miss",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleReaderDAO,
+				cache.ArticleReaderCache, UserRepository) {
+				d := daomocks.NewMockArticleReaderDAO(ctrl)
+				c := cachemocks.NewMockArticleReaderCache(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				c.EXPECT().Get(gomock.Any(), int64(1)).
+					Return(domain.Article{}, errors.New("cache miss"))
+				d.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(dao.PublicArticle{Id: 1, Content: "*hi*", AuthorId: 123}, nil)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(123)).
+					Return(domain.User{NickName: "Tom"}, nil)
+				c.EXPECT().Set(gomock.Any(), gomock.Any()).Return(nil)
+				return d, c, userRepo
+			},
+			wantArti: domain.Article{
+				Id:      1,
+				Content: "*hi*",
+				HTML:    "<p><em>hi</em></p>\n",
+				Author:  domain.Author{Id: 123, Name: "Tom"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo := NewCachedArticleReaderRepository(tc.mock(ctrl))
+			arti, err := repo.GetPubById(context.Background(), 1)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantArti.Id, arti.Id)
+			assert.Equal(t, tc.wantArti.HTML, arti.HTML)
+			assert.Equal(t, tc.wantArti.Author, arti.Author)
 		})
 	}
 }
@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"time"
+	"webook/webook/internal/domain"
+)
+
+// ArticleAuthorCache cache the drafts for the author, the author usually
+// opens the first page of the list and then the first article
+type ArticleAuthorCache interface {
+	GetFirstPage(ctx context.Context, uid int64) ([]domain.Article, error)
+	SetFirstPage(ctx context.Context, uid int64, artis []domain.Article) error
+	DelFirstPage(ctx context.Context, uid int64) error
+	Get(ctx context.Context, id int64) (domain.Article, error)
+	Set(ctx context.Context, arti domain.Article) error
+	Del(ctx context.Context, id int64) error
+}
+
+type RedisArticleAuthorCache struct {
+	client     redis.Cmdable
+	expiration time.Duration
+}
+
+func NewRedisArticleAuthorCache(client redis.Cmdable) ArticleAuthorCache {
+	return &RedisArticleAuthorCache{
+		client:     client,
+		expiration: 10 * time.Minute,
+	}
+}
+
+func (r *RedisArticleAuthorCache) GetFirstPage(ctx context.Context, uid int64) ([]domain.Article, error) {
+	key := r.firstKey(uid)
+	val, err := r.client.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	var res []domain.Article
+	err = json.Unmarshal(val, &res)
+	return res, err
+}
+
+func (r *RedisArticleAuthorCache) SetFirstPage(ctx context.Context,
+	uid int64, artis []domain.Article) error {
+	// Change content to abstract
+	var tmpArtis []domain.Article
+	for i := 0; i < len(artis); i++ {
+		tmpArtis = append(tmpArtis, artis[i])
+	}
+
+	// Change content to abstract
+	for i := 0; i < len(tmpArtis); i++ {
+		tmpArtis[i].Content = tmpArtis[i].Abstract()
+	}
+
+	// Save in cache
+	key := r.firstKey(uid)
+	val, err := json.Marshal(tmpArtis)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, key, val, r.expiration).Err()
+}
+
+func (r *RedisArticleAuthorCache) DelFirstPage(ctx context.Context, uid int64) error {
+	key := r.firstKey(uid)
+	return r.client.Del(ctx, key).Err()
+}
+
+func (r *RedisArticleAuthorCache) Get(ctx context.Context,
+	id int64) (domain.Article, error) {
+	val, err := r.client.Get(ctx, r.detailKey(id)).Bytes()
+	if err != nil {
+		return domain.Article{}, err
+	}
+	var res domain.Article
+	err = json.Unmarshal(val, &res)
+	return res, err
+}
+
+func (r *RedisArticleAuthorCache) Set(ctx context.Context,
+	arti domain.Article) error {
+	val, err := json.Marshal(arti)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, r.detailKey(arti.Id), val, r.expiration).Err()
+}
+
+func (r *RedisArticleAuthorCache) Del(ctx context.Context, id int64) error {
+	return r.client.Del(ctx, r.detailKey(id)).Err()
+}
+
+func (r *RedisArticleAuthorCache) detailKey(id int64) string {
+	return fmt.Sprintf("article:detail:%d", id)
+}
+
+// firstKey func
+func (r *RedisArticleAuthorCache) firstKey(uid int64) string {
+	return fmt.Sprintf("article:first_page:%d", uid)
+}
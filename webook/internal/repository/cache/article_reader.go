@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"time"
+	"webook/webook/internal/domain"
+)
+
+// ArticleReaderCache cache the published articles. They are read much more
+// than written, and the cache is refreshed on publishing, so they are kept longer
+type ArticleReaderCache interface {
+	// Get return the article with HTML if it's cached too
+	Get(ctx context.Context, id int64) (domain.Article, error)
+	// Set keep the HTML in another key next to the article
+	Set(ctx context.Context, arti domain.Article) error
+	Del(ctx context.Context, id int64) error
+}
+
+type RedisArticleReaderCache struct {
+	client     redis.Cmdable
+	expiration time.Duration
+}
+
+func NewRedisArticleReaderCache(client redis.Cmdable) ArticleReaderCache {
+	return &RedisArticleReaderCache{
+		client:     client,
+		expiration: 30 * time.Minute,
+	}
+}
+
+func (r *RedisArticleReaderCache) Get(ctx context.Context,
+	id int64) (domain.Article, error) {
+	vals, err := r.client.MGet(ctx, r.key(id), r.htmlKey(id)).Result()
+	if err != nil {
+		return domain.Article{}, err
+	}
+	val, ok := vals[0].(string)
+	if !ok {
+		return domain.Article{}, redis.Nil
+	}
+	var res domain.Article
+	err = json.Unmarshal([]byte(val), &res)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	res.HTML, _ = vals[1].(string)
+	return res, nil
+}
+
+func (r *RedisArticleReaderCache) Set(ctx context.Context, arti domain.Article) error {
+	html := arti.HTML
+	arti.HTML = ""
+	val, err := json.Marshal(arti)
+	if err != nil {
+		return err
+	}
+	pipe := r.client.TxPipeline()
+	pipe.Set(ctx, r.key(arti.Id), val, r.expiration)
+	if html != "" {
+		pipe.Set(ctx, r.htmlKey(arti.Id), html, r.expiration)
+	} else {
+		pipe.Del(ctx, r.htmlKey(arti.Id))
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (r *RedisArticleReaderCache) Del(ctx context.Context, id int64) error {
+	return r.client.Del(ctx, r.key(id), r.htmlKey(id)).Err()
+}
+
+func (r *RedisArticleReaderCache) key(id int64) string {
+	return fmt.Sprintf("article:pub:detail:%d", id)
+}
+
+func (r *RedisArticleReaderCache) htmlKey(id int64) string {
+	return fmt.Sprintf("article:pub:html:%d", id)
+}
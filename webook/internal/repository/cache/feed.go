@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"time"
+	"webook/webook/internal/domain"
+)
+
+// FeedCache cache the rendered feeds for a short while,
+// uid 0 means the feed of the whole site
+type FeedCache interface {
+	Get(ctx context.Context, format domain.FeedFormat, uid int64) (domain.Feed, error)
+	Set(ctx context.Context, uid int64, feed domain.Feed) error
+	// Del remove the feeds containing the articles of the author,
+	// the site feeds and the author feeds in all formats
+	Del(ctx context.Context, uid int64) error
+}
+
+type RedisFeedCache struct {
+	client     redis.Cmdable
+	expiration time.Duration
+}
+
+func NewRedisFeedCache(client redis.Cmdable) FeedCache {
+	return &RedisFeedCache{
+		client:     client,
+		expiration: 5 * time.Minute,
+	}
+}
+
+func (r *RedisFeedCache) Get(ctx context.Context,
+	format domain.FeedFormat, uid int64) (domain.Feed, error) {
+	val, err := r.client.Get(ctx, r.key(format, uid)).Bytes()
+	if err != nil {
+		return domain.Feed{}, err
+	}
+	var res domain.Feed
+	err = json.Unmarshal(val, &res)
+	return res, err
+}
+
+func (r *RedisFeedCache) Set(ctx context.Context, uid int64, feed domain.Feed) error {
+	val, err := json.Marshal(feed)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, r.key(feed.Format, uid), val, r.expiration).Err()
+}
+
+func (r *RedisFeedCache) Del(ctx context.Context, uid int64) error {
+	return r.client.Del(ctx,
+		r.key(domain.FeedFormatRSS, 0), r.key(domain.FeedFormatAtom, 0),
+		r.key(domain.FeedFormatRSS, uid), r.key(domain.FeedFormatAtom, uid)).Err()
+}
+
+func (r *RedisFeedCache) key(format domain.FeedFormat, uid int64) string {
+	if uid == 0 {
+		return fmt.Sprintf("feed:%s:site", format)
+	}
+	return fmt.Sprintf("feed:%s:author:%d", format, uid)
+}
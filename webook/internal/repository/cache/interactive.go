@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"webook/webook/constants"
 	"webook/webook/internal/domain"
 
@@ -13,13 +14,23 @@ import (
 )
 
 type InteractiveCache interface {
-	IncreaseViewCountIfPresent(ctx context.Context, biz string, bizId int64) error
-	IncreaseLikeIfPresent(ctx context.Context, biz string, id int64) error
-	DecreaseLikeIfPresent(ctx context.Context, biz string, id int64) error
+	IncreaseViewCountIfPresent(ctx context.Context, biz string, bizId int64, delta int64) error
+	// IncreaseLikeIfPresent add the reaction to the likes
+	IncreaseLikeIfPresent(ctx context.Context, biz string, id int64, reaction string) error
+	DecreaseLikeIfPresent(ctx context.Context, biz string, id int64, reaction string) error
+	// ChangeReactionIfPresent move a like from one reaction to another
+	ChangeReactionIfPresent(ctx context.Context, biz string, id int64, from, to string) error
 	IncreaseCollectCntIfPresent(ctx context.Context, biz string, id int64) error
 	DecreaseCollectCntIfPresent(ctx context.Context, biz string, id int64) error
+	// IncreaseUniqueViewCntIfPresent add the newly folded unique views
+	IncreaseUniqueViewCntIfPresent(ctx context.Context, biz string, id int64, delta int64) error
 	Get(ctx context.Context, biz string, id int64) (domain.InteractiveCount, error)
+	// GetByIds get the counts in a pipeline, the missed ones are not in the map
+	GetByIds(ctx context.Context, biz string, ids []int64) (map[int64]domain.InteractiveCount, error)
 	Set(ctx context.Context, biz string, id int64, res domain.InteractiveCount) error
+	// SetBatch set the counts by their BizId in a pipeline
+	SetBatch(ctx context.Context, biz string, inters []domain.InteractiveCount) error
+	Del(ctx context.Context, biz string, ids ...int64) error
 }
 
 var (
@@ -31,6 +42,12 @@ const (
 	fieldViewCnt    = "view_cnt"
 	fieldLikeCnt    = "like_cnt"
 	fieldCollectCnt = "collect_cnt"
+	// fieldUniqueViewCnt is the unique visitors,
+	// fieldViewCnt is the raw page views
+	fieldUniqueViewCnt = "unique_view_cnt"
+	// fieldReactionPrefix is followed by the reaction,
+	// fieldLikeCnt is the sum of them
+	fieldReactionPrefix = "reaction:"
 )
 
 type RedisInteractiveCache struct {
@@ -44,22 +61,31 @@ func NewRedisInteractiveCache(client redis.Cmdable) InteractiveCache {
 }
 
 func (c *RedisInteractiveCache) IncreaseViewCountIfPresent(ctx context.Context,
-	biz string, bizId int64) error {
+	biz string, bizId int64, delta int64) error {
 	key := key(biz, bizId)
 	return c.client.Eval(ctx, luaIncrCnt,
-		[]string{key}, fieldViewCnt, +1).Err()
+		[]string{key}, fieldViewCnt, delta).Err()
 }
 
 func (c *RedisInteractiveCache) IncreaseLikeIfPresent(ctx context.Context,
-	biz string, id int64) error {
+	biz string, id int64, reaction string) error {
 	key := key(biz, id)
-	return c.client.Eval(ctx, luaIncrCnt, []string{key}, fieldLikeCnt, +1).Err()
+	return c.client.Eval(ctx, luaIncrCnt, []string{key},
+		fieldLikeCnt, +1, fieldReactionPrefix+reaction, +1).Err()
 }
 
 func (c *RedisInteractiveCache) DecreaseLikeIfPresent(ctx context.Context,
-	biz string, id int64) error {
+	biz string, id int64, reaction string) error {
+	key := key(biz, id)
+	return c.client.Eval(ctx, luaIncrCnt, []string{key},
+		fieldLikeCnt, -1, fieldReactionPrefix+reaction, -1).Err()
+}
+
+func (c *RedisInteractiveCache) ChangeReactionIfPresent(ctx context.Context,
+	biz string, id int64, from, to string) error {
 	key := key(biz, id)
-	return c.client.Eval(ctx, luaIncrCnt, []string{key}, fieldLikeCnt, -1).Err()
+	return c.client.Eval(ctx, luaIncrCnt, []string{key},
+		fieldReactionPrefix+from, -1, fieldReactionPrefix+to, +1).Err()
 }
 
 func (c *RedisInteractiveCache) IncreaseCollectCntIfPresent(ctx context.Context,
@@ -74,6 +100,12 @@ func (c *RedisInteractiveCache) DecreaseCollectCntIfPresent(ctx context.Context,
 	return c.client.Eval(ctx, luaIncrCnt, []string{key}, fieldCollectCnt, -1).Err()
 }
 
+func (c *RedisInteractiveCache) IncreaseUniqueViewCntIfPresent(ctx context.Context,
+	biz string, id int64, delta int64) error {
+	key := key(biz, id)
+	return c.client.Eval(ctx, luaIncrCnt, []string{key}, fieldUniqueViewCnt, delta).Err()
+}
+
 func (c *RedisInteractiveCache) Get(ctx context.Context,
 	biz string, id int64) (domain.InteractiveCount, error) {
 	res, err := c.client.HGetAll(ctx, key(biz, id)).Result()
@@ -83,23 +115,37 @@ func (c *RedisInteractiveCache) Get(ctx context.Context,
 	if err != nil {
 		return domain.InteractiveCount{}, err
 	}
-
-	var inter domain.InteractiveCount
-	inter.ViewCnt, _ = strconv.ParseInt(res[fieldViewCnt], 10, 64)
-	inter.LikeCnt, _ = strconv.ParseInt(res[fieldLikeCnt], 10, 64)
-	inter.CollectCnt, _ = strconv.ParseInt(res[fieldCollectCnt], 10, 64)
-
+	inter := c.toDomain(res)
+	inter.BizId = id
 	return inter, nil
 }
 
+func (c *RedisInteractiveCache) GetByIds(ctx context.Context,
+	biz string, ids []int64) (map[int64]domain.InteractiveCount, error) {
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.HGetAll(ctx, key(biz, id))
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]domain.InteractiveCount, len(ids))
+	for i, cmd := range cmds {
+		if len(cmd.Val()) == 0 {
+			continue
+		}
+		inter := c.toDomain(cmd.Val())
+		inter.BizId = ids[i]
+		res[ids[i]] = inter
+	}
+	return res, nil
+}
+
 func (c *RedisInteractiveCache) Set(ctx context.Context,
 	biz string, id int64, res domain.InteractiveCount) error {
-	err := c.client.HSet(ctx, key(biz, id),
-		map[string]interface{}{
-			fieldViewCnt:    res.ViewCnt,
-			fieldLikeCnt:    res.LikeCnt,
-			fieldCollectCnt: res.CollectCnt,
-		}).Err()
+	err := c.client.HSet(ctx, key(biz, id), c.fields(res)).Err()
 	if err != nil {
 		return err
 	}
@@ -107,6 +153,68 @@ func (c *RedisInteractiveCache) Set(ctx context.Context,
 		Err()
 }
 
+func (c *RedisInteractiveCache) SetBatch(ctx context.Context,
+	biz string, inters []domain.InteractiveCount) error {
+	if len(inters) == 0 {
+		return nil
+	}
+	pipe := c.client.Pipeline()
+	for _, inter := range inters {
+		pipe.HSet(ctx, key(biz, inter.BizId), c.fields(inter))
+		pipe.Expire(ctx, key(biz, inter.BizId), constants.InteractiveCacheExpire)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *RedisInteractiveCache) toDomain(res map[string]string) domain.InteractiveCount {
+	var inter domain.InteractiveCount
+	inter.ViewCnt, _ = strconv.ParseInt(res[fieldViewCnt], 10, 64)
+	inter.LikeCnt, _ = strconv.ParseInt(res[fieldLikeCnt], 10, 64)
+	inter.CollectCnt, _ = strconv.ParseInt(res[fieldCollectCnt], 10, 64)
+	inter.UniqueViewCnt, _ = strconv.ParseInt(res[fieldUniqueViewCnt], 10, 64)
+	for field, val := range res {
+		reaction, ok := strings.CutPrefix(field, fieldReactionPrefix)
+		if !ok {
+			continue
+		}
+		cnt, _ := strconv.ParseInt(val, 10, 64)
+		if cnt <= 0 {
+			continue
+		}
+		if inter.Reactions == nil {
+			inter.Reactions = make(map[string]int64)
+		}
+		inter.Reactions[reaction] = cnt
+	}
+	return inter
+}
+
+func (c *RedisInteractiveCache) fields(res domain.InteractiveCount) map[string]interface{} {
+	vals := map[string]interface{}{
+		fieldViewCnt:       res.ViewCnt,
+		fieldLikeCnt:       res.LikeCnt,
+		fieldCollectCnt:    res.CollectCnt,
+		fieldUniqueViewCnt: res.UniqueViewCnt,
+	}
+	for reaction, cnt := range res.Reactions {
+		vals[fieldReactionPrefix+reaction] = cnt
+	}
+	return vals
+}
+
+func (c *RedisInteractiveCache) Del(ctx context.Context,
+	biz string, ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, key(biz, id))
+	}
+	return c.client.Del(ctx, keys...).Err()
+}
+
 // key func
 func key(biz string, bizId int64) string {
 	return fmt.Sprintf("interactive:%s:%d", biz, bizId)
@@ -1,14 +1,14 @@
 -- key is the biz key
 local key = KEYS[1]
--- cntKey used to choose the field to increment(view, like, or collect)
-local cntKey = ARGV[1]
--- delta used to specify +1 or -1
-local delta = tonumber(ARGV[2])
+-- ARGV is pairs of the field to increment(view, like, collect, or a reaction)
+-- and the delta, the pairs are changed together
 
 -- check if the key exists
 local exist=redis.call("EXISTS", key)
 if exist == 1 then
-    redis.call("HINCRBY", key, cntKey, delta)
+    for i = 1, #ARGV, 2 do
+        redis.call("HINCRBY", key, ARGV[i], tonumber(ARGV[i + 1]))
+    end
     return 1
 else
     return 0
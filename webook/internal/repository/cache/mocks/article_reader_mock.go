@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/cache/article_reader.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/cache/article_reader.go -package=cachemocks -destination=./webook/internal/repository/cache/mocks/article_reader_mock.go
+//
+
+// Package cachemocks is a generated GoMock package.
+package cachemocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "webook/webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleReaderCache is a mock of ArticleReaderCache interface.
+type MockArticleReaderCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleReaderCacheMockRecorder
+}
+
+// MockArticleReaderCacheMockRecorder is the mock recorder for MockArticleReaderCache.
+type MockArticleReaderCacheMockRecorder struct {
+	mock *MockArticleReaderCache
+}
+
+// NewMockArticleReaderCache creates a new mock instance.
+func NewMockArticleReaderCache(ctrl *gomock.Controller) *MockArticleReaderCache {
+	mock := &MockArticleReaderCache{ctrl: ctrl}
+	mock.recorder = &MockArticleReaderCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleReaderCache) EXPECT() *MockArticleReaderCacheMockRecorder {
+	return m.recorder
+}
+
+// Del mocks base method.
+func (m *MockArticleReaderCache) Del(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Del", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Del indicates an expected call of Del.
+func (mr *MockArticleReaderCacheMockRecorder) Del(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockArticleReaderCache)(nil).Del), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockArticleReaderCache) Get(ctx context.Context, id int64) (domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockArticleReaderCacheMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockArticleReaderCache)(nil).Get), ctx, id)
+}
+
+// Set mocks base method.
+func (m *MockArticleReaderCache) Set(ctx context.Context, arti domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, arti)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockArticleReaderCacheMockRecorder) Set(ctx, arti any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockArticleReaderCache)(nil).Set), ctx, arti)
+}
@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/cache/feed.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/cache/feed.go -package=cachemocks -destination=./webook/internal/repository/cache/mocks/feed_mock.go
+//
+
+// Package cachemocks is a generated GoMock package.
+package cachemocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "webook/webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeedCache is a mock of FeedCache interface.
+type MockFeedCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedCacheMockRecorder
+}
+
+// MockFeedCacheMockRecorder is the mock recorder for MockFeedCache.
+type MockFeedCacheMockRecorder struct {
+	mock *MockFeedCache
+}
+
+// NewMockFeedCache creates a new mock instance.
+func NewMockFeedCache(ctrl *gomock.Controller) *MockFeedCache {
+	mock := &MockFeedCache{ctrl: ctrl}
+	mock.recorder = &MockFeedCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedCache) EXPECT() *MockFeedCacheMockRecorder {
+	return m.recorder
+}
+
+// Del mocks base method.
+func (m *MockFeedCache) Del(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Del", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Del indicates an expected call of Del.
+func (mr *MockFeedCacheMockRecorder) Del(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockFeedCache)(nil).Del), ctx, uid)
+}
+
+// Get mocks base method.
+func (m *MockFeedCache) Get(ctx context.Context, format domain.FeedFormat, uid int64) (domain.Feed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, format, uid)
+	ret0, _ := ret[0].(domain.Feed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockFeedCacheMockRecorder) Get(ctx, format, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockFeedCache)(nil).Get), ctx, format, uid)
+}
+
+// Set mocks base method.
+func (m *MockFeedCache) Set(ctx context.Context, uid int64, feed domain.Feed) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, uid, feed)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockFeedCacheMockRecorder) Set(ctx, uid, feed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockFeedCache)(nil).Set), ctx, uid, feed)
+}
@@ -0,0 +1,216 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/cache/interactive.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/cache/interactive.go -package=cachemocks -destination=./webook/internal/repository/cache/mocks/interactive_mock.go
+//
+
+// Package cachemocks is a generated GoMock package.
+package cachemocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "webook/webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractiveCache is a mock of InteractiveCache interface.
+type MockInteractiveCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractiveCacheMockRecorder
+}
+
+// MockInteractiveCacheMockRecorder is the mock recorder for MockInteractiveCache.
+type MockInteractiveCacheMockRecorder struct {
+	mock *MockInteractiveCache
+}
+
+// NewMockInteractiveCache creates a new mock instance.
+func NewMockInteractiveCache(ctrl *gomock.Controller) *MockInteractiveCache {
+	mock := &MockInteractiveCache{ctrl: ctrl}
+	mock.recorder = &MockInteractiveCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractiveCache) EXPECT() *MockInteractiveCacheMockRecorder {
+	return m.recorder
+}
+
+// ChangeReactionIfPresent mocks base method.
+func (m *MockInteractiveCache) ChangeReactionIfPresent(ctx context.Context, biz string, id int64, from, to string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeReactionIfPresent", ctx, biz, id, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeReactionIfPresent indicates an expected call of ChangeReactionIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) ChangeReactionIfPresent(ctx, biz, id, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeReactionIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).ChangeReactionIfPresent), ctx, biz, id, from, to)
+}
+
+// DecreaseCollectCntIfPresent mocks base method.
+func (m *MockInteractiveCache) DecreaseCollectCntIfPresent(ctx context.Context, biz string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecreaseCollectCntIfPresent", ctx, biz, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecreaseCollectCntIfPresent indicates an expected call of DecreaseCollectCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) DecreaseCollectCntIfPresent(ctx, biz, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecreaseCollectCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).DecreaseCollectCntIfPresent), ctx, biz, id)
+}
+
+// DecreaseLikeIfPresent mocks base method.
+func (m *MockInteractiveCache) DecreaseLikeIfPresent(ctx context.Context, biz string, id int64, reaction string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecreaseLikeIfPresent", ctx, biz, id, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecreaseLikeIfPresent indicates an expected call of DecreaseLikeIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) DecreaseLikeIfPresent(ctx, biz, id, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecreaseLikeIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).DecreaseLikeIfPresent), ctx, biz, id, reaction)
+}
+
+// Del mocks base method.
+func (m *MockInteractiveCache) Del(ctx context.Context, biz string, ids ...int64) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, biz}
+	for _, a := range ids {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Del", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Del indicates an expected call of Del.
+func (mr *MockInteractiveCacheMockRecorder) Del(ctx, biz any, ids ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, biz}, ids...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockInteractiveCache)(nil).Del), varargs...)
+}
+
+// Get mocks base method.
+func (m *MockInteractiveCache) Get(ctx context.Context, biz string, id int64) (domain.InteractiveCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, biz, id)
+	ret0, _ := ret[0].(domain.InteractiveCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInteractiveCacheMockRecorder) Get(ctx, biz, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInteractiveCache)(nil).Get), ctx, biz, id)
+}
+
+// GetByIds mocks base method.
+func (m *MockInteractiveCache) GetByIds(ctx context.Context, biz string, ids []int64) (map[int64]domain.InteractiveCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIds", ctx, biz, ids)
+	ret0, _ := ret[0].(map[int64]domain.InteractiveCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIds indicates an expected call of GetByIds.
+func (mr *MockInteractiveCacheMockRecorder) GetByIds(ctx, biz, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractiveCache)(nil).GetByIds), ctx, biz, ids)
+}
+
+// IncreaseCollectCntIfPresent mocks base method.
+func (m *MockInteractiveCache) IncreaseCollectCntIfPresent(ctx context.Context, biz string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncreaseCollectCntIfPresent", ctx, biz, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncreaseCollectCntIfPresent indicates an expected call of IncreaseCollectCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) IncreaseCollectCntIfPresent(ctx, biz, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseCollectCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).IncreaseCollectCntIfPresent), ctx, biz, id)
+}
+
+// IncreaseLikeIfPresent mocks base method.
+func (m *MockInteractiveCache) IncreaseLikeIfPresent(ctx context.Context, biz string, id int64, reaction string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncreaseLikeIfPresent", ctx, biz, id, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncreaseLikeIfPresent indicates an expected call of IncreaseLikeIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) IncreaseLikeIfPresent(ctx, biz, id, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseLikeIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).IncreaseLikeIfPresent), ctx, biz, id, reaction)
+}
+
+// IncreaseUniqueViewCntIfPresent mocks base method.
+func (m *MockInteractiveCache) IncreaseUniqueViewCntIfPresent(ctx context.Context, biz string, id, delta int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncreaseUniqueViewCntIfPresent", ctx, biz, id, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncreaseUniqueViewCntIfPresent indicates an expected call of IncreaseUniqueViewCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) IncreaseUniqueViewCntIfPresent(ctx, biz, id, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseUniqueViewCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).IncreaseUniqueViewCntIfPresent), ctx, biz, id, delta)
+}
+
+// IncreaseViewCountIfPresent mocks base method.
+func (m *MockInteractiveCache) IncreaseViewCountIfPresent(ctx context.Context, biz string, bizId, delta int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncreaseViewCountIfPresent", ctx, biz, bizId, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncreaseViewCountIfPresent indicates an expected call of IncreaseViewCountIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) IncreaseViewCountIfPresent(ctx, biz, bizId, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseViewCountIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).IncreaseViewCountIfPresent), ctx, biz, bizId, delta)
+}
+
+// Set mocks base method.
+func (m *MockInteractiveCache) Set(ctx context.Context, biz string, id int64, res domain.InteractiveCount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, biz, id, res)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockInteractiveCacheMockRecorder) Set(ctx, biz, id, res any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockInteractiveCache)(nil).Set), ctx, biz, id, res)
+}
+
+// SetBatch mocks base method.
+func (m *MockInteractiveCache) SetBatch(ctx context.Context, biz string, inters []domain.InteractiveCount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBatch", ctx, biz, inters)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBatch indicates an expected call of SetBatch.
+func (mr *MockInteractiveCacheMockRecorder) SetBatch(ctx, biz, inters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBatch", reflect.TypeOf((*MockInteractiveCache)(nil).SetBatch), ctx, biz, inters)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/cache/unique_view.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/cache/unique_view.go -package=cachemocks -destination=./webook/internal/repository/cache/mocks/unique_view_mock.go
+//
+
+// Package cachemocks is a generated GoMock package.
+package cachemocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUniqueViewCache is a mock of UniqueViewCache interface.
+type MockUniqueViewCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockUniqueViewCacheMockRecorder
+}
+
+// MockUniqueViewCacheMockRecorder is the mock recorder for MockUniqueViewCache.
+type MockUniqueViewCacheMockRecorder struct {
+	mock *MockUniqueViewCache
+}
+
+// NewMockUniqueViewCache creates a new mock instance.
+func NewMockUniqueViewCache(ctrl *gomock.Controller) *MockUniqueViewCache {
+	mock := &MockUniqueViewCache{ctrl: ctrl}
+	mock.recorder = &MockUniqueViewCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUniqueViewCache) EXPECT() *MockUniqueViewCacheMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockUniqueViewCache) Add(ctx context.Context, biz string, id int64, visitor string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, biz, id, visitor, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockUniqueViewCacheMockRecorder) Add(ctx, biz, id, visitor, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockUniqueViewCache)(nil).Add), ctx, biz, id, visitor, now)
+}
+
+// Counts mocks base method.
+func (m *MockUniqueViewCache) Counts(ctx context.Context, biz string, day time.Time, ids []int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Counts", ctx, biz, day, ids)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Counts indicates an expected call of Counts.
+func (mr *MockUniqueViewCacheMockRecorder) Counts(ctx, biz, day, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Counts", reflect.TypeOf((*MockUniqueViewCache)(nil).Counts), ctx, biz, day, ids)
+}
+
+// Scan mocks base method.
+func (m *MockUniqueViewCache) Scan(ctx context.Context, biz string, day time.Time, cursor uint64, count int64) ([]int64, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scan", ctx, biz, day, cursor, count)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockUniqueViewCacheMockRecorder) Scan(ctx, biz, day, cursor, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockUniqueViewCache)(nil).Scan), ctx, biz, day, cursor, count)
+}
@@ -47,3 +47,28 @@ func (r *RedisRankingCache) Get(ctx context.Context) ([]domain.Article, error) {
 	err = json.Unmarshal(val, &artis)
 	return artis, err
 }
+
+// Remove drop the article from the top n and keep the ttl
+func (r *RedisRankingCache) Remove(ctx context.Context, id int64) error {
+	artis, err := r.Get(ctx)
+	if err == redis.Nil {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	res := make([]domain.Article, 0, len(artis))
+	for _, arti := range artis {
+		if arti.Id != id {
+			res = append(res, arti)
+		}
+	}
+	if len(res) == len(artis) {
+		return nil
+	}
+	val, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, r.key, val, redis.KeepTTL).Err()
+}
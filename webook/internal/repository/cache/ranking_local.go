@@ -45,3 +45,15 @@ func (r *LocalRankingCache) ForceGet(ctx context.Context) ([]domain.Article, err
 	}
 	return artis, nil
 }
+
+func (r *LocalRankingCache) Remove(ctx context.Context, id int64) error {
+	artis := r.topN.Load()
+	res := make([]domain.Article, 0, len(artis))
+	for _, arti := range artis {
+		if arti.Id != id {
+			res = append(res, arti)
+		}
+	}
+	r.topN.Store(res)
+	return nil
+}
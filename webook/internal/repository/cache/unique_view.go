@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+	"time"
+)
+
+// UniqueViewCache count the unique visitors of the resources per day with
+// HyperLogLog, and keep the ids visited on the day for folding them
+type UniqueViewCache interface {
+	// Add count the visitor once on the day of now
+	Add(ctx context.Context, biz string, id int64, visitor string, now time.Time) error
+	// Scan return a page of the ids visited on the day,
+	// cursor 0 is the first page and the end
+	Scan(ctx context.Context, biz string, day time.Time, cursor uint64, count int64) ([]int64, uint64, error)
+	// Counts return the estimated unique visitors of the ids on the day
+	Counts(ctx context.Context, biz string, day time.Time, ids []int64) ([]int64, error)
+}
+
+type RedisUniqueViewCache struct {
+	client redis.Cmdable
+	// expiration is long enough for the day to be folded after it ends
+	expiration time.Duration
+}
+
+func NewRedisUniqueViewCache(client redis.Cmdable) UniqueViewCache {
+	return &RedisUniqueViewCache{
+		client:     client,
+		expiration: 72 * time.Hour,
+	}
+}
+
+func (c *RedisUniqueViewCache) Add(ctx context.Context,
+	biz string, id int64, visitor string, now time.Time) error {
+	hllKey, idsKey := c.key(biz, id, now), c.idsKey(biz, now)
+	pipe := c.client.TxPipeline()
+	pipe.PFAdd(ctx, hllKey, visitor)
+	pipe.Expire(ctx, hllKey, c.expiration)
+	pipe.SAdd(ctx, idsKey, id)
+	pipe.Expire(ctx, idsKey, c.expiration)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *RedisUniqueViewCache) Scan(ctx context.Context,
+	biz string, day time.Time, cursor uint64, count int64) ([]int64, uint64, error) {
+	vals, next, err := c.client.SScan(ctx, c.idsKey(biz, day), cursor, "", count).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+	ids := make([]int64, 0, len(vals))
+	for _, val := range vals {
+		id, er := strconv.ParseInt(val, 10, 64)
+		if er != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, next, nil
+}
+
+func (c *RedisUniqueViewCache) Counts(ctx context.Context,
+	biz string, day time.Time, ids []int64) ([]int64, error) {
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.IntCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.PFCount(ctx, c.key(biz, id, day))
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]int64, len(ids))
+	for i, cmd := range cmds {
+		res[i] = cmd.Val()
+	}
+	return res, nil
+}
+
+func (c *RedisUniqueViewCache) key(biz string, id int64, day time.Time) string {
+	return fmt.Sprintf("unique_view:%s:%d:%s", biz, id, day.Format("20060102"))
+}
+
+func (c *RedisUniqueViewCache) idsKey(biz string, day time.Time) string {
+	return fmt.Sprintf("unique_view:%s:ids:%s", biz, day.Format("20060102"))
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository/dao"
+)
+
+var ErrCollaboratorNotFound = dao.ErrRecordNotFound
+
+type CollaboratorRepository interface {
+	Invite(ctx context.Context, c domain.Collaborator) error
+	// Accept return ErrCollaboratorNotFound if the user is not invited
+	Accept(ctx context.Context, aid int64, uid int64) error
+	// GetRole return CollaboratorRoleUnknown if the user is not
+	// a collaborator, or the invitation is not accepted yet
+	GetRole(ctx context.Context, aid int64, uid int64) (domain.CollaboratorRole, error)
+	List(ctx context.Context, aid int64) ([]domain.Collaborator, error)
+	Remove(ctx context.Context, aid int64, uid int64) error
+	DeleteByArticleIds(ctx context.Context, aids []int64) error
+}
+
+type CollaboratorRepo struct {
+	dao dao.CollaboratorDAO
+}
+
+func NewCollaboratorRepo(dao dao.CollaboratorDAO) CollaboratorRepository {
+	return &CollaboratorRepo{
+		dao: dao,
+	}
+}
+
+func (r *CollaboratorRepo) Invite(ctx context.Context, c domain.Collaborator) error {
+	return r.dao.Upsert(ctx, dao.ArticleCollaborator{
+		ArticleId: c.ArticleId,
+		Uid:       c.Uid,
+		Role:      uint8(c.Role),
+	})
+}
+
+func (r *CollaboratorRepo) Accept(ctx context.Context, aid int64, uid int64) error {
+	return r.dao.Accept(ctx, aid, uid)
+}
+
+func (r *CollaboratorRepo) GetRole(ctx context.Context,
+	aid int64, uid int64) (domain.CollaboratorRole, error) {
+	c, err := r.dao.Get(ctx, aid, uid)
+	if errors.Is(err, dao.ErrRecordNotFound) {
+		return domain.CollaboratorRoleUnknown, nil
+	}
+	if err != nil {
+		return domain.CollaboratorRoleUnknown, err
+	}
+	if c.Accepted == 0 {
+		return domain.CollaboratorRoleUnknown, nil
+	}
+	return domain.CollaboratorRole(c.Role), nil
+}
+
+func (r *CollaboratorRepo) List(ctx context.Context,
+	aid int64) ([]domain.Collaborator, error) {
+	cs, err := r.dao.ListByArticleId(ctx, aid)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Collaborator, 0, len(cs))
+	for _, c := range cs {
+		res = append(res, domain.Collaborator{
+			ArticleId: c.ArticleId,
+			Uid:       c.Uid,
+			Role:      domain.CollaboratorRole(c.Role),
+			Accepted:  c.Accepted == 1,
+			Ctime:     time.UnixMilli(c.Ctime),
+			Utime:     time.UnixMilli(c.Utime),
+		})
+	}
+	return res, nil
+}
+
+func (r *CollaboratorRepo) Remove(ctx context.Context, aid int64, uid int64) error {
+	return r.dao.Delete(ctx, aid, uid)
+}
+
+func (r *CollaboratorRepo) DeleteByArticleIds(ctx context.Context, aids []int64) error {
+	return r.dao.DeleteByArticleIds(ctx, aids)
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository/cache"
+	"webook/webook/internal/repository/dao"
+	"webook/webook/pkg/logger"
+)
+
+var ErrCollectionNotFound = dao.ErrRecordNotFound
+
+type CollectionRepository interface {
+	Create(ctx context.Context, c domain.Collection) (int64, error)
+	// Update return ErrCollectionNotFound if the user has no such collection
+	Update(ctx context.Context, c domain.Collection) error
+	// Delete remove the collection with its items
+	Delete(ctx context.Context, uid int64, id int64) error
+	GetById(ctx context.Context, id int64) (domain.Collection, error)
+	List(ctx context.Context, uid int64, onlyPublic bool) ([]domain.Collection, error)
+	ListItems(ctx context.Context, uid int64, cid int64, biz string, offset int64, limit int64) ([]domain.CollectionItem, error)
+	// MoveItem return ErrCollectionNotFound if the item is not in from
+	MoveItem(ctx context.Context, uid int64, biz string, bizId int64, from int64, to int64) error
+}
+
+// CollectionRepo the collections are not cached, but removing the items
+// changes the collect_cnt cached by InteractiveCache
+type CollectionRepo struct {
+	dao        dao.CollectionDAO
+	interCache cache.InteractiveCache
+	l          logger.Logger
+}
+
+func NewCollectionRepo(dao dao.CollectionDAO,
+	interCache cache.InteractiveCache, l logger.Logger) CollectionRepository {
+	return &CollectionRepo{
+		dao:        dao,
+		interCache: interCache,
+		l:          l,
+	}
+}
+
+func (r *CollectionRepo) Create(ctx context.Context, c domain.Collection) (int64, error) {
+	return r.dao.Insert(ctx, r.toEntity(c))
+}
+
+func (r *CollectionRepo) Update(ctx context.Context, c domain.Collection) error {
+	return r.dao.Update(ctx, r.toEntity(c))
+}
+
+func (r *CollectionRepo) Delete(ctx context.Context, uid int64, id int64) error {
+	items, err := r.dao.Delete(ctx, uid, id)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		r.decreaseCollectCnt(ctx, item.Biz, item.BizId)
+	}
+	return nil
+}
+
+func (r *CollectionRepo) GetById(ctx context.Context, id int64) (domain.Collection, error) {
+	c, err := r.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.Collection{}, err
+	}
+	return r.toDomain(c), nil
+}
+
+func (r *CollectionRepo) List(ctx context.Context,
+	uid int64, onlyPublic bool) ([]domain.Collection, error) {
+	cs, err := r.dao.ListByUid(ctx, uid, onlyPublic)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Collection, 0, len(cs))
+	for _, c := range cs {
+		res = append(res, r.toDomain(c))
+	}
+	return res, nil
+}
+
+func (r *CollectionRepo) ListItems(ctx context.Context,
+	uid int64, cid int64, biz string, offset int64, limit int64) ([]domain.CollectionItem, error) {
+	items, err := r.dao.ListItems(ctx, uid, cid, biz, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.CollectionItem, 0, len(items))
+	for _, item := range items {
+		res = append(res, domain.CollectionItem{
+			Cid:   item.Cid,
+			Biz:   item.Biz,
+			BizId: item.BizId,
+			Ctime: time.UnixMilli(item.Ctime),
+		})
+	}
+	return res, nil
+}
+
+func (r *CollectionRepo) MoveItem(ctx context.Context,
+	uid int64, biz string, bizId int64, from int64, to int64) error {
+	return r.dao.MoveItem(ctx, uid, biz, bizId, from, to)
+}
+
+// decreaseCollectCnt the database is changed already, so the failure is
+// only logged, the cache expires soon anyway
+func (r *CollectionRepo) decreaseCollectCnt(ctx context.Context, biz string, bizId int64) {
+	err := r.interCache.DecreaseCollectCntIfPresent(ctx, biz, bizId)
+	if err != nil {
+		r.l.Error("Failed to decrease collect count cache",
+			logger.Error(err),
+			logger.String("biz", biz),
+			logger.Int64("bizId", bizId))
+	}
+}
+
+func (r *CollectionRepo) toEntity(c domain.Collection) dao.Collection {
+	var public uint8
+	if c.Public {
+		public = 1
+	}
+	return dao.Collection{
+		Id:     c.Id,
+		Uid:    c.Uid,
+		Name:   c.Name,
+		Public: public,
+	}
+}
+
+func (r *CollectionRepo) toDomain(c dao.Collection) domain.Collection {
+	return domain.Collection{
+		Id:     c.Id,
+		Uid:    c.Uid,
+		Name:   c.Name,
+		Public: c.Public == 1,
+		Ctime:  time.UnixMilli(c.Ctime),
+		Utime:  time.UnixMilli(c.Utime),
+	}
+}
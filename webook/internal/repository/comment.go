@@ -10,7 +10,9 @@ import (
 type CommentRepository interface {
 	Create(ctx context.Context, comment domain.Comment) (int64, error)
 	GetByArticleId(ctx context.Context, articleId int64, offset int64, limit int64) ([]domain.Comment, error)
+	GetByArticleIdAfter(ctx context.Context, articleId int64, cursor domain.Cursor, limit int64) ([]domain.Comment, error)
 	DeleteById(ctx context.Context, id int64, userId int64) error
+	DeleteByArticleIds(ctx context.Context, articleIds []int64) error
 }
 
 type CommentRepo struct {
@@ -43,10 +45,26 @@ func (r *CommentRepo) GetByArticleId(ctx context.Context,
 	return r.toDomain(comments), nil
 }
 
+func (r *CommentRepo) GetByArticleIdAfter(ctx context.Context,
+	articleId int64, cursor domain.Cursor, limit int64) ([]domain.Comment, error) {
+
+	utime, id := toCursorArgs(cursor)
+	comments, err := r.dao.GetByArticleIdAfter(ctx, articleId, utime, id, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomain(comments), nil
+}
+
 func (r *CommentRepo) DeleteById(ctx context.Context, id int64, userId int64) error {
 	return r.dao.DeleteById(ctx, id, userId)
 }
 
+func (r *CommentRepo) DeleteByArticleIds(ctx context.Context, articleIds []int64) error {
+	return r.dao.DeleteByArticleIds(ctx, articleIds)
+}
+
 func (r *CommentRepo) toDomain(comments []dao.Comment) []domain.Comment {
 	res := make([]domain.Comment, 0, len(comments))
 	for _, comment := range comments {
@@ -0,0 +1,12 @@
+package repository
+
+import "webook/webook/internal/domain"
+
+// toCursorArgs convert the cursor to the (utime, id) of the DAO,
+// the zero cursor is (0, 0)
+func toCursorArgs(c domain.Cursor) (int64, int64) {
+	if c.IsZero() {
+		return 0, 0
+	}
+	return c.Utime.UnixMilli(), c.Id
+}
@@ -1,4 +1,296 @@
 package dao
 
+import (
+	"context"
+	"errors"
+	"time"
+	"webook/webook/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// ArticleAuthorDAO is the write side of articles, used by the author and
+// the collaborators. UpdateById and UpdateStatus don't check who is updating,
+// the service checks the permission before
 type ArticleAuthorDAO interface {
+	Insert(ctx context.Context, arti Article) (int64, error)
+	// UpdateById update the draft if arti.Version is the current version,
+	// otherwise return ErrArticleVersionConflict
+	UpdateById(ctx context.Context, arti Article) error
+	UpdateStatus(ctx context.Context, id int64, status uint8) error
+	GetByAuthor(ctx context.Context, uid int64, offset int64, limit int64) ([]Article, error)
+	// GetByAuthorAfter is the keyset version of GetByAuthor, it return the
+	// articles after (utime, id), utime == 0 means the first page
+	GetByAuthorAfter(ctx context.Context, uid int64, utime int64, id int64, limit int64) ([]Article, error)
+	GetById(ctx context.Context, id int64) (Article, error)
+	ListScheduled(ctx context.Context, before int64, limit int64) ([]Article, error)
+	// PublishScheduled mark a due scheduled article as published,
+	// return false if it's not scheduled or not due
+	PublishScheduled(ctx context.Context, id int64, now int64) (Article, bool, error)
+	CancelSchedule(ctx context.Context, uid int64, id int64) error
+	// Delete move the article to the trash, the other methods
+	// ignore the deleted articles except the ones about the trash
+	Delete(ctx context.Context, uid int64, id int64, now int64) error
+	// Restore move the article out of the trash if it's deleted after the
+	// given time, otherwise return ErrArticleNotInTrash
+	Restore(ctx context.Context, uid int64, id int64, after int64) error
+	ListDeleted(ctx context.Context, uid int64, offset int64, limit int64) ([]Article, error)
+	// ListExpired return the articles deleted before the given time
+	ListExpired(ctx context.Context, before int64, limit int64) ([]Article, error)
+	// Purge remove the articles and their tags for good
+	Purge(ctx context.Context, ids []int64) error
+}
+
+type GORMArticleAuthorDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMArticleAuthorDAO(db *gorm.DB) ArticleAuthorDAO {
+	return &GORMArticleAuthorDAO{
+		db: db,
+	}
+}
+
+func (d *GORMArticleAuthorDAO) Insert(ctx context.Context, arti Article) (int64, error) {
+	now := time.Now().UnixMilli()
+	arti.Ctime = now
+	arti.Utime = now
+	arti.Version = 1
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&arti).Error
+		if err != nil {
+			return err
+		}
+		return setArticleTags(tx, "article_tags", arti.Id, arti.Tags)
+	})
+	return arti.Id, err
+}
+
+func (d *GORMArticleAuthorDAO) UpdateById(ctx context.Context, arti Article) error {
+	now := time.Now().UnixMilli()
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Article{}).
+			Where("id =? AND version = ? AND deleted_at = 0",
+				arti.Id, arti.Version).
+			Updates(map[string]any{
+				"title":      arti.Title,
+				"content":    arti.Content,
+				"utime":      now,
+				"status":     arti.Status,
+				"publish_at": arti.PublishAt,
+				"version":    gorm.Expr("version + 1")})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			var cnt int64
+			err := tx.Model(&Article{}).
+				Where("id =? AND deleted_at = 0", arti.Id).
+				Count(&cnt).Error
+			if err != nil {
+				return err
+			}
+			if cnt > 0 {
+				return ErrArticleVersionConflict
+			}
+			return errors.New("update article fail, Id is wrong")
+		}
+		return setArticleTags(tx, "article_tags", arti.Id, arti.Tags)
+	})
+}
+
+func (d *GORMArticleAuthorDAO) UpdateStatus(ctx context.Context,
+	id int64, status uint8) error {
+	res := d.db.WithContext(ctx).Model(&Article{}).
+		Where("id = ? AND deleted_at = 0", id).
+		Updates(map[string]any{
+			"utime":  time.Now().UnixMilli(),
+			"status": status,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected != 1 {
+		return errors.New("id is wrong")
+	}
+	return nil
+}
+
+func (d *GORMArticleAuthorDAO) GetByAuthor(ctx context.Context,
+	uid int64, offset int64, limit int64) ([]Article, error) {
+
+	var artis []Article
+	err := d.db.WithContext(ctx).
+		Where("author_id = ? AND deleted_at = 0", uid).
+		Offset(int(offset)).Limit(int(limit)).
+		Order("utime DESC, id DESC").
+		Find(&artis).Error
+	if err != nil {
+		return nil, err
+	}
+	return artis, fillTags(ctx, d.db, "article_tags", artis)
+}
+
+func (d *GORMArticleAuthorDAO) GetByAuthorAfter(ctx context.Context,
+	uid int64, utime int64, id int64, limit int64) ([]Article, error) {
+
+	var artis []Article
+	err := afterCursor(d.db.WithContext(ctx), "articles", utime, id).
+		Where("author_id = ? AND deleted_at = 0", uid).
+		Limit(int(limit)).
+		Find(&artis).Error
+	if err != nil {
+		return nil, err
+	}
+	return artis, fillTags(ctx, d.db, "article_tags", artis)
+}
+
+func (d *GORMArticleAuthorDAO) GetById(ctx context.Context,
+	id int64) (Article, error) {
+	var arti Article
+	err := d.db.WithContext(ctx).
+		Where("id = ? AND deleted_at = 0", id).
+		First(&arti).Error
+	if err != nil {
+		return Article{}, err
+	}
+	tags, err := getArticleTags(ctx, d.db, "article_tags", []int64{id})
+	arti.Tags = tags[id]
+	return arti, err
+}
+
+func (d *GORMArticleAuthorDAO) ListScheduled(ctx context.Context,
+	before int64, limit int64) ([]Article, error) {
+	var artis []Article
+	err := d.db.WithContext(ctx).
+		Where("status = ? AND publish_at <= ? AND deleted_at = 0",
+			domain.ArticleStatusScheduled, before).
+		Order("publish_at ASC").
+		Limit(int(limit)).
+		Find(&artis).Error
+	return artis, err
+}
+
+// PublishScheduled check the status in the update, so an article is
+// published only once even if the job runs on several instances,
+// or the author cancels it at the same time.
+func (d *GORMArticleAuthorDAO) PublishScheduled(ctx context.Context,
+	id int64, now int64) (Article, bool, error) {
+	var (
+		arti      Article
+		published bool
+	)
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Article{}).
+			Where("id = ? AND status = ? AND publish_at <= ? AND deleted_at = 0",
+				id, domain.ArticleStatusScheduled, now).
+			Updates(map[string]any{
+				"utime":  now,
+				"status": domain.ArticleStatusPublished,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+
+		err := tx.Where("id = ?", id).First(&arti).Error
+		if err != nil {
+			return err
+		}
+		tags, err := getArticleTags(ctx, tx, "article_tags", []int64{id})
+		if err != nil {
+			return err
+		}
+		arti.Tags = tags[id]
+		published = true
+		return nil
+	})
+	return arti, published, err
+}
+
+func (d *GORMArticleAuthorDAO) CancelSchedule(ctx context.Context,
+	uid int64, id int64) error {
+	res := d.db.WithContext(ctx).Model(&Article{}).
+		Where("id = ? AND author_id = ? AND status = ? AND deleted_at = 0",
+			id, uid, domain.ArticleStatusScheduled).
+		Updates(map[string]any{
+			"utime":      time.Now().UnixMilli(),
+			"status":     domain.ArticleStatusUnpublished,
+			"publish_at": 0,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrArticleNotScheduled
+	}
+	return nil
+}
+
+func (d *GORMArticleAuthorDAO) Delete(ctx context.Context,
+	uid int64, id int64, now int64) error {
+	res := d.db.WithContext(ctx).Model(&Article{}).
+		Where("id = ? AND author_id = ? AND deleted_at = 0", id, uid).
+		Update("deleted_at", now)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected != 1 {
+		return errors.New("id or author is wrong")
+	}
+	return nil
+}
+
+func (d *GORMArticleAuthorDAO) Restore(ctx context.Context,
+	uid int64, id int64, after int64) error {
+	res := d.db.WithContext(ctx).Model(&Article{}).
+		Where("id = ? AND author_id = ? AND deleted_at > ?", id, uid, after).
+		Update("deleted_at", 0)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrArticleNotInTrash
+	}
+	return nil
+}
+
+func (d *GORMArticleAuthorDAO) ListDeleted(ctx context.Context,
+	uid int64, offset int64, limit int64) ([]Article, error) {
+	var artis []Article
+	err := d.db.WithContext(ctx).
+		Where("author_id = ? AND deleted_at > 0", uid).
+		Order("deleted_at DESC").
+		Offset(int(offset)).Limit(int(limit)).
+		Find(&artis).Error
+	if err != nil {
+		return nil, err
+	}
+	return artis, fillTags(ctx, d.db, "article_tags", artis)
+}
+
+func (d *GORMArticleAuthorDAO) ListExpired(ctx context.Context,
+	before int64, limit int64) ([]Article, error) {
+	var artis []Article
+	err := d.db.WithContext(ctx).
+		Where("deleted_at > 0 AND deleted_at < ?", before).
+		Order("deleted_at ASC").
+		Limit(int(limit)).
+		Find(&artis).Error
+	return artis, err
+}
+
+func (d *GORMArticleAuthorDAO) Purge(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("article_id IN ?", ids).Delete(&ArticleTag{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("id IN ? AND deleted_at > 0", ids).Delete(&Article{}).Error
+	})
 }
@@ -0,0 +1,175 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"regexp"
+	"testing"
+)
+
+func TestGORMArticleAuthorDAO_UpdateById(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(t *testing.T) *sql.DB
+
+		arti Article
+
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles`").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM `article_tags`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				return db
+			},
+			arti: Article{Id: 1, AuthorId: 123, Version: 2},
+		},
+		{
+			name: "stale version",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT count").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectRollback()
+				return db
+			},
+			arti:    Article{Id: 1, AuthorId: 123, Version: 1},
+			wantErr: ErrArticleVersionConflict,
+		},
+		{
+			name: "wrong id",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT count").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectRollback()
+				return db
+			},
+			arti:    Article{Id: 1, AuthorId: 123, Version: 2},
+			wantErr: errors.New("update article fail, Id is wrong"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqldb := tc.mock(t)
+			db, err := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      sqldb,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				SkipDefaultTransaction: true,
+				DisableAutomaticPing:   true,
+			})
+			assert.NoError(t, err)
+			dao := NewGORMArticleAuthorDAO(db)
+			err = dao.UpdateById(context.Background(), tc.arti)
+
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestGORMArticleAuthorDAO_GetByAuthorAfter(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(t *testing.T) *sql.DB
+
+		utime int64
+		id    int64
+
+		wantIds []int64
+		wantErr error
+	}{
+		{
+			name: "first page",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE author_id = ? AND deleted_at = 0 " +
+					"ORDER BY articles.utime DESC, articles.id DESC LIMIT 2")).
+					WithArgs(123).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "author_id", "utime"}).
+						AddRow(3, 123, 200).AddRow(2, 123, 100))
+				mock.ExpectQuery("SELECT rel.article_id, tags.name").
+					WillReturnRows(sqlmock.NewRows([]string{"article_id", "name"}))
+				return db
+			},
+			wantIds: []int64{3, 2},
+		},
+		{
+			name: "after cursor",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE "+
+					"(articles.utime < ? OR (articles.utime = ? AND articles.id < ?)) AND (author_id = ? AND deleted_at = 0) "+
+					"ORDER BY articles.utime DESC, articles.id DESC LIMIT 2")).
+					WithArgs(100, 100, 2, 123).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "author_id", "utime"}).
+						AddRow(1, 123, 100))
+				mock.ExpectQuery("SELECT rel.article_id, tags.name").
+					WillReturnRows(sqlmock.NewRows([]string{"article_id", "name"}))
+				return db
+			},
+			utime:   100,
+			id:      2,
+			wantIds: []int64{1},
+		},
+		{
+			name: "db error",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `articles`").
+					WillReturnError(errors.New("db error"))
+				return db
+			},
+			utime:   100,
+			id:      2,
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqldb := tc.mock(t)
+			db, err := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      sqldb,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				SkipDefaultTransaction: true,
+				DisableAutomaticPing:   true,
+			})
+			assert.NoError(t, err)
+			dao := NewGORMArticleAuthorDAO(db)
+			artis, err := dao.GetByAuthorAfter(context.Background(), 123, tc.utime, tc.id, 2)
+
+			assert.Equal(t, tc.wantErr, err)
+			ids := make([]int64, 0, len(artis))
+			for _, arti := range artis {
+				ids = append(ids, arti.Id)
+			}
+			if tc.wantErr == nil {
+				assert.Equal(t, tc.wantIds, ids)
+			}
+		})
+	}
+}
@@ -1,4 +1,237 @@
 package dao
 
+import (
+	"context"
+	"time"
+	"webook/webook/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ArticleReaderDAO is the read side of articles, it only keeps
+// the articles which have been published
 type ArticleReaderDAO interface {
+	// Upsert create or overwrite the public copy of the article
+	Upsert(ctx context.Context, arti PublicArticle) error
+	UpdateStatus(ctx context.Context, id int64, status uint8) error
+	GetPubById(ctx context.Context, id int64) (PublicArticle, error)
+	// ListPubByIds return the public copies of ids in the statuses,
+	// the deleted ones are skipped. The order is not kept
+	ListPubByIds(ctx context.Context, ids []int64, statuses []uint8) ([]Article, error)
+	ListPub(ctx context.Context, start time.Time, offset int64, limit int64) ([]Article, error)
+	// ListPubAfter is the keyset version of ListPub, it return the
+	// articles after (utime, id), utime == 0 means the first page
+	ListPubAfter(ctx context.Context, utime int64, id int64, limit int64) ([]Article, error)
+	ListPubByTag(ctx context.Context, tag string, start time.Time, offset int64, limit int64) ([]Article, error)
+	// ListPubByAuthor is ListPub of the author
+	ListPubByAuthor(ctx context.Context, uid int64, start time.Time, offset int64, limit int64) ([]Article, error)
+	ListPopularTags(ctx context.Context, limit int64) ([]TagCount, error)
+	// Delete hide the public copy until it's restored or purged
+	Delete(ctx context.Context, id int64, now int64) error
+	Restore(ctx context.Context, id int64) error
+	// Purge remove the public copies and their tags for good
+	Purge(ctx context.Context, ids []int64) error
+}
+
+// GORMArticleReaderDAO writes to db, and reads from replica
+// which may lag behind it
+type GORMArticleReaderDAO struct {
+	db      *gorm.DB
+	replica *gorm.DB
+}
+
+func NewGORMArticleReaderDAO(db *gorm.DB) ArticleReaderDAO {
+	return NewGORMArticleReaderDAOWithReplica(db, db)
+}
+
+// NewGORMArticleReaderDAOWithReplica read the public articles from
+// replica, such as a read replica of db
+func NewGORMArticleReaderDAOWithReplica(db *gorm.DB, replica *gorm.DB) ArticleReaderDAO {
+	return &GORMArticleReaderDAO{
+		db:      db,
+		replica: replica,
+	}
+}
+
+func (d *GORMArticleReaderDAO) Upsert(ctx context.Context, arti PublicArticle) error {
+	now := time.Now().UnixMilli()
+	arti.Ctime = now
+	arti.Utime = now
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"title":   arti.Title,
+				"content": arti.Content,
+				"utime":   now,
+				"status":  arti.Status,
+			}),
+		}).Create(&arti).Error
+		if err != nil {
+			return err
+		}
+		return setArticleTags(tx, "public_article_tags", arti.Id, arti.Tags)
+	})
+}
+
+func (d *GORMArticleReaderDAO) UpdateStatus(ctx context.Context,
+	id int64, status uint8) error {
+	return d.db.WithContext(ctx).Model(&PublicArticle{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"utime":  time.Now().UnixMilli(),
+			"status": status,
+		}).Error
+}
+
+func (d *GORMArticleReaderDAO) GetPubById(ctx context.Context,
+	id int64) (PublicArticle, error) {
+	var arti PublicArticle
+	err := d.replica.WithContext(ctx).
+		Where("id = ? AND deleted_at = 0", id).
+		First(&arti).Error
+	if err != nil {
+		return PublicArticle{}, err
+	}
+	tags, err := getArticleTags(ctx, d.replica, "public_article_tags", []int64{id})
+	arti.Tags = tags[id]
+	return arti, err
+}
+
+func (d *GORMArticleReaderDAO) ListPubByIds(ctx context.Context,
+	ids []int64, statuses []uint8) ([]Article, error) {
+	var artis []Article
+	err := d.replica.WithContext(ctx).
+		Table("public_articles").
+		Select("public_articles.*, users.nick_name as author_name").
+		Joins("LEFT JOIN users ON public_articles.author_id = users.id").
+		Where("public_articles.id IN ? AND public_articles.status IN ? AND public_articles.deleted_at = 0",
+			ids, statuses).
+		Find(&artis).Error
+	if err != nil {
+		return nil, err
+	}
+	return artis, fillTags(ctx, d.replica, "public_article_tags", artis)
+}
+
+func (d *GORMArticleReaderDAO) ListPub(ctx context.Context,
+	start time.Time, offset int64, limit int64) ([]Article, error) {
+	var artis []Article
+	err := d.replica.WithContext(ctx).
+		Table("public_articles").
+		Select("public_articles.*, users.nick_name as author_name").
+		Joins("LEFT JOIN users ON public_articles.author_id = users.id").
+		Where("public_articles.status = ? AND public_articles.deleted_at = 0 AND public_articles.utime < ?",
+			domain.ArticleStatusPublished, start.UnixMilli()).
+		Order("public_articles.utime DESC, public_articles.id DESC").
+		Offset(int(offset)).
+		Limit(int(limit)).
+		Find(&artis).Error
+	if err != nil {
+		return nil, err
+	}
+	return artis, fillTags(ctx, d.replica, "public_article_tags", artis)
+}
+
+func (d *GORMArticleReaderDAO) ListPubAfter(ctx context.Context,
+	utime int64, id int64, limit int64) ([]Article, error) {
+	var artis []Article
+	err := afterCursor(d.replica.WithContext(ctx), "public_articles", utime, id).
+		Table("public_articles").
+		Select("public_articles.*, users.nick_name as author_name").
+		Joins("LEFT JOIN users ON public_articles.author_id = users.id").
+		Where("public_articles.status = ? AND public_articles.deleted_at = 0",
+			domain.ArticleStatusPublished).
+		Limit(int(limit)).
+		Find(&artis).Error
+	if err != nil {
+		return nil, err
+	}
+	return artis, fillTags(ctx, d.replica, "public_article_tags", artis)
+}
+
+func (d *GORMArticleReaderDAO) ListPubByTag(ctx context.Context, tag string,
+	start time.Time, offset int64, limit int64) ([]Article, error) {
+	var artis []Article
+	err := d.replica.WithContext(ctx).
+		Table("public_articles").
+		Select("public_articles.*, users.nick_name as author_name").
+		Joins("JOIN public_article_tags ON public_article_tags.article_id = public_articles.id").
+		Joins("JOIN tags ON tags.id = public_article_tags.tag_id").
+		Joins("LEFT JOIN users ON public_articles.author_id = users.id").
+		Where("tags.name = ? AND public_articles.status = ? AND public_articles.deleted_at = 0 "+
+			"AND public_articles.utime < ?",
+			tag, domain.ArticleStatusPublished, start.UnixMilli()).
+		Order("public_articles.utime DESC").
+		Offset(int(offset)).
+		Limit(int(limit)).
+		Find(&artis).Error
+	if err != nil {
+		return nil, err
+	}
+	return artis, fillTags(ctx, d.replica, "public_article_tags", artis)
+}
+
+func (d *GORMArticleReaderDAO) ListPubByAuthor(ctx context.Context, uid int64,
+	start time.Time, offset int64, limit int64) ([]Article, error) {
+	var artis []Article
+	err := d.replica.WithContext(ctx).
+		Table("public_articles").
+		Select("public_articles.*, users.nick_name as author_name").
+		Joins("LEFT JOIN users ON public_articles.author_id = users.id").
+		Where("public_articles.author_id = ? AND public_articles.status = ? "+
+			"AND public_articles.deleted_at = 0 AND public_articles.utime < ?",
+			uid, domain.ArticleStatusPublished, start.UnixMilli()).
+		Order("public_articles.utime DESC, public_articles.id DESC").
+		Offset(int(offset)).
+		Limit(int(limit)).
+		Find(&artis).Error
+	if err != nil {
+		return nil, err
+	}
+	return artis, fillTags(ctx, d.replica, "public_article_tags", artis)
+}
+
+// ListPopularTags return the tags used by most published articles
+func (d *GORMArticleReaderDAO) ListPopularTags(ctx context.Context,
+	limit int64) ([]TagCount, error) {
+	var res []TagCount
+	err := d.replica.WithContext(ctx).
+		Table("public_article_tags").
+		Select("tags.id, tags.name, COUNT(*) AS cnt").
+		Joins("JOIN tags ON tags.id = public_article_tags.tag_id").
+		Joins("JOIN public_articles ON public_articles.id = public_article_tags.article_id").
+		Where("public_articles.status = ? AND public_articles.deleted_at = 0",
+			domain.ArticleStatusPublished).
+		Group("tags.id, tags.name").
+		Order("cnt DESC").
+		Limit(int(limit)).
+		Scan(&res).Error
+	return res, err
+}
+
+func (d *GORMArticleReaderDAO) Delete(ctx context.Context, id int64, now int64) error {
+	return d.db.WithContext(ctx).Model(&PublicArticle{}).
+		Where("id = ? AND deleted_at = 0", id).
+		Update("deleted_at", now).Error
+}
+
+func (d *GORMArticleReaderDAO) Restore(ctx context.Context, id int64) error {
+	return d.db.WithContext(ctx).Model(&PublicArticle{}).
+		Where("id = ?", id).
+		Update("deleted_at", 0).Error
+}
+
+func (d *GORMArticleReaderDAO) Purge(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("article_id IN ?", ids).Delete(&PublicArticleTag{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("id IN ?", ids).Delete(&PublicArticle{}).Error
+	})
 }
@@ -0,0 +1,75 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ArticleRevision is an immutable snapshot of an article,
+// written on every save or publish and never updated.
+type ArticleRevision struct {
+	Id        int64  `gorm:"primaryKey;autoIncrement"`
+	ArticleId int64  `gorm:"index:aid_ctime"`
+	AuthorId  int64  `gorm:"index"`
+	Title     string `gorm:"type:varchar(256)"`
+	Content   string `gorm:"type:longtext"`
+	Status    uint8
+
+	Ctime int64 `gorm:"index:aid_ctime"`
+}
+
+type ArticleRevisionDAO interface {
+	Insert(ctx context.Context, rev ArticleRevision) (int64, error)
+	GetByArticle(ctx context.Context, aid int64, uid int64, offset int64, limit int64) ([]ArticleRevision, error)
+	GetById(ctx context.Context, id int64) (ArticleRevision, error)
+	DeleteByArticleIds(ctx context.Context, aids []int64) error
+}
+
+type GORMArticleRevisionDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMArticleRevisionDAO(db *gorm.DB) ArticleRevisionDAO {
+	return &GORMArticleRevisionDAO{
+		db: db,
+	}
+}
+
+func (d *GORMArticleRevisionDAO) Insert(ctx context.Context,
+	rev ArticleRevision) (int64, error) {
+	rev.Ctime = time.Now().UnixMilli()
+	err := d.db.WithContext(ctx).Create(&rev).Error
+	return rev.Id, err
+}
+
+func (d *GORMArticleRevisionDAO) GetByArticle(ctx context.Context,
+	aid int64, uid int64, offset int64, limit int64) ([]ArticleRevision, error) {
+	var revs []ArticleRevision
+	err := d.db.WithContext(ctx).
+		Where("article_id = ? AND author_id = ?", aid, uid).
+		Order("id DESC").
+		Offset(int(offset)).Limit(int(limit)).
+		Find(&revs).Error
+	return revs, err
+}
+
+func (d *GORMArticleRevisionDAO) GetById(ctx context.Context,
+	id int64) (ArticleRevision, error) {
+	var rev ArticleRevision
+	err := d.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&rev).Error
+	return rev, err
+}
+
+func (d *GORMArticleRevisionDAO) DeleteByArticleIds(ctx context.Context,
+	aids []int64) error {
+	if len(aids) == 0 {
+		return nil
+	}
+	return d.db.WithContext(ctx).
+		Where("article_id IN ?", aids).
+		Delete(&ArticleRevision{}).Error
+}
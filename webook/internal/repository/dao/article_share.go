@@ -0,0 +1,84 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ArticleShare the token is signed from the id, so it's not stored,
+// revoking a share deletes it
+type ArticleShare struct {
+	Id        int64 `gorm:"primaryKey;autoIncrement"`
+	ArticleId int64 `gorm:"index"`
+	Draft     bool
+	// ExpireAt is 0 if it never expires
+	ExpireAt int64
+	Ctime    int64
+	Utime    int64
+}
+
+type ArticleShareDAO interface {
+	Insert(ctx context.Context, s ArticleShare) (int64, error)
+	GetById(ctx context.Context, id int64) (ArticleShare, error)
+	ListByArticleId(ctx context.Context, aid int64) ([]ArticleShare, error)
+	// Delete return ErrRecordNotFound if the article has no such share
+	Delete(ctx context.Context, aid int64, id int64) error
+	DeleteByArticleIds(ctx context.Context, aids []int64) error
+}
+
+type GORMArticleShareDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMArticleShareDAO(db *gorm.DB) ArticleShareDAO {
+	return &GORMArticleShareDAO{
+		db: db,
+	}
+}
+
+func (d *GORMArticleShareDAO) Insert(ctx context.Context, s ArticleShare) (int64, error) {
+	now := time.Now().UnixMilli()
+	s.Ctime = now
+	s.Utime = now
+	err := d.db.WithContext(ctx).Create(&s).Error
+	return s.Id, err
+}
+
+func (d *GORMArticleShareDAO) GetById(ctx context.Context, id int64) (ArticleShare, error) {
+	var s ArticleShare
+	err := d.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&s).Error
+	return s, err
+}
+
+func (d *GORMArticleShareDAO) ListByArticleId(ctx context.Context,
+	aid int64) ([]ArticleShare, error) {
+	var res []ArticleShare
+	err := d.db.WithContext(ctx).
+		Where("article_id = ?", aid).
+		Order("id DESC").
+		Find(&res).Error
+	return res, err
+}
+
+func (d *GORMArticleShareDAO) Delete(ctx context.Context, aid int64, id int64) error {
+	res := d.db.WithContext(ctx).
+		Where("id = ? AND article_id = ?", id, aid).
+		Delete(&ArticleShare{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+func (d *GORMArticleShareDAO) DeleteByArticleIds(ctx context.Context, aids []int64) error {
+	return d.db.WithContext(ctx).
+		Where("article_id IN ?", aids).
+		Delete(&ArticleShare{}).Error
+}
@@ -0,0 +1,105 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ArticleCollaborator the author of the article is not in it,
+// the author is always the owner
+type ArticleCollaborator struct {
+	Id        int64 `gorm:"primaryKey;autoIncrement"`
+	ArticleId int64 `gorm:"uniqueIndex:idx_article_uid"`
+	Uid       int64 `gorm:"uniqueIndex:idx_article_uid;index"`
+	Role      uint8
+	// Accepted is 1 if the invitation is accepted
+	Accepted uint8
+	Ctime    int64
+	Utime    int64
+}
+
+type CollaboratorDAO interface {
+	// Upsert invite the user again if the user is invited,
+	// the invitation need to be accepted again
+	Upsert(ctx context.Context, c ArticleCollaborator) error
+	// Accept return ErrRecordNotFound if there is no such invitation
+	Accept(ctx context.Context, aid int64, uid int64) error
+	Get(ctx context.Context, aid int64, uid int64) (ArticleCollaborator, error)
+	ListByArticleId(ctx context.Context, aid int64) ([]ArticleCollaborator, error)
+	Delete(ctx context.Context, aid int64, uid int64) error
+	DeleteByArticleIds(ctx context.Context, aids []int64) error
+}
+
+type GORMCollaboratorDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMCollaboratorDAO(db *gorm.DB) CollaboratorDAO {
+	return &GORMCollaboratorDAO{
+		db: db,
+	}
+}
+
+func (d *GORMCollaboratorDAO) Upsert(ctx context.Context, c ArticleCollaborator) error {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	c.Accepted = 0
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"role":     c.Role,
+			"accepted": 0,
+			"utime":    now,
+		}),
+	}).Create(&c).Error
+}
+
+func (d *GORMCollaboratorDAO) Accept(ctx context.Context, aid int64, uid int64) error {
+	res := d.db.WithContext(ctx).Model(&ArticleCollaborator{}).
+		Where("article_id = ? AND uid = ?", aid, uid).
+		Updates(map[string]any{
+			"accepted": 1,
+			"utime":    time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+func (d *GORMCollaboratorDAO) Get(ctx context.Context,
+	aid int64, uid int64) (ArticleCollaborator, error) {
+	var c ArticleCollaborator
+	err := d.db.WithContext(ctx).
+		Where("article_id = ? AND uid = ?", aid, uid).
+		First(&c).Error
+	return c, err
+}
+
+func (d *GORMCollaboratorDAO) ListByArticleId(ctx context.Context,
+	aid int64) ([]ArticleCollaborator, error) {
+	var res []ArticleCollaborator
+	err := d.db.WithContext(ctx).
+		Where("article_id = ?", aid).
+		Order("id ASC").
+		Find(&res).Error
+	return res, err
+}
+
+func (d *GORMCollaboratorDAO) Delete(ctx context.Context, aid int64, uid int64) error {
+	return d.db.WithContext(ctx).
+		Where("article_id = ? AND uid = ?", aid, uid).
+		Delete(&ArticleCollaborator{}).Error
+}
+
+func (d *GORMCollaboratorDAO) DeleteByArticleIds(ctx context.Context, aids []int64) error {
+	return d.db.WithContext(ctx).
+		Where("article_id IN ?", aids).
+		Delete(&ArticleCollaborator{}).Error
+}
@@ -0,0 +1,201 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Collection the items are UserCollectionBiz with its id as Cid
+type Collection struct {
+	Id   int64  `gorm:"primaryKey;autoIncrement"`
+	Uid  int64  `gorm:"index"`
+	Name string `gorm:"type:varchar(64)"`
+	// Public is 1 if the others can see it
+	Public uint8
+	Ctime  int64
+	Utime  int64
+}
+
+type CollectionDAO interface {
+	Insert(ctx context.Context, c Collection) (int64, error)
+	// Update return ErrRecordNotFound if the user has no such collection
+	Update(ctx context.Context, c Collection) error
+	// Delete remove the collection with its items. It returns the items
+	// in none of the other collections of the user, their collect_cnt
+	// is decreased
+	Delete(ctx context.Context, uid int64, id int64) ([]UserCollectionBiz, error)
+	GetById(ctx context.Context, id int64) (Collection, error)
+	// ListByUid list all the collections of the user, or only the public ones
+	ListByUid(ctx context.Context, uid int64, onlyPublic bool) ([]Collection, error)
+	// ListItems return the items of the biz in the collection, the latest first
+	ListItems(ctx context.Context, uid int64, cid int64, biz string, offset int64, limit int64) ([]UserCollectionBiz, error)
+	// MoveItem move the item from a collection to another, return
+	// ErrRecordNotFound if it's not in from. If it's in to already, it's
+	// removed from from, the user still collects it either way
+	MoveItem(ctx context.Context, uid int64, biz string, bizId int64, from int64, to int64) error
+}
+
+type GORMCollectionDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMCollectionDAO(db *gorm.DB) CollectionDAO {
+	return &GORMCollectionDAO{
+		db: db,
+	}
+}
+
+func (d *GORMCollectionDAO) Insert(ctx context.Context, c Collection) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	err := d.db.WithContext(ctx).Create(&c).Error
+	return c.Id, err
+}
+
+func (d *GORMCollectionDAO) Update(ctx context.Context, c Collection) error {
+	res := d.db.WithContext(ctx).Model(&Collection{}).
+		Where("id = ? AND uid = ?", c.Id, c.Uid).
+		Updates(map[string]any{
+			"name":   c.Name,
+			"public": c.Public,
+			"utime":  time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+func (d *GORMCollectionDAO) Delete(ctx context.Context,
+	uid int64, id int64) ([]UserCollectionBiz, error) {
+	var uncollected []UserCollectionBiz
+	now := time.Now().UnixMilli()
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND uid = ?", id, uid).Delete(&Collection{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+		// The counts are locked in the same order by everyone
+		var items []UserCollectionBiz
+		err := tx.Where("uid = ? AND cid = ?", uid, id).
+			Order("biz, biz_id").
+			Find(&items).Error
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			err = lockCollectCnt(tx, item.Biz, item.BizId, now)
+			if err != nil {
+				return err
+			}
+			res = tx.Where("uid = ? AND cid = ? AND biz = ? AND biz_id = ?",
+				uid, id, item.Biz, item.BizId).
+				Delete(&UserCollectionBiz{})
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				// Removed by another request
+				continue
+			}
+			cnt, err := countUserCollections(tx, item.Biz, item.BizId, uid)
+			if err != nil {
+				return err
+			}
+			if cnt > 0 {
+				continue
+			}
+			uncollected = append(uncollected, item)
+			err = addCollectCnt(tx, item.Biz, item.BizId, -1, now)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uncollected, nil
+}
+
+func (d *GORMCollectionDAO) GetById(ctx context.Context, id int64) (Collection, error) {
+	var c Collection
+	err := d.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&c).Error
+	return c, err
+}
+
+func (d *GORMCollectionDAO) ListByUid(ctx context.Context,
+	uid int64, onlyPublic bool) ([]Collection, error) {
+	var res []Collection
+	query := d.db.WithContext(ctx).Where("uid = ?", uid)
+	if onlyPublic {
+		query = query.Where("public = ?", 1)
+	}
+	err := query.Order("id ASC").Find(&res).Error
+	return res, err
+}
+
+func (d *GORMCollectionDAO) ListItems(ctx context.Context,
+	uid int64, cid int64, biz string, offset int64, limit int64) ([]UserCollectionBiz, error) {
+	var res []UserCollectionBiz
+	err := d.db.WithContext(ctx).
+		Where("uid = ? AND cid = ? AND biz = ?", uid, cid, biz).
+		Order("utime DESC").Order("biz_id DESC").
+		Offset(int(offset)).
+		Limit(int(limit)).
+		Find(&res).Error
+	return res, err
+}
+
+func (d *GORMCollectionDAO) MoveItem(ctx context.Context,
+	uid int64, biz string, bizId int64, from int64, to int64) error {
+	now := time.Now().UnixMilli()
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cnt int64
+		err := tx.Model(&UserCollectionBiz{}).
+			Where("uid = ? AND biz = ? AND biz_id = ? AND cid = ?", uid, biz, bizId, to).
+			Count(&cnt).Error
+		if err != nil {
+			return err
+		}
+		source := tx.Where("uid = ? AND biz = ? AND biz_id = ? AND cid = ?",
+			uid, biz, bizId, from)
+		if cnt == 0 {
+			// Moving it updates utime, so it's the latest in the new one
+			res := source.Model(&UserCollectionBiz{}).
+				Updates(map[string]any{
+					"cid":   to,
+					"utime": now,
+				})
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return ErrRecordNotFound
+			}
+			return nil
+		}
+
+		// It's merged into to, the collect_cnt is not changed
+		res := source.Delete(&UserCollectionBiz{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+		return nil
+	})
+}
@@ -11,17 +11,21 @@ import (
 type Comment struct {
 	Id        int64  `gorm:"primaryKey;autoIncrement"`
 	Content   string `gorm:"type:text"`
-	ArticleId int64  `gorm:"index"`
+	ArticleId int64  `gorm:"index:idx_article_utime,priority:1"`
 	UserId    int64  `gorm:"index"`
 	UserName  string `gorm:"type:varchar(128)"`
 	Ctime     int64
-	Utime     int64
+	Utime     int64 `gorm:"index:idx_article_utime,priority:2"`
 }
 
 type CommentDAO interface {
 	Insert(ctx context.Context, comment Comment) (int64, error)
 	GetByArticleId(ctx context.Context, articleId int64, offset int64, limit int64) ([]Comment, error)
+	// GetByArticleIdAfter return the comments after (utime, id),
+	// utime == 0 means the first page
+	GetByArticleIdAfter(ctx context.Context, articleId int64, utime int64, id int64, limit int64) ([]Comment, error)
 	DeleteById(ctx context.Context, id int64, userId int64) error
+	DeleteByArticleIds(ctx context.Context, articleIds []int64) error
 }
 
 type GORMCommentDAO struct {
@@ -53,6 +57,16 @@ func (d *GORMCommentDAO) GetByArticleId(ctx context.Context, articleId int64, of
 	return comments, err
 }
 
+func (d *GORMCommentDAO) GetByArticleIdAfter(ctx context.Context,
+	articleId int64, utime int64, id int64, limit int64) ([]Comment, error) {
+	var comments []Comment
+	err := afterCursor(d.db.WithContext(ctx), "comments", utime, id).
+		Where("article_id = ?", articleId).
+		Limit(int(limit)).
+		Find(&comments).Error
+	return comments, err
+}
+
 func (d *GORMCommentDAO) DeleteById(ctx context.Context, id int64, userId int64) error {
 	res := d.db.WithContext(ctx).
 		Where("id = ? AND user_id = ?", id, userId).
@@ -65,3 +79,12 @@ func (d *GORMCommentDAO) DeleteById(ctx context.Context, id int64, userId int64)
 	}
 	return nil
 }
+
+func (d *GORMCommentDAO) DeleteByArticleIds(ctx context.Context, articleIds []int64) error {
+	if len(articleIds) == 0 {
+		return nil
+	}
+	return d.db.WithContext(ctx).
+		Where("article_id IN ?", articleIds).
+		Delete(&Comment{}).Error
+}
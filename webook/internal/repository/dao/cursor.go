@@ -0,0 +1,34 @@
+package dao
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"gorm.io/gorm"
+)
+
+// afterCursor add the keyset condition of (utime, id) desc on table,
+// utime == 0 means the first page
+func afterCursor(db *gorm.DB, table string, utime int64, id int64) *gorm.DB {
+	if utime != 0 {
+		db = db.Where(fmt.Sprintf("%[1]s.utime < ? OR (%[1]s.utime = ? AND %[1]s.id < ?)", table),
+			utime, utime, id)
+	}
+	return db.Order(fmt.Sprintf("%[1]s.utime DESC, %[1]s.id DESC", table))
+}
+
+// afterCursorFilter is the MongoDB version of afterCursor
+func afterCursorFilter(filter bson.D, utime int64, id int64) bson.D {
+	if utime == 0 {
+		return filter
+	}
+	return append(filter, bson.E{Key: "$or", Value: bson.A{
+		bson.D{{Key: "utime", Value: bson.D{{Key: "$lt", Value: utime}}}},
+		bson.D{
+			{Key: "utime", Value: utime},
+			{Key: "id", Value: bson.D{{Key: "$lt", Value: id}}},
+		},
+	}})
+}
+
+var cursorSort = bson.D{{Key: "utime", Value: -1}, {Key: "id", Value: -1}}
@@ -16,9 +16,22 @@ func InitTables(db *gorm.DB) error {
 		&Article{},
 		&PublicArticle{},
 		&InteractiveCount{},
+		&InteractiveReaction{},
 		&UserLikeBiz{},
 		&UserCollectionBiz{},
 		&Comment{},
+		&ArticleRevision{},
+		&Tag{},
+		&ArticleTag{},
+		&PublicArticleTag{},
+		&Upload{},
+		&Series{},
+		&SeriesArticle{},
+		&ArticleCollaborator{},
+		&ModerationItem{},
+		&DailyUniqueView{},
+		&ArticleShare{},
+		&Collection{},
 	)
 }
 
@@ -33,7 +46,15 @@ func InitCollection(mdb *mongo.Database) error {
 			Options: options.Index().SetUnique(true),
 		},
 		{
-			Keys: bson.D{{"author_id", 1}},
+			Keys: bson.D{{"author_id", 1}, {"utime", -1}, {"id", -1}},
+		},
+		{
+			Keys: bson.D{{"status", 1}, {"publish_at", 1}},
+		},
+		{
+			Keys: bson.D{{"deleted_at", 1}},
+			Options: options.Index().SetPartialFilterExpression(
+				bson.D{{"deleted_at", bson.D{{"$exists", true}}}}),
 		},
 	})
 	if err != nil {
@@ -48,6 +69,12 @@ func InitCollection(mdb *mongo.Database) error {
 		{
 			Keys: bson.D{{"author_id", 1}},
 		},
+		{
+			Keys: bson.D{{"status", 1}, {"utime", -1}, {"id", -1}},
+		},
+		{
+			Keys: bson.D{{"tags", 1}},
+		},
 	})
 	if err != nil {
 		return err
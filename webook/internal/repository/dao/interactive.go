@@ -1,8 +1,11 @@
 package dao
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"slices"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -11,15 +14,44 @@ import (
 
 type InteractiveDAO interface {
 	IncreaseViewCount(ctx context.Context, biz string, bizId int64) error
-	IncreaseViewCountBatch(ctx context.Context, bizs []string, ids []int64) error
-	InsertLikeInfo(ctx context.Context, biz string, id int64, uid int64) error
-	DeleteLikeInfo(ctx context.Context, biz string, id int64, uid int64) error
-	InsertCollectionBiz(ctx context.Context, biz string, id int64, cid int64, uid int64) error
-	DeleteCollectionBiz(ctx context.Context, biz string, id int64, uid int64) error
+	// IncreaseViewCountBatch add the ViewCnt of each to the counts in one upsert
+	IncreaseViewCountBatch(ctx context.Context, cnts []InteractiveCount) error
+	// InsertLikeInfo set the reaction of the user, a user has one reaction
+	// at most. It returns the reaction replaced, "" if there is none
+	InsertLikeInfo(ctx context.Context, biz string, id int64, uid int64, reaction string) (string, error)
+	// DeleteLikeInfo return the reaction removed, "" if there is none
+	DeleteLikeInfo(ctx context.Context, biz string, id int64, uid int64) (string, error)
+	// InsertCollectionBiz put the resource into the collection. The
+	// collect_cnt counts the users, so it's increased and true is returned
+	// only if the resource is in none of the collections of the user yet
+	InsertCollectionBiz(ctx context.Context, biz string, id int64, cid int64, uid int64) (bool, error)
+	// DeleteCollectionBiz remove the resource from all the collections of
+	// the user, and return false if it's in none of them
+	DeleteCollectionBiz(ctx context.Context, biz string, id int64, uid int64) (bool, error)
 	GetLikeInfo(ctx context.Context, biz string, id int64, uid int64) (UserLikeBiz, error)
+	// ListLikers return the active likes of the resource after the cursor,
+	// ordered by (utime, id) desc. utime 0 is the first page
+	ListLikers(ctx context.Context, biz string, id int64, utime int64, lid int64, limit int64) ([]UserLikeBiz, error)
+	// ListLiked is ListLikers for the likes of the user
+	ListLiked(ctx context.Context, biz string, uid int64, utime int64, lid int64, limit int64) ([]UserLikeBiz, error)
 	GetCollectInfo(ctx context.Context, biz string, id int64, uid int64) (UserCollectionBiz, error)
 	Get(ctx context.Context, biz string, id int64) (InteractiveCount, error)
+	GetReactions(ctx context.Context, biz string, id int64) ([]InteractiveReaction, error)
+	GetReactionsByIds(ctx context.Context, biz string, ids []int64) ([]InteractiveReaction, error)
+	// GetUserStates return the resources liked or collected by the user
+	// among the ids in one query
+	GetUserStates(ctx context.Context, biz string, uid int64, ids []int64) ([]UserBizState, error)
 	GetByIds(ctx context.Context, biz string, ids []int64) ([]InteractiveCount, error)
+	// DeleteByBizIds remove the counts, reactions and collections of the resources
+	DeleteByBizIds(ctx context.Context, biz string, ids []int64) error
+	// ReconcileCounts recompute the like, reaction and collect counts of a
+	// batch after afterId, from the likes and the collections. The diffs are
+	// repaired in the same transaction under the row locks unless dryRun
+	ReconcileCounts(ctx context.Context, biz string, afterId int64, limit int, dryRun bool) (ReconcileBatch, error)
+	// SetDailyUniqueViews save the unique views of the resources on the day,
+	// and add the differences to the totals. Saving the same counts again
+	// changes nothing. It returns the differences which are not 0
+	SetDailyUniqueViews(ctx context.Context, biz string, day int64, cnts map[int64]int64) (map[int64]int64, error)
 }
 
 type InteractiveCount struct {
@@ -28,9 +60,38 @@ type InteractiveCount struct {
 	Biz   string `gorm:"type:varchar(128);uniqueIndex:biz_type_id"`
 	BizId int64  `gorm:"uniqueIndex:biz_type_id"`
 
-	ViewCnt    int64
-	LikeCnt    int64
-	CollectCnt int64
+	ViewCnt       int64
+	UniqueViewCnt int64
+	LikeCnt       int64
+	CollectCnt    int64
+
+	Ctime int64
+	Utime int64
+}
+
+// InteractiveReaction is the count of a reaction, the sum of them
+// is InteractiveCount.LikeCnt
+type InteractiveReaction struct {
+	Id int64 `gorm:"primaryKey,autoIncrement"`
+
+	Biz      string `gorm:"type:varchar(128);uniqueIndex:biz_type_id_reaction"`
+	BizId    int64  `gorm:"uniqueIndex:biz_type_id_reaction"`
+	Reaction string `gorm:"type:varchar(32);uniqueIndex:biz_type_id_reaction"`
+	Cnt      int64
+
+	Ctime int64
+	Utime int64
+}
+
+// DailyUniqueView is the unique visitors of a resource on a day,
+// Day is the start of the day in unix ms
+type DailyUniqueView struct {
+	Id int64 `gorm:"primaryKey,autoIncrement"`
+
+	Biz   string `gorm:"type:varchar(128);uniqueIndex:biz_type_id_day"`
+	BizId int64  `gorm:"uniqueIndex:biz_type_id_day"`
+	Day   int64  `gorm:"uniqueIndex:biz_type_id_day"`
+	Cnt   int64
 
 	Ctime int64
 	Utime int64
@@ -39,14 +100,18 @@ type InteractiveCount struct {
 type UserLikeBiz struct {
 	Id int64 `gorm:"primaryKey,autoIncrement"`
 
-	Uid   int64  `gorm:"uniqueIndex:biz_type_id"`
-	BizId int64  `gorm:"uniqueIndex:biz_type_id"`
-	Biz   string `gorm:"type:varchar(128);uniqueIndex:biz_type_id"`
+	// uid_utime and biz_id_utime are for the listings
+	Uid   int64  `gorm:"uniqueIndex:biz_type_id;index:uid_utime"`
+	BizId int64  `gorm:"uniqueIndex:biz_type_id;index:biz_id_utime"`
+	Biz   string `gorm:"type:varchar(128);uniqueIndex:biz_type_id;index:uid_utime;index:biz_id_utime"`
 
 	Status int64
+	// Reaction is kept after it's canceled, the likes
+	// before the reactions are "like"
+	Reaction string `gorm:"type:varchar(32);default:'like'"`
 
 	Ctime int64
-	Utime int64
+	Utime int64 `gorm:"index:uid_utime;index:biz_id_utime"`
 }
 
 type UserCollectionBiz struct {
@@ -60,6 +125,40 @@ type UserCollectionBiz struct {
 	Utime int64
 }
 
+// UserBizState is a resource liked or collected by the user, Kind is
+// UserBizStateLike or UserBizStateCollect, Reaction is only of the likes
+type UserBizState struct {
+	BizId    int64
+	Kind     string
+	Reaction string
+}
+
+const (
+	UserBizStateLike    = "like"
+	UserBizStateCollect = "collect"
+)
+
+// CounterDiff is a counter of BizId which should be New
+type CounterDiff struct {
+	BizId   int64
+	Counter string
+	Old     int64
+	New     int64
+}
+
+type ReconcileBatch struct {
+	LastId  int64
+	Scanned int
+	Diffs   []CounterDiff
+}
+
+const (
+	CounterLike    = "like_cnt"
+	CounterCollect = "collect_cnt"
+	// CounterReactionPrefix is followed by the reaction
+	CounterReactionPrefix = "reaction:"
+)
+
 type GORMInteractiveDAO struct {
 	db *gorm.DB
 }
@@ -89,40 +188,72 @@ func (d *GORMInteractiveDAO) IncreaseViewCount(ctx context.Context,
 }
 
 func (d *GORMInteractiveDAO) IncreaseViewCountBatch(ctx context.Context,
-	bizs []string, ids []int64) error {
-	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		txDAO := NewGORMInteractiveDAO(tx)
-		for i := range bizs {
-			err := txDAO.IncreaseViewCount(ctx, bizs[i], ids[i])
-			if err != nil {
-				return err
-			}
-		}
+	cnts []InteractiveCount) error {
+	if len(cnts) == 0 {
 		return nil
-	})
+	}
+	now := time.Now().UnixMilli()
+	rows := make([]InteractiveCount, len(cnts))
+	for i, cnt := range cnts {
+		rows[i] = InteractiveCount{
+			Biz:     cnt.Biz,
+			BizId:   cnt.BizId,
+			ViewCnt: cnt.ViewCnt,
+			Ctime:   now,
+			Utime:   now,
+		}
+	}
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"view_cnt": gorm.Expr("view_cnt + VALUES(view_cnt)"),
+			"utime":    now,
+		}),
+	}).Create(&rows).Error
 }
 
 func (d *GORMInteractiveDAO) InsertLikeInfo(ctx context.Context,
-	biz string, id int64, uid int64) error {
-	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	biz string, id int64, uid int64, reaction string) (string, error) {
+	var prev string
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		now := time.Now().UnixMilli()
-		err := tx.Clauses(clause.OnConflict{
-			DoUpdates: clause.Assignments(map[string]interface{}{
-				"status": 1,
-				"utime":  now,
-			}),
-		}).Create(&UserLikeBiz{
-			Uid:    uid,
-			BizId:  id,
-			Biz:    biz,
-			Status: 1,
-			Utime:  now,
-			Ctime:  now,
-		}).Error
+		// The row is created canceled before it's locked, so the concurrent
+		// first likes of the user wait for each other on the row instead
+		// of both finding nothing
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&UserLikeBiz{
+				Uid:      uid,
+				BizId:    id,
+				Biz:      biz,
+				Reaction: reaction,
+				Utime:    now,
+				Ctime:    now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		prev, err = d.lockReaction(tx, biz, id, uid)
+		if err != nil || prev == reaction {
+			return err
+		}
+		err = tx.Model(&UserLikeBiz{}).
+			Where("uid = ? AND biz = ? AND biz_id = ?", uid, biz, id).
+			Updates(map[string]interface{}{
+				"status":   1,
+				"reaction": reaction,
+				"utime":    now,
+			}).Error
 		if err != nil {
 			return err
 		}
-		return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		if prev != "" {
+			// Changing the reaction, the user still likes it
+			err = d.addReactionCnt(tx, biz, id, prev, -1, now)
+			if err != nil {
+				return err
+			}
+			return d.addReactionCnt(tx, biz, id, reaction, 1, now)
+		}
+		err = tx.Clauses(clause.OnConflict{
 			DoUpdates: clause.Assignments(map[string]interface{}{
 				"like_cnt": gorm.Expr("like_cnt + 1"),
 				"utime":    now,
@@ -134,14 +265,25 @@ func (d *GORMInteractiveDAO) InsertLikeInfo(ctx context.Context,
 			Ctime:   now,
 			Utime:   now,
 		}).Error
+		if err != nil {
+			return err
+		}
+		return d.addReactionCnt(tx, biz, id, reaction, 1, now)
 	})
+	return prev, err
 }
 
 func (d *GORMInteractiveDAO) DeleteLikeInfo(ctx context.Context,
-	biz string, id int64, uid int64) error {
-	now := time.Now().UnixMilli()
-	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := tx.Model(&UserLikeBiz{}).
+	biz string, id int64, uid int64) (string, error) {
+	var prev string
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		var err error
+		prev, err = d.lockReaction(tx, biz, id, uid)
+		if err != nil || prev == "" {
+			return err
+		}
+		err = tx.Model(&UserLikeBiz{}).
 			Where("uid = ? AND biz = ? AND biz_id = ?", uid, biz, id).
 			Updates(map[string]interface{}{
 				"status": 0,
@@ -150,61 +292,155 @@ func (d *GORMInteractiveDAO) DeleteLikeInfo(ctx context.Context,
 		if err != nil {
 			return err
 		}
-		return tx.Model(&InteractiveCount{}).
+		err = tx.Model(&InteractiveCount{}).
 			Where("biz = ? AND biz_id = ?", biz, id).
 			Updates(map[string]interface{}{
 				"like_cnt": gorm.Expr("like_cnt - 1"),
 				"utime":    now,
 			}).Error
+		if err != nil {
+			return err
+		}
+		return d.addReactionCnt(tx, biz, id, prev, -1, now)
 	})
+	return prev, err
+}
+
+// lockReaction lock the like of the user in the transaction,
+// and return the active reaction, "" if there is none
+func (d *GORMInteractiveDAO) lockReaction(tx *gorm.DB,
+	biz string, id int64, uid int64) (string, error) {
+	var like UserLikeBiz
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("uid = ? AND biz = ? AND biz_id = ?", uid, biz, id).
+		First(&like).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if like.Status != 1 {
+		return "", nil
+	}
+	return like.Reaction, nil
+}
+
+// addReactionCnt only creates the count when it's increased
+func (d *GORMInteractiveDAO) addReactionCnt(tx *gorm.DB,
+	biz string, id int64, reaction string, delta int64, now int64) error {
+	if delta < 0 {
+		return tx.Model(&InteractiveReaction{}).
+			Where("biz = ? AND biz_id = ? AND reaction = ?", biz, id, reaction).
+			Updates(map[string]interface{}{
+				"cnt":   gorm.Expr("cnt + ?", delta),
+				"utime": now,
+			}).Error
+	}
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"cnt":   gorm.Expr("cnt + ?", delta),
+			"utime": now,
+		}),
+	}).Create(&InteractiveReaction{
+		Biz:      biz,
+		BizId:    id,
+		Reaction: reaction,
+		Cnt:      delta,
+		Ctime:    now,
+		Utime:    now,
+	}).Error
 }
 
 func (d *GORMInteractiveDAO) InsertCollectionBiz(ctx context.Context,
-	biz string, id int64, cid int64, uid int64) error {
+	biz string, id int64, cid int64, uid int64) (bool, error) {
+	collected := false
 	now := time.Now().UnixMilli()
-	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := tx.Create(&UserCollectionBiz{
-			Biz:   biz,
-			BizId: id,
-			Uid:   uid,
-			Cid:   cid,
-			Ctime: now,
-			Utime: now,
-		}).Error
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := lockCollectCnt(tx, biz, id, now)
 		if err != nil {
 			return err
 		}
-		return d.db.WithContext(ctx).Clauses(clause.OnConflict{
-			DoUpdates: clause.Assignments(map[string]interface{}{
-				"collect_cnt": gorm.Expr("collect_cnt + 1"),
-				"utime":       now,
-			}),
-		}).Create(&InteractiveCount{
-			Biz:        biz,
-			BizId:      id,
-			CollectCnt: 1,
-			Ctime:      now,
-			Utime:      now,
-		}).Error
+		cnt, err := countUserCollections(tx, biz, id, uid)
+		if err != nil {
+			return err
+		}
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&UserCollectionBiz{
+				Biz:   biz,
+				BizId: id,
+				Uid:   uid,
+				Cid:   cid,
+				Ctime: now,
+				Utime: now,
+			})
+		// It's in the collection already, or the user has it in another one
+		if res.Error != nil || res.RowsAffected == 0 || cnt > 0 {
+			return res.Error
+		}
+		collected = true
+		return addCollectCnt(tx, biz, id, 1, now)
 	})
+	return collected, err
 }
 
 func (d *GORMInteractiveDAO) DeleteCollectionBiz(ctx context.Context,
-	biz string, id int64, uid int64) error {
+	biz string, id int64, uid int64) (bool, error) {
+	uncollected := false
 	now := time.Now().UnixMilli()
-	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := tx.Where("uid = ? AND biz = ? AND biz_id = ?", uid, biz, id).
-			Delete(&UserCollectionBiz{}).Error
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := lockCollectCnt(tx, biz, id, now)
 		if err != nil {
 			return err
 		}
-		return tx.Model(&InteractiveCount{}).
-			Where("biz = ? AND biz_id = ?", biz, id).
-			Updates(map[string]interface{}{
-				"collect_cnt": gorm.Expr("collect_cnt - 1"),
-				"utime":       now,
-			}).Error
+		res := tx.Where("uid = ? AND biz = ? AND biz_id = ?", uid, biz, id).
+			Delete(&UserCollectionBiz{})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		uncollected = true
+		// The user is counted once however many collections it's in
+		return addCollectCnt(tx, biz, id, -1, now)
 	})
+	return uncollected, err
+}
+
+// lockCollectCnt lock the count of the resource, it's created if absent.
+// The collections of the resource are changed after it, so the concurrent
+// changes of a user don't both find the resource uncollected
+func lockCollectCnt(tx *gorm.DB, biz string, id int64, now int64) error {
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&InteractiveCount{
+			Biz:   biz,
+			BizId: id,
+			Ctime: now,
+			Utime: now,
+		}).Error
+	if err != nil {
+		return err
+	}
+	var cnt InteractiveCount
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("biz = ? AND biz_id = ?", biz, id).
+		First(&cnt).Error
+}
+
+// countUserCollections return how many collections of the user have the resource
+func countUserCollections(tx *gorm.DB, biz string, id int64, uid int64) (int64, error) {
+	var cnt int64
+	err := tx.Model(&UserCollectionBiz{}).
+		Where("uid = ? AND biz = ? AND biz_id = ?", uid, biz, id).
+		Count(&cnt).Error
+	return cnt, err
+}
+
+func addCollectCnt(tx *gorm.DB, biz string, id int64, delta int64, now int64) error {
+	return tx.Model(&InteractiveCount{}).
+		Where("biz = ? AND biz_id = ?", biz, id).
+		Updates(map[string]interface{}{
+			"collect_cnt": gorm.Expr("collect_cnt + ?", delta),
+			"utime":       now,
+		}).Error
 }
 
 func (d *GORMInteractiveDAO) GetLikeInfo(ctx context.Context,
@@ -248,6 +484,59 @@ func (d *GORMInteractiveDAO) Get(ctx context.Context,
 	return count, err
 }
 
+func (d *GORMInteractiveDAO) ListLikers(ctx context.Context,
+	biz string, id int64, utime int64, lid int64, limit int64) ([]UserLikeBiz, error) {
+	var res []UserLikeBiz
+	err := afterCursor(d.db.WithContext(ctx), "user_like_bizs", utime, lid).
+		Where("biz = ? AND biz_id = ? AND status = ?", biz, id, 1).
+		Limit(int(limit)).
+		Find(&res).Error
+	return res, err
+}
+
+func (d *GORMInteractiveDAO) ListLiked(ctx context.Context,
+	biz string, uid int64, utime int64, lid int64, limit int64) ([]UserLikeBiz, error) {
+	var res []UserLikeBiz
+	err := afterCursor(d.db.WithContext(ctx), "user_like_bizs", utime, lid).
+		Where("uid = ? AND biz = ? AND status = ?", uid, biz, 1).
+		Limit(int(limit)).
+		Find(&res).Error
+	return res, err
+}
+
+func (d *GORMInteractiveDAO) GetReactions(ctx context.Context,
+	biz string, id int64) ([]InteractiveReaction, error) {
+	var res []InteractiveReaction
+	err := d.db.WithContext(ctx).
+		Where("biz = ? AND biz_id = ?", biz, id).
+		Find(&res).Error
+	return res, err
+}
+
+func (d *GORMInteractiveDAO) GetReactionsByIds(ctx context.Context,
+	biz string, ids []int64) ([]InteractiveReaction, error) {
+	var res []InteractiveReaction
+	err := d.db.WithContext(ctx).
+		Where("biz = ? AND biz_id IN ?", biz, ids).
+		Find(&res).Error
+	return res, err
+}
+
+func (d *GORMInteractiveDAO) GetUserStates(ctx context.Context,
+	biz string, uid int64, ids []int64) ([]UserBizState, error) {
+	var res []UserBizState
+	err := d.db.WithContext(ctx).Raw(
+		"SELECT biz_id, ? AS kind, reaction FROM user_like_bizs "+
+			"WHERE uid = ? AND biz = ? AND biz_id IN ? AND status = 1 "+
+			"UNION ALL "+
+			"SELECT DISTINCT biz_id, ? AS kind, '' AS reaction FROM user_collection_bizs "+
+			"WHERE uid = ? AND biz = ? AND biz_id IN ?",
+		UserBizStateLike, uid, biz, ids,
+		UserBizStateCollect, uid, biz, ids).
+		Scan(&res).Error
+	return res, err
+}
+
 func (d *GORMInteractiveDAO) GetByIds(ctx context.Context,
 	biz string, ids []int64) ([]InteractiveCount, error) {
 	var counts []InteractiveCount
@@ -256,3 +545,255 @@ func (d *GORMInteractiveDAO) GetByIds(ctx context.Context,
 		Find(&counts).Error
 	return counts, err
 }
+
+func (d *GORMInteractiveDAO) ReconcileCounts(ctx context.Context,
+	biz string, afterId int64, limit int, dryRun bool) (ReconcileBatch, error) {
+	var res ReconcileBatch
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The counts and the reactions are locked before the likes are
+		// counted, so the likes committed while waiting are counted too
+		locked := func() *gorm.DB {
+			if dryRun {
+				return tx
+			}
+			return tx.Clauses(clause.Locking{Strength: "UPDATE"})
+		}
+		var cnts []InteractiveCount
+		err := locked().Where("biz = ? AND id > ?", biz, afterId).
+			Order("id").Limit(limit).
+			Find(&cnts).Error
+		if err != nil || len(cnts) == 0 {
+			return err
+		}
+		res.Scanned = len(cnts)
+		res.LastId = cnts[len(cnts)-1].Id
+		ids := make([]int64, len(cnts))
+		for i, cnt := range cnts {
+			ids[i] = cnt.BizId
+		}
+		var reactions []InteractiveReaction
+		err = locked().Where("biz = ? AND biz_id IN ?", biz, ids).
+			Find(&reactions).Error
+		if err != nil {
+			return err
+		}
+
+		type groupCnt struct {
+			BizId    int64
+			Reaction string
+			Cnt      int64
+		}
+		var likeCnts []groupCnt
+		err = tx.Model(&UserLikeBiz{}).
+			Select("biz_id, reaction, COUNT(*) AS cnt").
+			Where("biz = ? AND biz_id IN ? AND status = ?", biz, ids, 1).
+			Group("biz_id, reaction").
+			Scan(&likeCnts).Error
+		if err != nil {
+			return err
+		}
+		var collectCnts []groupCnt
+		// A user may have it in several collections, but is counted once
+		err = tx.Model(&UserCollectionBiz{}).
+			Select("biz_id, COUNT(DISTINCT uid) AS cnt").
+			Where("biz = ? AND biz_id IN ?", biz, ids).
+			Group("biz_id").
+			Scan(&collectCnts).Error
+		if err != nil {
+			return err
+		}
+
+		wantLikes := make(map[int64]int64, len(cnts))
+		wantReactions := make(map[int64]map[string]int64, len(cnts))
+		for _, c := range likeCnts {
+			wantLikes[c.BizId] += c.Cnt
+			if wantReactions[c.BizId] == nil {
+				wantReactions[c.BizId] = make(map[string]int64)
+			}
+			wantReactions[c.BizId][c.Reaction] = c.Cnt
+		}
+		wantCollects := make(map[int64]int64, len(cnts))
+		for _, c := range collectCnts {
+			wantCollects[c.BizId] = c.Cnt
+		}
+
+		for _, cnt := range cnts {
+			if cnt.LikeCnt != wantLikes[cnt.BizId] {
+				res.Diffs = append(res.Diffs, CounterDiff{BizId: cnt.BizId,
+					Counter: CounterLike, Old: cnt.LikeCnt, New: wantLikes[cnt.BizId]})
+			}
+			if cnt.CollectCnt != wantCollects[cnt.BizId] {
+				res.Diffs = append(res.Diffs, CounterDiff{BizId: cnt.BizId,
+					Counter: CounterCollect, Old: cnt.CollectCnt, New: wantCollects[cnt.BizId]})
+			}
+		}
+		olds := make(map[int64]map[string]int64, len(reactions))
+		for _, r := range reactions {
+			if olds[r.BizId] == nil {
+				olds[r.BizId] = make(map[string]int64)
+			}
+			olds[r.BizId][r.Reaction] = r.Cnt
+			if r.Cnt != wantReactions[r.BizId][r.Reaction] {
+				res.Diffs = append(res.Diffs, CounterDiff{BizId: r.BizId,
+					Counter: CounterReactionPrefix + r.Reaction,
+					Old:     r.Cnt, New: wantReactions[r.BizId][r.Reaction]})
+			}
+		}
+		// The reactions without the counts, such as the likes
+		// before the reactions
+		for _, id := range ids {
+			for reaction, want := range wantReactions[id] {
+				if _, ok := olds[id][reaction]; !ok {
+					res.Diffs = append(res.Diffs, CounterDiff{BizId: id,
+						Counter: CounterReactionPrefix + reaction, New: want})
+				}
+			}
+		}
+		slices.SortFunc(res.Diffs, func(x, y CounterDiff) int {
+			if x.BizId != y.BizId {
+				return cmp.Compare(x.BizId, y.BizId)
+			}
+			return cmp.Compare(x.Counter, y.Counter)
+		})
+		if dryRun {
+			return nil
+		}
+		return d.repairCounts(tx, biz, res.Diffs)
+	})
+	return res, err
+}
+
+func (d *GORMInteractiveDAO) repairCounts(tx *gorm.DB, biz string, diffs []CounterDiff) error {
+	now := time.Now().UnixMilli()
+	for _, diff := range diffs {
+		var err error
+		if reaction, ok := strings.CutPrefix(diff.Counter, CounterReactionPrefix); ok {
+			err = tx.Clauses(clause.OnConflict{
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"cnt":   diff.New,
+					"utime": now,
+				}),
+			}).Create(&InteractiveReaction{
+				Biz:      biz,
+				BizId:    diff.BizId,
+				Reaction: reaction,
+				Cnt:      diff.New,
+				Ctime:    now,
+				Utime:    now,
+			}).Error
+		} else {
+			err = tx.Model(&InteractiveCount{}).
+				Where("biz = ? AND biz_id = ?", biz, diff.BizId).
+				Updates(map[string]interface{}{
+					diff.Counter: diff.New,
+					"utime":      now,
+				}).Error
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *GORMInteractiveDAO) SetDailyUniqueViews(ctx context.Context,
+	biz string, day int64, cnts map[int64]int64) (map[int64]int64, error) {
+	if len(cnts) == 0 {
+		return nil, nil
+	}
+	ids := make([]int64, 0, len(cnts))
+	for id := range cnts {
+		ids = append(ids, id)
+	}
+	diffs := make(map[int64]int64, len(cnts))
+	now := time.Now().UnixMilli()
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var olds []DailyUniqueView
+		// Lock the rows, so the concurrent folding doesn't add twice
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("biz = ? AND day = ? AND biz_id IN ?", biz, day, ids).
+			Find(&olds).Error
+		if err != nil {
+			return err
+		}
+		oldCnts := make(map[int64]int64, len(olds))
+		for _, old := range olds {
+			oldCnts[old.BizId] = old.Cnt
+		}
+
+		for _, id := range ids {
+			diff := cnts[id] - oldCnts[id]
+			if diff == 0 {
+				continue
+			}
+			err = tx.Clauses(clause.OnConflict{
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"cnt":   cnts[id],
+					"utime": now,
+				}),
+			}).Create(&DailyUniqueView{
+				Biz:   biz,
+				BizId: id,
+				Day:   day,
+				Cnt:   cnts[id],
+				Ctime: now,
+				Utime: now,
+			}).Error
+			if err != nil {
+				return err
+			}
+			err = tx.Clauses(clause.OnConflict{
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"unique_view_cnt": gorm.Expr("unique_view_cnt + ?", diff),
+					"utime":           now,
+				}),
+			}).Create(&InteractiveCount{
+				Biz:           biz,
+				BizId:         id,
+				UniqueViewCnt: diff,
+				Ctime:         now,
+				Utime:         now,
+			}).Error
+			if err != nil {
+				return err
+			}
+			diffs[id] = diff
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return diffs, nil
+}
+
+func (d *GORMInteractiveDAO) DeleteByBizIds(ctx context.Context,
+	biz string, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("biz = ? AND biz_id IN ?", biz, ids).
+			Delete(&UserLikeBiz{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("biz = ? AND biz_id IN ?", biz, ids).
+			Delete(&UserCollectionBiz{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("biz = ? AND biz_id IN ?", biz, ids).
+			Delete(&DailyUniqueView{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("biz = ? AND biz_id IN ?", biz, ids).
+			Delete(&InteractiveReaction{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("biz = ? AND biz_id IN ?", biz, ids).
+			Delete(&InteractiveCount{}).Error
+	})
+}
@@ -0,0 +1,317 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"regexp"
+	"testing"
+)
+
+func TestGORMInteractiveDAO_ReconcileCounts(t *testing.T) {
+	// 1 has a like of the reaction "like" and one of "funny", the "funny"
+	// is not counted, 2 has no likes but the count went negative
+	mockCounts := func(mock sqlmock.Sqlmock, lock string) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `interactive_counts` "+
+			"WHERE biz = ? AND id > ? ORDER BY id LIMIT 2"+lock)).
+			WithArgs("article", 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "biz", "biz_id", "like_cnt", "collect_cnt"}).
+				AddRow(11, "article", 1, 3, 1).
+				AddRow(12, "article", 2, -1, 0))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `interactive_reactions` "+
+			"WHERE biz = ? AND biz_id IN (?,?)"+lock)).
+			WithArgs("article", 1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "biz", "biz_id", "reaction", "cnt"}).
+				AddRow(1, "article", 1, "like", 2))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT biz_id, reaction, COUNT(*) AS cnt FROM `user_like_bizs` "+
+			"WHERE biz = ? AND biz_id IN (?,?) AND status = ? GROUP BY biz_id, reaction")).
+			WithArgs("article", 1, 2, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"biz_id", "reaction", "cnt"}).
+				AddRow(1, "like", 1).
+				AddRow(1, "funny", 1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT biz_id, COUNT(DISTINCT uid) AS cnt FROM `user_collection_bizs` "+
+			"WHERE biz = ? AND biz_id IN (?,?) GROUP BY `biz_id`")).
+			WithArgs("article", 1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"biz_id", "cnt"}).
+				AddRow(1, 1))
+	}
+	wantDiffs := []CounterDiff{
+		{BizId: 1, Counter: CounterLike, Old: 3, New: 2},
+		{BizId: 1, Counter: "reaction:funny", Old: 0, New: 1},
+		{BizId: 1, Counter: "reaction:like", Old: 2, New: 1},
+		{BizId: 2, Counter: CounterLike, Old: -1, New: 0},
+	}
+
+	testCases := []struct {
+		name   string
+		mock   func(t *testing.T) (*sql.DB, sqlmock.Sqlmock)
+		dryRun bool
+	}{
+		{
+			name: "repair",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mockCounts(mock, " FOR UPDATE")
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE `interactive_counts` SET `like_cnt`=?")).
+					WithArgs(2, sqlmock.AnyArg(), "article", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `interactive_reactions`").
+					WithArgs("article", 1, "funny", 1, sqlmock.AnyArg(), sqlmock.AnyArg(), 1, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectExec("INSERT INTO `interactive_reactions`").
+					WithArgs("article", 1, "like", 1, sqlmock.AnyArg(), sqlmock.AnyArg(), 1, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE `interactive_counts` SET `like_cnt`=?")).
+					WithArgs(0, sqlmock.AnyArg(), "article", 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				return db, mock
+			},
+		},
+		{
+			name: "dry run",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mockCounts(mock, "")
+				mock.ExpectCommit()
+				return db, mock
+			},
+			dryRun: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqldb, mock := tc.mock(t)
+			dao := NewGORMInteractiveDAO(openMockDB(t, sqldb))
+			res, err := dao.ReconcileCounts(context.Background(), "article", 10, 2, tc.dryRun)
+			require.NoError(t, err)
+			assert.Equal(t, ReconcileBatch{LastId: 12, Scanned: 2, Diffs: wantDiffs}, res)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// The double taps are the second requests, which see the rows of the first
+// ones after waiting for their locks
+func TestGORMInteractiveDAO_InsertLikeInfo(t *testing.T) {
+	lockLike := regexp.QuoteMeta("SELECT * FROM `user_like_bizs` " +
+		"WHERE uid = ? AND biz = ? AND biz_id = ?")
+	testCases := []struct {
+		name     string
+		mock     func(mock sqlmock.Sqlmock)
+		reaction string
+		wantPrev string
+	}{
+		{
+			name: "first like",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `user_like_bizs`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(lockLike).WithArgs(123, "article", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "status", "reaction"}).
+						AddRow(1, 0, "like"))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE `user_like_bizs` SET `reaction`=?,`status`=?")).
+					WithArgs("like", 1, sqlmock.AnyArg(), 123, "article", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `interactive_counts`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `interactive_reactions`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			reaction: "like",
+		},
+		{
+			name: "double tap",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `user_like_bizs`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(lockLike).WithArgs(123, "article", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "status", "reaction"}).
+						AddRow(1, 1, "like"))
+				mock.ExpectCommit()
+			},
+			reaction: "like",
+			wantPrev: "like",
+		},
+		{
+			name: "change reaction",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `user_like_bizs`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(lockLike).WithArgs(123, "article", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "status", "reaction"}).
+						AddRow(1, 1, "like"))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE `user_like_bizs` SET `reaction`=?,`status`=?")).
+					WithArgs("funny", 1, sqlmock.AnyArg(), 123, "article", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE `interactive_reactions` SET `cnt`=cnt + ?")).
+					WithArgs(-1, sqlmock.AnyArg(), "article", 1, "like").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `interactive_reactions`").
+					WithArgs("article", 1, "funny", 1, sqlmock.AnyArg(), sqlmock.AnyArg(), 1, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectCommit()
+			},
+			reaction: "funny",
+			wantPrev: "like",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqldb, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+			dao := NewGORMInteractiveDAO(openMockDB(t, sqldb))
+			prev, err := dao.InsertLikeInfo(context.Background(), "article", 1, 123, tc.reaction)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantPrev, prev)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGORMInteractiveDAO_InsertCollectionBiz(t *testing.T) {
+	testCases := []struct {
+		name          string
+		mock          func(mock sqlmock.Sqlmock)
+		wantCollected bool
+	}{
+		{
+			name: "collect",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockLockCollectCnt(mock)
+				mockCountUserCollections(mock, 0)
+				mock.ExpectExec("INSERT INTO `user_collection_bizs`").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE `interactive_counts` SET `collect_cnt`=collect_cnt + ?")).
+					WithArgs(1, sqlmock.AnyArg(), "article", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantCollected: true,
+		},
+		{
+			name: "in another collection",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockLockCollectCnt(mock)
+				mockCountUserCollections(mock, 1)
+				mock.ExpectExec("INSERT INTO `user_collection_bizs`").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "double tap",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockLockCollectCnt(mock)
+				mockCountUserCollections(mock, 1)
+				mock.ExpectExec("INSERT INTO `user_collection_bizs`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqldb, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+			dao := NewGORMInteractiveDAO(openMockDB(t, sqldb))
+			collected, err := dao.InsertCollectionBiz(context.Background(), "article", 1, 2, 123)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantCollected, collected)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGORMInteractiveDAO_DeleteCollectionBiz(t *testing.T) {
+	testCases := []struct {
+		name            string
+		mock            func(mock sqlmock.Sqlmock)
+		wantUncollected bool
+	}{
+		{
+			name: "in two collections",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockLockCollectCnt(mock)
+				mock.ExpectExec("DELETE FROM `user_collection_bizs`").
+					WithArgs(123, "article", 1).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				// The user is counted once
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE `interactive_counts` SET `collect_cnt`=collect_cnt + ?")).
+					WithArgs(-1, sqlmock.AnyArg(), "article", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantUncollected: true,
+		},
+		{
+			name: "double tap",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockLockCollectCnt(mock)
+				mock.ExpectExec("DELETE FROM `user_collection_bizs`").
+					WithArgs(123, "article", 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqldb, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+			dao := NewGORMInteractiveDAO(openMockDB(t, sqldb))
+			uncollected, err := dao.DeleteCollectionBiz(context.Background(), "article", 1, 123)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantUncollected, uncollected)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// mockLockCollectCnt expect the count of article 1 created and locked
+func mockLockCollectCnt(mock sqlmock.Sqlmock) {
+	mock.ExpectExec("INSERT INTO `interactive_counts`").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `interactive_counts` WHERE biz = ? AND biz_id = ?")+
+		".*"+regexp.QuoteMeta("FOR UPDATE")).
+		WithArgs("article", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "biz", "biz_id"}).AddRow(1, "article", 1))
+}
+
+func mockCountUserCollections(mock sqlmock.Sqlmock, cnt int64) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `user_collection_bizs`")).
+		WithArgs(123, "article", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(cnt))
+}
+
+func openMockDB(t *testing.T, sqldb *sql.DB) *gorm.DB {
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqldb,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+	})
+	require.NoError(t, err)
+	return db
+}
@@ -0,0 +1,246 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/dao/article_author.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/dao/article_author.go -package=daomocks -destination=./webook/internal/repository/dao/mocks/article_author_mock.go
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	dao "webook/webook/internal/repository/dao"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleAuthorDAO is a mock of ArticleAuthorDAO interface.
+type MockArticleAuthorDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleAuthorDAOMockRecorder
+}
+
+// MockArticleAuthorDAOMockRecorder is the mock recorder for MockArticleAuthorDAO.
+type MockArticleAuthorDAOMockRecorder struct {
+	mock *MockArticleAuthorDAO
+}
+
+// NewMockArticleAuthorDAO creates a new mock instance.
+func NewMockArticleAuthorDAO(ctrl *gomock.Controller) *MockArticleAuthorDAO {
+	mock := &MockArticleAuthorDAO{ctrl: ctrl}
+	mock.recorder = &MockArticleAuthorDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleAuthorDAO) EXPECT() *MockArticleAuthorDAOMockRecorder {
+	return m.recorder
+}
+
+// CancelSchedule mocks base method.
+func (m *MockArticleAuthorDAO) CancelSchedule(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSchedule", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelSchedule indicates an expected call of CancelSchedule.
+func (mr *MockArticleAuthorDAOMockRecorder) CancelSchedule(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSchedule", reflect.TypeOf((*MockArticleAuthorDAO)(nil).CancelSchedule), ctx, uid, id)
+}
+
+// Delete mocks base method.
+func (m *MockArticleAuthorDAO) Delete(ctx context.Context, uid, id, now int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, uid, id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArticleAuthorDAOMockRecorder) Delete(ctx, uid, id, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleAuthorDAO)(nil).Delete), ctx, uid, id, now)
+}
+
+// GetByAuthor mocks base method.
+func (m *MockArticleAuthorDAO) GetByAuthor(ctx context.Context, uid, offset, limit int64) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAuthor", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByAuthor indicates an expected call of GetByAuthor.
+func (mr *MockArticleAuthorDAOMockRecorder) GetByAuthor(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthor", reflect.TypeOf((*MockArticleAuthorDAO)(nil).GetByAuthor), ctx, uid, offset, limit)
+}
+
+// GetByAuthorAfter mocks base method.
+func (m *MockArticleAuthorDAO) GetByAuthorAfter(ctx context.Context, uid, utime, id, limit int64) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAuthorAfter", ctx, uid, utime, id, limit)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByAuthorAfter indicates an expected call of GetByAuthorAfter.
+func (mr *MockArticleAuthorDAOMockRecorder) GetByAuthorAfter(ctx, uid, utime, id, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthorAfter", reflect.TypeOf((*MockArticleAuthorDAO)(nil).GetByAuthorAfter), ctx, uid, utime, id, limit)
+}
+
+// GetById mocks base method.
+func (m *MockArticleAuthorDAO) GetById(ctx context.Context, id int64) (dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockArticleAuthorDAOMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockArticleAuthorDAO)(nil).GetById), ctx, id)
+}
+
+// Insert mocks base method.
+func (m *MockArticleAuthorDAO) Insert(ctx context.Context, arti dao.Article) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, arti)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockArticleAuthorDAOMockRecorder) Insert(ctx, arti any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockArticleAuthorDAO)(nil).Insert), ctx, arti)
+}
+
+// ListDeleted mocks base method.
+func (m *MockArticleAuthorDAO) ListDeleted(ctx context.Context, uid, offset, limit int64) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeleted", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeleted indicates an expected call of ListDeleted.
+func (mr *MockArticleAuthorDAOMockRecorder) ListDeleted(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeleted", reflect.TypeOf((*MockArticleAuthorDAO)(nil).ListDeleted), ctx, uid, offset, limit)
+}
+
+// ListExpired mocks base method.
+func (m *MockArticleAuthorDAO) ListExpired(ctx context.Context, before, limit int64) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpired", ctx, before, limit)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpired indicates an expected call of ListExpired.
+func (mr *MockArticleAuthorDAOMockRecorder) ListExpired(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpired", reflect.TypeOf((*MockArticleAuthorDAO)(nil).ListExpired), ctx, before, limit)
+}
+
+// ListScheduled mocks base method.
+func (m *MockArticleAuthorDAO) ListScheduled(ctx context.Context, before, limit int64) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduled", ctx, before, limit)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduled indicates an expected call of ListScheduled.
+func (mr *MockArticleAuthorDAOMockRecorder) ListScheduled(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduled", reflect.TypeOf((*MockArticleAuthorDAO)(nil).ListScheduled), ctx, before, limit)
+}
+
+// PublishScheduled mocks base method.
+func (m *MockArticleAuthorDAO) PublishScheduled(ctx context.Context, id, now int64) (dao.Article, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishScheduled", ctx, id, now)
+	ret0, _ := ret[0].(dao.Article)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PublishScheduled indicates an expected call of PublishScheduled.
+func (mr *MockArticleAuthorDAOMockRecorder) PublishScheduled(ctx, id, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishScheduled", reflect.TypeOf((*MockArticleAuthorDAO)(nil).PublishScheduled), ctx, id, now)
+}
+
+// Purge mocks base method.
+func (m *MockArticleAuthorDAO) Purge(ctx context.Context, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockArticleAuthorDAOMockRecorder) Purge(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockArticleAuthorDAO)(nil).Purge), ctx, ids)
+}
+
+// Restore mocks base method.
+func (m *MockArticleAuthorDAO) Restore(ctx context.Context, uid, id, after int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, uid, id, after)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockArticleAuthorDAOMockRecorder) Restore(ctx, uid, id, after any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockArticleAuthorDAO)(nil).Restore), ctx, uid, id, after)
+}
+
+// UpdateById mocks base method.
+func (m *MockArticleAuthorDAO) UpdateById(ctx context.Context, arti dao.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, arti)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockArticleAuthorDAOMockRecorder) UpdateById(ctx, arti any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockArticleAuthorDAO)(nil).UpdateById), ctx, arti)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockArticleAuthorDAO) UpdateStatus(ctx context.Context, id int64, status uint8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockArticleAuthorDAOMockRecorder) UpdateStatus(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockArticleAuthorDAO)(nil).UpdateStatus), ctx, id, status)
+}
@@ -0,0 +1,217 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/dao/article_reader.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/dao/article_reader.go -package=daomocks -destination=./webook/internal/repository/dao/mocks/article_reader_mock.go
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	dao "webook/webook/internal/repository/dao"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleReaderDAO is a mock of ArticleReaderDAO interface.
+type MockArticleReaderDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleReaderDAOMockRecorder
+}
+
+// MockArticleReaderDAOMockRecorder is the mock recorder for MockArticleReaderDAO.
+type MockArticleReaderDAOMockRecorder struct {
+	mock *MockArticleReaderDAO
+}
+
+// NewMockArticleReaderDAO creates a new mock instance.
+func NewMockArticleReaderDAO(ctrl *gomock.Controller) *MockArticleReaderDAO {
+	mock := &MockArticleReaderDAO{ctrl: ctrl}
+	mock.recorder = &MockArticleReaderDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleReaderDAO) EXPECT() *MockArticleReaderDAOMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockArticleReaderDAO) Delete(ctx context.Context, id, now int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArticleReaderDAOMockRecorder) Delete(ctx, id, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleReaderDAO)(nil).Delete), ctx, id, now)
+}
+
+// GetPubById mocks base method.
+func (m *MockArticleReaderDAO) GetPubById(ctx context.Context, id int64) (dao.PublicArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubById", ctx, id)
+	ret0, _ := ret[0].(dao.PublicArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubById indicates an expected call of GetPubById.
+func (mr *MockArticleReaderDAOMockRecorder) GetPubById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubById", reflect.TypeOf((*MockArticleReaderDAO)(nil).GetPubById), ctx, id)
+}
+
+// ListPopularTags mocks base method.
+func (m *MockArticleReaderDAO) ListPopularTags(ctx context.Context, limit int64) ([]dao.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPopularTags", ctx, limit)
+	ret0, _ := ret[0].([]dao.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPopularTags indicates an expected call of ListPopularTags.
+func (mr *MockArticleReaderDAOMockRecorder) ListPopularTags(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPopularTags", reflect.TypeOf((*MockArticleReaderDAO)(nil).ListPopularTags), ctx, limit)
+}
+
+// ListPub mocks base method.
+func (m *MockArticleReaderDAO) ListPub(ctx context.Context, start time.Time, offset, limit int64) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPub", ctx, start, offset, limit)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPub indicates an expected call of ListPub.
+func (mr *MockArticleReaderDAOMockRecorder) ListPub(ctx, start, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleReaderDAO)(nil).ListPub), ctx, start, offset, limit)
+}
+
+// ListPubAfter mocks base method.
+func (m *MockArticleReaderDAO) ListPubAfter(ctx context.Context, utime, id, limit int64) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubAfter", ctx, utime, id, limit)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubAfter indicates an expected call of ListPubAfter.
+func (mr *MockArticleReaderDAOMockRecorder) ListPubAfter(ctx, utime, id, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubAfter", reflect.TypeOf((*MockArticleReaderDAO)(nil).ListPubAfter), ctx, utime, id, limit)
+}
+
+// ListPubByAuthor mocks base method.
+func (m *MockArticleReaderDAO) ListPubByAuthor(ctx context.Context, uid int64, start time.Time, offset, limit int64) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByAuthor", ctx, uid, start, offset, limit)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByAuthor indicates an expected call of ListPubByAuthor.
+func (mr *MockArticleReaderDAOMockRecorder) ListPubByAuthor(ctx, uid, start, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByAuthor", reflect.TypeOf((*MockArticleReaderDAO)(nil).ListPubByAuthor), ctx, uid, start, offset, limit)
+}
+
+// ListPubByIds mocks base method.
+func (m *MockArticleReaderDAO) ListPubByIds(ctx context.Context, ids []int64, statuses []uint8) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByIds", ctx, ids, statuses)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByIds indicates an expected call of ListPubByIds.
+func (mr *MockArticleReaderDAOMockRecorder) ListPubByIds(ctx, ids, statuses any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByIds", reflect.TypeOf((*MockArticleReaderDAO)(nil).ListPubByIds), ctx, ids, statuses)
+}
+
+// ListPubByTag mocks base method.
+func (m *MockArticleReaderDAO) ListPubByTag(ctx context.Context, tag string, start time.Time, offset, limit int64) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByTag", ctx, tag, start, offset, limit)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByTag indicates an expected call of ListPubByTag.
+func (mr *MockArticleReaderDAOMockRecorder) ListPubByTag(ctx, tag, start, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByTag", reflect.TypeOf((*MockArticleReaderDAO)(nil).ListPubByTag), ctx, tag, start, offset, limit)
+}
+
+// Purge mocks base method.
+func (m *MockArticleReaderDAO) Purge(ctx context.Context, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockArticleReaderDAOMockRecorder) Purge(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockArticleReaderDAO)(nil).Purge), ctx, ids)
+}
+
+// Restore mocks base method.
+func (m *MockArticleReaderDAO) Restore(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockArticleReaderDAOMockRecorder) Restore(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockArticleReaderDAO)(nil).Restore), ctx, id)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockArticleReaderDAO) UpdateStatus(ctx context.Context, id int64, status uint8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockArticleReaderDAOMockRecorder) UpdateStatus(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockArticleReaderDAO)(nil).UpdateStatus), ctx, id, status)
+}
+
+// Upsert mocks base method.
+func (m *MockArticleReaderDAO) Upsert(ctx context.Context, arti dao.PublicArticle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, arti)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockArticleReaderDAOMockRecorder) Upsert(ctx, arti any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockArticleReaderDAO)(nil).Upsert), ctx, arti)
+}
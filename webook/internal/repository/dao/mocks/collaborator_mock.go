@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/dao/collaborator.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/dao/collaborator.go -package=daomocks -destination=./webook/internal/repository/dao/mocks/collaborator_mock.go
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	dao "webook/webook/internal/repository/dao"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCollaboratorDAO is a mock of CollaboratorDAO interface.
+type MockCollaboratorDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockCollaboratorDAOMockRecorder
+}
+
+// MockCollaboratorDAOMockRecorder is the mock recorder for MockCollaboratorDAO.
+type MockCollaboratorDAOMockRecorder struct {
+	mock *MockCollaboratorDAO
+}
+
+// NewMockCollaboratorDAO creates a new mock instance.
+func NewMockCollaboratorDAO(ctrl *gomock.Controller) *MockCollaboratorDAO {
+	mock := &MockCollaboratorDAO{ctrl: ctrl}
+	mock.recorder = &MockCollaboratorDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCollaboratorDAO) EXPECT() *MockCollaboratorDAOMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockCollaboratorDAO) Accept(ctx context.Context, aid, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", ctx, aid, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockCollaboratorDAOMockRecorder) Accept(ctx, aid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockCollaboratorDAO)(nil).Accept), ctx, aid, uid)
+}
+
+// Delete mocks base method.
+func (m *MockCollaboratorDAO) Delete(ctx context.Context, aid, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, aid, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCollaboratorDAOMockRecorder) Delete(ctx, aid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCollaboratorDAO)(nil).Delete), ctx, aid, uid)
+}
+
+// DeleteByArticleIds mocks base method.
+func (m *MockCollaboratorDAO) DeleteByArticleIds(ctx context.Context, aids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByArticleIds", ctx, aids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByArticleIds indicates an expected call of DeleteByArticleIds.
+func (mr *MockCollaboratorDAOMockRecorder) DeleteByArticleIds(ctx, aids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByArticleIds", reflect.TypeOf((*MockCollaboratorDAO)(nil).DeleteByArticleIds), ctx, aids)
+}
+
+// Get mocks base method.
+func (m *MockCollaboratorDAO) Get(ctx context.Context, aid, uid int64) (dao.ArticleCollaborator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, aid, uid)
+	ret0, _ := ret[0].(dao.ArticleCollaborator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCollaboratorDAOMockRecorder) Get(ctx, aid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCollaboratorDAO)(nil).Get), ctx, aid, uid)
+}
+
+// ListByArticleId mocks base method.
+func (m *MockCollaboratorDAO) ListByArticleId(ctx context.Context, aid int64) ([]dao.ArticleCollaborator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByArticleId", ctx, aid)
+	ret0, _ := ret[0].([]dao.ArticleCollaborator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByArticleId indicates an expected call of ListByArticleId.
+func (mr *MockCollaboratorDAOMockRecorder) ListByArticleId(ctx, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByArticleId", reflect.TypeOf((*MockCollaboratorDAO)(nil).ListByArticleId), ctx, aid)
+}
+
+// Upsert mocks base method.
+func (m *MockCollaboratorDAO) Upsert(ctx context.Context, c dao.ArticleCollaborator) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockCollaboratorDAOMockRecorder) Upsert(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockCollaboratorDAO)(nil).Upsert), ctx, c)
+}
@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/dao/collection.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/dao/collection.go -package=daomocks -destination=./webook/internal/repository/dao/mocks/collection_mock.go
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	dao "webook/webook/internal/repository/dao"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCollectionDAO is a mock of CollectionDAO interface.
+type MockCollectionDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockCollectionDAOMockRecorder
+}
+
+// MockCollectionDAOMockRecorder is the mock recorder for MockCollectionDAO.
+type MockCollectionDAOMockRecorder struct {
+	mock *MockCollectionDAO
+}
+
+// NewMockCollectionDAO creates a new mock instance.
+func NewMockCollectionDAO(ctrl *gomock.Controller) *MockCollectionDAO {
+	mock := &MockCollectionDAO{ctrl: ctrl}
+	mock.recorder = &MockCollectionDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCollectionDAO) EXPECT() *MockCollectionDAOMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockCollectionDAO) Delete(ctx context.Context, uid, id int64) ([]dao.UserCollectionBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, uid, id)
+	ret0, _ := ret[0].([]dao.UserCollectionBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCollectionDAOMockRecorder) Delete(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCollectionDAO)(nil).Delete), ctx, uid, id)
+}
+
+// GetById mocks base method.
+func (m *MockCollectionDAO) GetById(ctx context.Context, id int64) (dao.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(dao.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockCollectionDAOMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockCollectionDAO)(nil).GetById), ctx, id)
+}
+
+// Insert mocks base method.
+func (m *MockCollectionDAO) Insert(ctx context.Context, c dao.Collection) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, c)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockCollectionDAOMockRecorder) Insert(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockCollectionDAO)(nil).Insert), ctx, c)
+}
+
+// ListByUid mocks base method.
+func (m *MockCollectionDAO) ListByUid(ctx context.Context, uid int64, onlyPublic bool) ([]dao.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUid", ctx, uid, onlyPublic)
+	ret0, _ := ret[0].([]dao.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUid indicates an expected call of ListByUid.
+func (mr *MockCollectionDAOMockRecorder) ListByUid(ctx, uid, onlyPublic any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUid", reflect.TypeOf((*MockCollectionDAO)(nil).ListByUid), ctx, uid, onlyPublic)
+}
+
+// ListItems mocks base method.
+func (m *MockCollectionDAO) ListItems(ctx context.Context, uid, cid int64, biz string, offset, limit int64) ([]dao.UserCollectionBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListItems", ctx, uid, cid, biz, offset, limit)
+	ret0, _ := ret[0].([]dao.UserCollectionBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListItems indicates an expected call of ListItems.
+func (mr *MockCollectionDAOMockRecorder) ListItems(ctx, uid, cid, biz, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItems", reflect.TypeOf((*MockCollectionDAO)(nil).ListItems), ctx, uid, cid, biz, offset, limit)
+}
+
+// MoveItem mocks base method.
+func (m *MockCollectionDAO) MoveItem(ctx context.Context, uid int64, biz string, bizId, from, to int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveItem", ctx, uid, biz, bizId, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveItem indicates an expected call of MoveItem.
+func (mr *MockCollectionDAOMockRecorder) MoveItem(ctx, uid, biz, bizId, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveItem", reflect.TypeOf((*MockCollectionDAO)(nil).MoveItem), ctx, uid, biz, bizId, from, to)
+}
+
+// Update mocks base method.
+func (m *MockCollectionDAO) Update(ctx context.Context, c dao.Collection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCollectionDAOMockRecorder) Update(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCollectionDAO)(nil).Update), ctx, c)
+}
@@ -0,0 +1,308 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/dao/interactive.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/dao/interactive.go -package=daomocks -destination=./webook/internal/repository/dao/mocks/interactive_mock.go
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	dao "webook/webook/internal/repository/dao"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractiveDAO is a mock of InteractiveDAO interface.
+type MockInteractiveDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractiveDAOMockRecorder
+}
+
+// MockInteractiveDAOMockRecorder is the mock recorder for MockInteractiveDAO.
+type MockInteractiveDAOMockRecorder struct {
+	mock *MockInteractiveDAO
+}
+
+// NewMockInteractiveDAO creates a new mock instance.
+func NewMockInteractiveDAO(ctrl *gomock.Controller) *MockInteractiveDAO {
+	mock := &MockInteractiveDAO{ctrl: ctrl}
+	mock.recorder = &MockInteractiveDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractiveDAO) EXPECT() *MockInteractiveDAOMockRecorder {
+	return m.recorder
+}
+
+// DeleteByBizIds mocks base method.
+func (m *MockInteractiveDAO) DeleteByBizIds(ctx context.Context, biz string, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByBizIds", ctx, biz, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByBizIds indicates an expected call of DeleteByBizIds.
+func (mr *MockInteractiveDAOMockRecorder) DeleteByBizIds(ctx, biz, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByBizIds", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteByBizIds), ctx, biz, ids)
+}
+
+// DeleteCollectionBiz mocks base method.
+func (m *MockInteractiveDAO) DeleteCollectionBiz(ctx context.Context, biz string, id, uid int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollectionBiz", ctx, biz, id, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCollectionBiz indicates an expected call of DeleteCollectionBiz.
+func (mr *MockInteractiveDAOMockRecorder) DeleteCollectionBiz(ctx, biz, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollectionBiz", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteCollectionBiz), ctx, biz, id, uid)
+}
+
+// DeleteLikeInfo mocks base method.
+func (m *MockInteractiveDAO) DeleteLikeInfo(ctx context.Context, biz string, id, uid int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLikeInfo", ctx, biz, id, uid)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteLikeInfo indicates an expected call of DeleteLikeInfo.
+func (mr *MockInteractiveDAOMockRecorder) DeleteLikeInfo(ctx, biz, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLikeInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteLikeInfo), ctx, biz, id, uid)
+}
+
+// Get mocks base method.
+func (m *MockInteractiveDAO) Get(ctx context.Context, biz string, id int64) (dao.InteractiveCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, biz, id)
+	ret0, _ := ret[0].(dao.InteractiveCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInteractiveDAOMockRecorder) Get(ctx, biz, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInteractiveDAO)(nil).Get), ctx, biz, id)
+}
+
+// GetByIds mocks base method.
+func (m *MockInteractiveDAO) GetByIds(ctx context.Context, biz string, ids []int64) ([]dao.InteractiveCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIds", ctx, biz, ids)
+	ret0, _ := ret[0].([]dao.InteractiveCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIds indicates an expected call of GetByIds.
+func (mr *MockInteractiveDAOMockRecorder) GetByIds(ctx, biz, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractiveDAO)(nil).GetByIds), ctx, biz, ids)
+}
+
+// GetCollectInfo mocks base method.
+func (m *MockInteractiveDAO) GetCollectInfo(ctx context.Context, biz string, id, uid int64) (dao.UserCollectionBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectInfo", ctx, biz, id, uid)
+	ret0, _ := ret[0].(dao.UserCollectionBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectInfo indicates an expected call of GetCollectInfo.
+func (mr *MockInteractiveDAOMockRecorder) GetCollectInfo(ctx, biz, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).GetCollectInfo), ctx, biz, id, uid)
+}
+
+// GetLikeInfo mocks base method.
+func (m *MockInteractiveDAO) GetLikeInfo(ctx context.Context, biz string, id, uid int64) (dao.UserLikeBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikeInfo", ctx, biz, id, uid)
+	ret0, _ := ret[0].(dao.UserLikeBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikeInfo indicates an expected call of GetLikeInfo.
+func (mr *MockInteractiveDAOMockRecorder) GetLikeInfo(ctx, biz, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikeInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).GetLikeInfo), ctx, biz, id, uid)
+}
+
+// GetReactions mocks base method.
+func (m *MockInteractiveDAO) GetReactions(ctx context.Context, biz string, id int64) ([]dao.InteractiveReaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReactions", ctx, biz, id)
+	ret0, _ := ret[0].([]dao.InteractiveReaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReactions indicates an expected call of GetReactions.
+func (mr *MockInteractiveDAOMockRecorder) GetReactions(ctx, biz, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReactions", reflect.TypeOf((*MockInteractiveDAO)(nil).GetReactions), ctx, biz, id)
+}
+
+// GetReactionsByIds mocks base method.
+func (m *MockInteractiveDAO) GetReactionsByIds(ctx context.Context, biz string, ids []int64) ([]dao.InteractiveReaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReactionsByIds", ctx, biz, ids)
+	ret0, _ := ret[0].([]dao.InteractiveReaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReactionsByIds indicates an expected call of GetReactionsByIds.
+func (mr *MockInteractiveDAOMockRecorder) GetReactionsByIds(ctx, biz, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReactionsByIds", reflect.TypeOf((*MockInteractiveDAO)(nil).GetReactionsByIds), ctx, biz, ids)
+}
+
+// GetUserStates mocks base method.
+func (m *MockInteractiveDAO) GetUserStates(ctx context.Context, biz string, uid int64, ids []int64) ([]dao.UserBizState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserStates", ctx, biz, uid, ids)
+	ret0, _ := ret[0].([]dao.UserBizState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserStates indicates an expected call of GetUserStates.
+func (mr *MockInteractiveDAOMockRecorder) GetUserStates(ctx, biz, uid, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserStates", reflect.TypeOf((*MockInteractiveDAO)(nil).GetUserStates), ctx, biz, uid, ids)
+}
+
+// IncreaseViewCount mocks base method.
+func (m *MockInteractiveDAO) IncreaseViewCount(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncreaseViewCount", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncreaseViewCount indicates an expected call of IncreaseViewCount.
+func (mr *MockInteractiveDAOMockRecorder) IncreaseViewCount(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseViewCount", reflect.TypeOf((*MockInteractiveDAO)(nil).IncreaseViewCount), ctx, biz, bizId)
+}
+
+// IncreaseViewCountBatch mocks base method.
+func (m *MockInteractiveDAO) IncreaseViewCountBatch(ctx context.Context, cnts []dao.InteractiveCount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncreaseViewCountBatch", ctx, cnts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncreaseViewCountBatch indicates an expected call of IncreaseViewCountBatch.
+func (mr *MockInteractiveDAOMockRecorder) IncreaseViewCountBatch(ctx, cnts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseViewCountBatch", reflect.TypeOf((*MockInteractiveDAO)(nil).IncreaseViewCountBatch), ctx, cnts)
+}
+
+// InsertCollectionBiz mocks base method.
+func (m *MockInteractiveDAO) InsertCollectionBiz(ctx context.Context, biz string, id, cid, uid int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCollectionBiz", ctx, biz, id, cid, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertCollectionBiz indicates an expected call of InsertCollectionBiz.
+func (mr *MockInteractiveDAOMockRecorder) InsertCollectionBiz(ctx, biz, id, cid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCollectionBiz", reflect.TypeOf((*MockInteractiveDAO)(nil).InsertCollectionBiz), ctx, biz, id, cid, uid)
+}
+
+// InsertLikeInfo mocks base method.
+func (m *MockInteractiveDAO) InsertLikeInfo(ctx context.Context, biz string, id, uid int64, reaction string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertLikeInfo", ctx, biz, id, uid, reaction)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertLikeInfo indicates an expected call of InsertLikeInfo.
+func (mr *MockInteractiveDAOMockRecorder) InsertLikeInfo(ctx, biz, id, uid, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertLikeInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).InsertLikeInfo), ctx, biz, id, uid, reaction)
+}
+
+// ListLiked mocks base method.
+func (m *MockInteractiveDAO) ListLiked(ctx context.Context, biz string, uid, utime, lid, limit int64) ([]dao.UserLikeBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLiked", ctx, biz, uid, utime, lid, limit)
+	ret0, _ := ret[0].([]dao.UserLikeBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLiked indicates an expected call of ListLiked.
+func (mr *MockInteractiveDAOMockRecorder) ListLiked(ctx, biz, uid, utime, lid, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLiked", reflect.TypeOf((*MockInteractiveDAO)(nil).ListLiked), ctx, biz, uid, utime, lid, limit)
+}
+
+// ListLikers mocks base method.
+func (m *MockInteractiveDAO) ListLikers(ctx context.Context, biz string, id, utime, lid, limit int64) ([]dao.UserLikeBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLikers", ctx, biz, id, utime, lid, limit)
+	ret0, _ := ret[0].([]dao.UserLikeBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLikers indicates an expected call of ListLikers.
+func (mr *MockInteractiveDAOMockRecorder) ListLikers(ctx, biz, id, utime, lid, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikers", reflect.TypeOf((*MockInteractiveDAO)(nil).ListLikers), ctx, biz, id, utime, lid, limit)
+}
+
+// ReconcileCounts mocks base method.
+func (m *MockInteractiveDAO) ReconcileCounts(ctx context.Context, biz string, afterId int64, limit int, dryRun bool) (dao.ReconcileBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileCounts", ctx, biz, afterId, limit, dryRun)
+	ret0, _ := ret[0].(dao.ReconcileBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileCounts indicates an expected call of ReconcileCounts.
+func (mr *MockInteractiveDAOMockRecorder) ReconcileCounts(ctx, biz, afterId, limit, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileCounts", reflect.TypeOf((*MockInteractiveDAO)(nil).ReconcileCounts), ctx, biz, afterId, limit, dryRun)
+}
+
+// SetDailyUniqueViews mocks base method.
+func (m *MockInteractiveDAO) SetDailyUniqueViews(ctx context.Context, biz string, day int64, cnts map[int64]int64) (map[int64]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDailyUniqueViews", ctx, biz, day, cnts)
+	ret0, _ := ret[0].(map[int64]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetDailyUniqueViews indicates an expected call of SetDailyUniqueViews.
+func (mr *MockInteractiveDAOMockRecorder) SetDailyUniqueViews(ctx, biz, day, cnts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDailyUniqueViews", reflect.TypeOf((*MockInteractiveDAO)(nil).SetDailyUniqueViews), ctx, biz, day, cnts)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/dao/moderation.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/dao/moderation.go -package=daomocks -destination=./webook/internal/repository/dao/mocks/moderation_mock.go
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	dao "webook/webook/internal/repository/dao"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockModerationDAO is a mock of ModerationDAO interface.
+type MockModerationDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockModerationDAOMockRecorder
+}
+
+// MockModerationDAOMockRecorder is the mock recorder for MockModerationDAO.
+type MockModerationDAOMockRecorder struct {
+	mock *MockModerationDAO
+}
+
+// NewMockModerationDAO creates a new mock instance.
+func NewMockModerationDAO(ctrl *gomock.Controller) *MockModerationDAO {
+	mock := &MockModerationDAO{ctrl: ctrl}
+	mock.recorder = &MockModerationDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModerationDAO) EXPECT() *MockModerationDAOMockRecorder {
+	return m.recorder
+}
+
+// GetById mocks base method.
+func (m *MockModerationDAO) GetById(ctx context.Context, id int64) (dao.ModerationItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(dao.ModerationItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockModerationDAOMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockModerationDAO)(nil).GetById), ctx, id)
+}
+
+// Insert mocks base method.
+func (m *MockModerationDAO) Insert(ctx context.Context, item dao.ModerationItem) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, item)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockModerationDAOMockRecorder) Insert(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockModerationDAO)(nil).Insert), ctx, item)
+}
+
+// ListByStatus mocks base method.
+func (m *MockModerationDAO) ListByStatus(ctx context.Context, status uint8, offset, limit int64) ([]dao.ModerationItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByStatus", ctx, status, offset, limit)
+	ret0, _ := ret[0].([]dao.ModerationItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByStatus indicates an expected call of ListByStatus.
+func (mr *MockModerationDAOMockRecorder) ListByStatus(ctx, status, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByStatus", reflect.TypeOf((*MockModerationDAO)(nil).ListByStatus), ctx, status, offset, limit)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockModerationDAO) UpdateStatus(ctx context.Context, id int64, from, to uint8, reviewer int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, from, to, reviewer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockModerationDAOMockRecorder) UpdateStatus(ctx, id, from, to, reviewer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockModerationDAO)(nil).UpdateStatus), ctx, id, from, to, reviewer)
+}
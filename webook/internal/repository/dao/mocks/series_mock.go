@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/dao/series.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/dao/series.go -package=daomocks -destination=./webook/internal/repository/dao/mocks/series_mock.go
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	dao "webook/webook/internal/repository/dao"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSeriesDAO is a mock of SeriesDAO interface.
+type MockSeriesDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeriesDAOMockRecorder
+}
+
+// MockSeriesDAOMockRecorder is the mock recorder for MockSeriesDAO.
+type MockSeriesDAOMockRecorder struct {
+	mock *MockSeriesDAO
+}
+
+// NewMockSeriesDAO creates a new mock instance.
+func NewMockSeriesDAO(ctrl *gomock.Controller) *MockSeriesDAO {
+	mock := &MockSeriesDAO{ctrl: ctrl}
+	mock.recorder = &MockSeriesDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeriesDAO) EXPECT() *MockSeriesDAOMockRecorder {
+	return m.recorder
+}
+
+// DeleteArticles mocks base method.
+func (m *MockSeriesDAO) DeleteArticles(ctx context.Context, articleIds []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteArticles", ctx, articleIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteArticles indicates an expected call of DeleteArticles.
+func (mr *MockSeriesDAOMockRecorder) DeleteArticles(ctx, articleIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArticles", reflect.TypeOf((*MockSeriesDAO)(nil).DeleteArticles), ctx, articleIds)
+}
+
+// GetArticleIds mocks base method.
+func (m *MockSeriesDAO) GetArticleIds(ctx context.Context, seriesId int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticleIds", ctx, seriesId)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArticleIds indicates an expected call of GetArticleIds.
+func (mr *MockSeriesDAOMockRecorder) GetArticleIds(ctx, seriesId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleIds", reflect.TypeOf((*MockSeriesDAO)(nil).GetArticleIds), ctx, seriesId)
+}
+
+// GetByArticleId mocks base method.
+func (m *MockSeriesDAO) GetByArticleId(ctx context.Context, articleId int64) (dao.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByArticleId", ctx, articleId)
+	ret0, _ := ret[0].(dao.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByArticleId indicates an expected call of GetByArticleId.
+func (mr *MockSeriesDAOMockRecorder) GetByArticleId(ctx, articleId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByArticleId", reflect.TypeOf((*MockSeriesDAO)(nil).GetByArticleId), ctx, articleId)
+}
+
+// GetByAuthorId mocks base method.
+func (m *MockSeriesDAO) GetByAuthorId(ctx context.Context, authorId, offset, limit int64) ([]dao.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAuthorId", ctx, authorId, offset, limit)
+	ret0, _ := ret[0].([]dao.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByAuthorId indicates an expected call of GetByAuthorId.
+func (mr *MockSeriesDAOMockRecorder) GetByAuthorId(ctx, authorId, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthorId", reflect.TypeOf((*MockSeriesDAO)(nil).GetByAuthorId), ctx, authorId, offset, limit)
+}
+
+// GetById mocks base method.
+func (m *MockSeriesDAO) GetById(ctx context.Context, id int64) (dao.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(dao.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockSeriesDAOMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockSeriesDAO)(nil).GetById), ctx, id)
+}
+
+// Insert mocks base method.
+func (m *MockSeriesDAO) Insert(ctx context.Context, s dao.Series) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, s)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockSeriesDAOMockRecorder) Insert(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockSeriesDAO)(nil).Insert), ctx, s)
+}
+
+// SetArticleIds mocks base method.
+func (m *MockSeriesDAO) SetArticleIds(ctx context.Context, seriesId int64, articleIds []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetArticleIds", ctx, seriesId, articleIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetArticleIds indicates an expected call of SetArticleIds.
+func (mr *MockSeriesDAOMockRecorder) SetArticleIds(ctx, seriesId, articleIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetArticleIds", reflect.TypeOf((*MockSeriesDAO)(nil).SetArticleIds), ctx, seriesId, articleIds)
+}
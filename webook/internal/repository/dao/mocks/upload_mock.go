@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/dao/upload.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/dao/upload.go -package=daomocks -destination=./webook/internal/repository/dao/mocks/upload_mock.go
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	dao "webook/webook/internal/repository/dao"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUploadDAO is a mock of UploadDAO interface.
+type MockUploadDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadDAOMockRecorder
+}
+
+// MockUploadDAOMockRecorder is the mock recorder for MockUploadDAO.
+type MockUploadDAOMockRecorder struct {
+	mock *MockUploadDAO
+}
+
+// NewMockUploadDAO creates a new mock instance.
+func NewMockUploadDAO(ctrl *gomock.Controller) *MockUploadDAO {
+	mock := &MockUploadDAO{ctrl: ctrl}
+	mock.recorder = &MockUploadDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadDAO) EXPECT() *MockUploadDAOMockRecorder {
+	return m.recorder
+}
+
+// DeleteByIds mocks base method.
+func (m *MockUploadDAO) DeleteByIds(ctx context.Context, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByIds", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByIds indicates an expected call of DeleteByIds.
+func (mr *MockUploadDAOMockRecorder) DeleteByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByIds", reflect.TypeOf((*MockUploadDAO)(nil).DeleteByIds), ctx, ids)
+}
+
+// Insert mocks base method.
+func (m *MockUploadDAO) Insert(ctx context.Context, u dao.Upload) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, u)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockUploadDAOMockRecorder) Insert(ctx, u any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockUploadDAO)(nil).Insert), ctx, u)
+}
+
+// ListBefore mocks base method.
+func (m *MockUploadDAO) ListBefore(ctx context.Context, before, startId, limit int64) ([]dao.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBefore", ctx, before, startId, limit)
+	ret0, _ := ret[0].([]dao.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBefore indicates an expected call of ListBefore.
+func (mr *MockUploadDAOMockRecorder) ListBefore(ctx, before, startId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBefore", reflect.TypeOf((*MockUploadDAO)(nil).ListBefore), ctx, before, startId, limit)
+}
+
+// ListByArticleIds mocks base method.
+func (m *MockUploadDAO) ListByArticleIds(ctx context.Context, aids []int64) ([]dao.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByArticleIds", ctx, aids)
+	ret0, _ := ret[0].([]dao.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByArticleIds indicates an expected call of ListByArticleIds.
+func (mr *MockUploadDAOMockRecorder) ListByArticleIds(ctx, aids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByArticleIds", reflect.TypeOf((*MockUploadDAO)(nil).ListByArticleIds), ctx, aids)
+}
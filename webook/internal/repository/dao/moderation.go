@@ -0,0 +1,89 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ModerationItem struct {
+	Id      int64  `gorm:"primaryKey;autoIncrement"`
+	Biz     string `gorm:"type:varchar(32)"`
+	BizId   int64
+	Uid     int64
+	Title   string `gorm:"type:varchar(1024)"`
+	Content string `gorm:"type:text"`
+	Version int64
+	// Words is separated by '\n'
+	Words string `gorm:"type:text"`
+	// Unlisted is 1 if the article is published unlisted on approval
+	Unlisted uint8
+	Status   uint8 `gorm:"index"`
+	Reviewer int64
+	Ctime    int64
+	Utime    int64
+}
+
+type ModerationDAO interface {
+	Insert(ctx context.Context, item ModerationItem) (int64, error)
+	GetById(ctx context.Context, id int64) (ModerationItem, error)
+	// ListByStatus return the items in the order of submitting
+	ListByStatus(ctx context.Context, status uint8, offset int64, limit int64) ([]ModerationItem, error)
+	// UpdateStatus change the status only if it's still from,
+	// otherwise return ErrRecordNotFound
+	UpdateStatus(ctx context.Context, id int64, from uint8, to uint8, reviewer int64) error
+}
+
+type GORMModerationDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMModerationDAO(db *gorm.DB) ModerationDAO {
+	return &GORMModerationDAO{
+		db: db,
+	}
+}
+
+func (d *GORMModerationDAO) Insert(ctx context.Context, item ModerationItem) (int64, error) {
+	now := time.Now().UnixMilli()
+	item.Ctime = now
+	item.Utime = now
+	err := d.db.WithContext(ctx).Create(&item).Error
+	return item.Id, err
+}
+
+func (d *GORMModerationDAO) GetById(ctx context.Context, id int64) (ModerationItem, error) {
+	var item ModerationItem
+	err := d.db.WithContext(ctx).Where("id = ?", id).First(&item).Error
+	return item, err
+}
+
+func (d *GORMModerationDAO) ListByStatus(ctx context.Context,
+	status uint8, offset int64, limit int64) ([]ModerationItem, error) {
+	var items []ModerationItem
+	err := d.db.WithContext(ctx).
+		Where("status = ?", status).
+		Order("id").
+		Offset(int(offset)).Limit(int(limit)).
+		Find(&items).Error
+	return items, err
+}
+
+func (d *GORMModerationDAO) UpdateStatus(ctx context.Context,
+	id int64, from uint8, to uint8, reviewer int64) error {
+	res := d.db.WithContext(ctx).Model(&ModerationItem{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(map[string]any{
+			"status":   to,
+			"reviewer": reviewer,
+			"utime":    time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
@@ -8,29 +8,32 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"time"
+	"webook/webook/internal/domain"
 )
 
-type MongoDBArticleDAO struct {
-	node    *snowflake.Node
-	col     *mongo.Collection
-	liveCol *mongo.Collection
+// notDeleted match the documents not in the trash,
+// deleted_at is unset for them
+var notDeleted = bson.E{Key: "deleted_at", Value: nil}
+
+type MongoDBArticleAuthorDAO struct {
+	node *snowflake.Node
+	col  *mongo.Collection
 }
 
-func NewMongoDBArticleDAO(db *mongo.Database,
-	node *snowflake.Node) *MongoDBArticleDAO {
-	return &MongoDBArticleDAO{
-		node:    node,
-		col:     db.Collection("articles"),
-		liveCol: db.Collection("published_articles"),
+func NewMongoDBArticleAuthorDAO(db *mongo.Database,
+	node *snowflake.Node) ArticleAuthorDAO {
+	return &MongoDBArticleAuthorDAO{
+		node: node,
+		col:  db.Collection("articles"),
 	}
-
 }
 
-func (d *MongoDBArticleDAO) Insert(ctx context.Context,
+func (d *MongoDBArticleAuthorDAO) Insert(ctx context.Context,
 	arti Article) (int64, error) {
 	now := time.Now().UnixMilli()
 	arti.Ctime = now
 	arti.Utime = now
+	arti.Version = 1
 	arti.Id = d.node.Generate().Int64()
 
 	// Insert
@@ -41,79 +44,441 @@ func (d *MongoDBArticleDAO) Insert(ctx context.Context,
 	return arti.Id, nil
 }
 
-func (d *MongoDBArticleDAO) UpdateById(ctx context.Context,
+func (d *MongoDBArticleAuthorDAO) UpdateById(ctx context.Context,
 	arti Article) error {
 	filter := bson.M{
-		"id":        arti.Id,
-		"author_id": arti.AuthorId,
+		"id":         arti.Id,
+		"version":    arti.Version,
+		"deleted_at": nil,
+	}
+	if arti.Version == 0 {
+		// version 0 is not stored
+		filter["version"] = bson.M{"$in": bson.A{0, nil}}
 	}
 	set := bson.D{
 		{"$set", bson.M{
-			"title":   arti.Title,
-			"content": arti.Content,
-			"utime":   time.Now().UnixMilli(),
-			"status":  arti.Status},
+			"title":      arti.Title,
+			"content":    arti.Content,
+			"utime":      time.Now().UnixMilli(),
+			"status":     arti.Status,
+			"publish_at": arti.PublishAt,
+			"tags":       arti.Tags},
 		},
+		{"$inc", bson.M{"version": 1}},
 	}
 	res, err := d.col.UpdateOne(ctx, filter, set)
 	if err != nil {
 		return err
 	}
-	if res.ModifiedCount == 0 {
-		return errors.New("id or author_id is wrong")
+	if res.MatchedCount == 0 {
+		cnt, err := d.col.CountDocuments(ctx, bson.M{
+			"id":         arti.Id,
+			"deleted_at": nil,
+		})
+		if err != nil {
+			return err
+		}
+		if cnt > 0 {
+			return ErrArticleVersionConflict
+		}
+		return errors.New("id is wrong")
 	}
 	return nil
 }
 
-func (d *MongoDBArticleDAO) Sync(ctx context.Context,
-	arti Article) (int64, error) {
-	var (
-		id  = arti.Id
-		err error
-	)
-	if id > 0 {
-		err = d.UpdateById(ctx, arti)
-	} else {
-		id, err = d.Insert(ctx, arti)
+func (d *MongoDBArticleAuthorDAO) UpdateStatus(ctx context.Context,
+	id int64, status uint8) error {
+	filter := bson.D{
+		{"id", id},
+		notDeleted,
+	}
+	set := bson.D{
+		{"$set", bson.D{
+			{"status", status},
+			{"utime", time.Now().UnixMilli()},
+		}},
 	}
+
+	// Update
+	res, err := d.col.UpdateOne(ctx, filter, set)
 	if err != nil {
-		return 0, err
+		return err
+	}
+	if res.ModifiedCount != 1 {
+		return errors.New("id is wrong")
+	}
+	return nil
+}
+
+func (d *MongoDBArticleAuthorDAO) GetByAuthor(ctx context.Context,
+	uid int64, offset int64, limit int64) ([]Article, error) {
+	opts := options.Find().
+		SetSort(cursorSort).
+		SetSkip(offset).
+		SetLimit(limit)
+	return findArticles(ctx, d.col, bson.D{{"author_id", uid}, notDeleted}, opts)
+}
+
+func (d *MongoDBArticleAuthorDAO) GetByAuthorAfter(ctx context.Context,
+	uid int64, utime int64, id int64, limit int64) ([]Article, error) {
+	filter := afterCursorFilter(bson.D{{"author_id", uid}, notDeleted}, utime, id)
+	opts := options.Find().
+		SetSort(cursorSort).
+		SetLimit(limit)
+	return findArticles(ctx, d.col, filter, opts)
+}
+
+func (d *MongoDBArticleAuthorDAO) GetById(ctx context.Context,
+	id int64) (Article, error) {
+	var arti Article
+	err := d.col.FindOne(ctx, bson.D{{"id", id}, notDeleted}).Decode(&arti)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return Article{}, ErrRecordNotFound
 	}
+	return arti, err
+}
 
-	arti.Id = id
-	arti.Utime = time.Now().UnixMilli()
+func (d *MongoDBArticleAuthorDAO) ListScheduled(ctx context.Context,
+	before int64, limit int64) ([]Article, error) {
 	filter := bson.D{
-		{"id", arti.Id},
-		{"author_id", arti.AuthorId},
+		{"status", domain.ArticleStatusScheduled},
+		{"publish_at", bson.D{{"$lte", before}}},
+		notDeleted,
+	}
+	opts := options.Find().
+		SetSort(bson.D{{"publish_at", 1}}).
+		SetLimit(limit)
+	return findArticles(ctx, d.col, filter, opts)
+}
+
+// PublishScheduled check the status in the update like GORMArticleAuthorDAO,
+// so an article is published only once
+func (d *MongoDBArticleAuthorDAO) PublishScheduled(ctx context.Context,
+	id int64, now int64) (Article, bool, error) {
+	filter := bson.D{
+		{"id", id},
+		{"status", domain.ArticleStatusScheduled},
+		{"publish_at", bson.D{{"$lte", now}}},
+		notDeleted,
 	}
 	set := bson.D{
-		{"$set", arti},
-		{"$setOnInsert", bson.M{"ctime": time.Now().UnixMilli()}},
+		{"$set", bson.D{
+			{"status", domain.ArticleStatusPublished},
+			{"utime", now},
+		}},
 	}
-	_, err = d.liveCol.UpdateOne(ctx, filter, set,
-		options.Update().SetUpsert(true))
-	return id, err
+	var arti Article
+	err := d.col.FindOneAndUpdate(ctx, filter, set,
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).
+		Decode(&arti)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return Article{}, false, nil
+	}
+	if err != nil {
+		return Article{}, false, err
+	}
+	return arti, true, nil
 }
 
-func (d *MongoDBArticleDAO) SyncStatus(ctx context.Context,
-	uid int64, id int64, status uint8) error {
+func (d *MongoDBArticleAuthorDAO) CancelSchedule(ctx context.Context,
+	uid int64, id int64) error {
 	filter := bson.D{
 		{"id", id},
 		{"author_id", uid},
+		{"status", domain.ArticleStatusScheduled},
+		notDeleted,
 	}
 	set := bson.D{
-		{"$set", bson.D{{"status", status}}},
+		{"$set", bson.D{
+			{"status", domain.ArticleStatusUnpublished},
+			{"publish_at", 0},
+			{"utime", time.Now().UnixMilli()},
+		}},
 	}
-
-	// Update
 	res, err := d.col.UpdateOne(ctx, filter, set)
 	if err != nil {
 		return err
 	}
-	if res.ModifiedCount != 1 {
+	if res.MatchedCount == 0 {
+		return ErrArticleNotScheduled
+	}
+	return nil
+}
+
+func (d *MongoDBArticleAuthorDAO) Delete(ctx context.Context,
+	uid int64, id int64, now int64) error {
+	filter := bson.D{
+		{"id", id},
+		{"author_id", uid},
+		notDeleted,
+	}
+	res, err := d.col.UpdateOne(ctx, filter,
+		bson.D{{"$set", bson.D{{"deleted_at", now}}}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
 		return errors.New("id or author_id is wrong")
 	}
-	_, err = d.liveCol.UpdateOne(ctx, filter, set)
+	return nil
+}
 
+func (d *MongoDBArticleAuthorDAO) Restore(ctx context.Context,
+	uid int64, id int64, after int64) error {
+	filter := bson.D{
+		{"id", id},
+		{"author_id", uid},
+		{"deleted_at", bson.D{{"$gt", after}}},
+	}
+	res, err := d.col.UpdateOne(ctx, filter,
+		bson.D{{"$unset", bson.D{{"deleted_at", ""}}}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrArticleNotInTrash
+	}
+	return nil
+}
+
+func (d *MongoDBArticleAuthorDAO) ListDeleted(ctx context.Context,
+	uid int64, offset int64, limit int64) ([]Article, error) {
+	filter := bson.D{
+		{"author_id", uid},
+		{"deleted_at", bson.D{{"$gt", 0}}},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{"deleted_at", -1}}).
+		SetSkip(offset).
+		SetLimit(limit)
+	return findArticles(ctx, d.col, filter, opts)
+}
+
+func (d *MongoDBArticleAuthorDAO) ListExpired(ctx context.Context,
+	before int64, limit int64) ([]Article, error) {
+	filter := bson.D{
+		{"deleted_at", bson.D{{"$gt", 0}, {"$lt", before}}},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{"deleted_at", 1}}).
+		SetLimit(limit)
+	return findArticles(ctx, d.col, filter, opts)
+}
+
+func (d *MongoDBArticleAuthorDAO) Purge(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := d.col.DeleteMany(ctx, bson.D{
+		{"id", bson.D{{"$in", ids}}},
+		{"deleted_at", bson.D{{"$gt", 0}}},
+	})
 	return err
 }
+
+type MongoDBArticleReaderDAO struct {
+	liveCol *mongo.Collection
+	// users are stored in MySQL, so the author name is looked up by userDAO
+	userDAO UserDAO
+}
+
+func NewMongoDBArticleReaderDAO(db *mongo.Database,
+	userDAO UserDAO) ArticleReaderDAO {
+	return &MongoDBArticleReaderDAO{
+		liveCol: db.Collection("published_articles"),
+		userDAO: userDAO,
+	}
+}
+
+func (d *MongoDBArticleReaderDAO) Upsert(ctx context.Context,
+	arti PublicArticle) error {
+	now := time.Now().UnixMilli()
+	filter := bson.D{
+		{"id", arti.Id},
+	}
+	set := bson.D{
+		{"$set", bson.M{
+			"title":     arti.Title,
+			"content":   arti.Content,
+			"author_id": arti.AuthorId,
+			"status":    arti.Status,
+			"tags":      arti.Tags,
+			"utime":     now,
+		}},
+		{"$setOnInsert", bson.M{"ctime": now}},
+	}
+	_, err := d.liveCol.UpdateOne(ctx, filter, set,
+		options.Update().SetUpsert(true))
+	return err
+}
+
+func (d *MongoDBArticleReaderDAO) UpdateStatus(ctx context.Context,
+	id int64, status uint8) error {
+	set := bson.D{
+		{"$set", bson.D{
+			{"status", status},
+			{"utime", time.Now().UnixMilli()},
+		}},
+	}
+	_, err := d.liveCol.UpdateOne(ctx, bson.D{{"id", id}}, set)
+	return err
+}
+
+func (d *MongoDBArticleReaderDAO) GetPubById(ctx context.Context,
+	id int64) (PublicArticle, error) {
+	var arti PublicArticle
+	err := d.liveCol.FindOne(ctx, bson.D{{"id", id}, notDeleted}).Decode(&arti)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return PublicArticle{}, ErrRecordNotFound
+	}
+	return arti, err
+}
+
+func (d *MongoDBArticleReaderDAO) ListPubByIds(ctx context.Context,
+	ids []int64, statuses []uint8) ([]Article, error) {
+	filter := bson.D{
+		{Key: "id", Value: bson.D{{Key: "$in", Value: ids}}},
+		{Key: "status", Value: bson.D{{Key: "$in", Value: statuses}}},
+		notDeleted,
+	}
+	artis, err := findArticles(ctx, d.liveCol, filter, options.Find())
+	if err != nil {
+		return nil, err
+	}
+	return artis, d.fillAuthorName(ctx, artis)
+}
+
+func (d *MongoDBArticleReaderDAO) ListPub(ctx context.Context,
+	start time.Time, offset int64, limit int64) ([]Article, error) {
+	filter := bson.D{
+		{"status", domain.ArticleStatusPublished},
+		notDeleted,
+		{"utime", bson.D{{"$lt", start.UnixMilli()}}},
+	}
+	return d.listPub(ctx, filter, offset, limit)
+}
+
+func (d *MongoDBArticleReaderDAO) ListPubAfter(ctx context.Context,
+	utime int64, id int64, limit int64) ([]Article, error) {
+	filter := afterCursorFilter(bson.D{{"status", domain.ArticleStatusPublished}, notDeleted}, utime, id)
+	return d.listPub(ctx, filter, 0, limit)
+}
+
+func (d *MongoDBArticleReaderDAO) ListPubByTag(ctx context.Context, tag string,
+	start time.Time, offset int64, limit int64) ([]Article, error) {
+	filter := bson.D{
+		{"tags", tag},
+		{"status", domain.ArticleStatusPublished},
+		notDeleted,
+		{"utime", bson.D{{"$lt", start.UnixMilli()}}},
+	}
+	return d.listPub(ctx, filter, offset, limit)
+}
+
+// ListPopularTags return the tags used by most published articles,
+// tags are inline in MongoDB, so TagCount.Id is always 0
+func (d *MongoDBArticleReaderDAO) ListPubByAuthor(ctx context.Context, uid int64,
+	start time.Time, offset int64, limit int64) ([]Article, error) {
+	filter := bson.D{
+		{"author_id", uid},
+		{"status", domain.ArticleStatusPublished},
+		notDeleted,
+		{"utime", bson.D{{"$lt", start.UnixMilli()}}},
+	}
+	return d.listPub(ctx, filter, offset, limit)
+}
+
+func (d *MongoDBArticleReaderDAO) ListPopularTags(ctx context.Context,
+	limit int64) ([]TagCount, error) {
+	pipeline := mongo.Pipeline{
+		{{"$match", bson.D{{"status", domain.ArticleStatusPublished}, notDeleted}}},
+		{{"$unwind", "$tags"}},
+		{{"$group", bson.D{
+			{"_id", "$tags"},
+			{"cnt", bson.D{{"$sum", 1}}},
+		}}},
+		{{"$sort", bson.D{{"cnt", -1}, {"_id", 1}}}},
+		{{"$limit", limit}},
+	}
+	cursor, err := d.liveCol.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		Name string `bson:"_id"`
+		Cnt  int64  `bson:"cnt"`
+	}
+	err = cursor.All(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]TagCount, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, TagCount{Name: row.Name, Cnt: row.Cnt})
+	}
+	return res, nil
+}
+
+func (d *MongoDBArticleReaderDAO) Delete(ctx context.Context, id int64, now int64) error {
+	_, err := d.liveCol.UpdateOne(ctx, bson.D{{"id", id}, notDeleted},
+		bson.D{{"$set", bson.D{{"deleted_at", now}}}})
+	return err
+}
+
+func (d *MongoDBArticleReaderDAO) Restore(ctx context.Context, id int64) error {
+	_, err := d.liveCol.UpdateOne(ctx, bson.D{{"id", id}},
+		bson.D{{"$unset", bson.D{{"deleted_at", ""}}}})
+	return err
+}
+
+func (d *MongoDBArticleReaderDAO) Purge(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := d.liveCol.DeleteMany(ctx, bson.D{{"id", bson.D{{"$in", ids}}}})
+	return err
+}
+
+func (d *MongoDBArticleReaderDAO) listPub(ctx context.Context,
+	filter bson.D, offset int64, limit int64) ([]Article, error) {
+	opts := options.Find().
+		SetSort(cursorSort).
+		SetSkip(offset).
+		SetLimit(limit)
+	artis, err := findArticles(ctx, d.liveCol, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	return artis, d.fillAuthorName(ctx, artis)
+}
+
+func (d *MongoDBArticleReaderDAO) fillAuthorName(ctx context.Context,
+	artis []Article) error {
+	ids := make([]int64, 0, len(artis))
+	for _, arti := range artis {
+		ids = append(ids, arti.AuthorId)
+	}
+	users, err := d.userDAO.FindByIds(ctx, ids)
+	if err != nil {
+		return err
+	}
+	names := make(map[int64]string, len(users))
+	for _, u := range users {
+		names[u.Id] = u.NickName
+	}
+	for i := range artis {
+		artis[i].AuthorName = names[artis[i].AuthorId]
+	}
+	return nil
+}
+
+func findArticles(ctx context.Context, col *mongo.Collection,
+	filter bson.D, opts *options.FindOptions) ([]Article, error) {
+	cursor, err := col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var artis []Article
+	err = cursor.All(ctx, &artis)
+	return artis, err
+}
@@ -0,0 +1,89 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ObjectStorage keeps the uploaded files, the key is a slash separated
+// path such as "article/1/xxx.png"
+type ObjectStorage interface {
+	Put(ctx context.Context, key string, r io.ReadSeeker, size int64, contentType string) error
+	Delete(ctx context.Context, key string) error
+	// URL return the public url of the object, it never changes
+	URL(key string) string
+}
+
+var errInvalidObjectKey = errors.New("invalid object key")
+
+// LocalObjectStorage keeps the files under dir,
+// the web server serves dir at baseURL
+type LocalObjectStorage struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocalObjectStorage(dir string, baseURL string) ObjectStorage {
+	return &LocalObjectStorage{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// Put write to a temporary file first,
+// so a failed upload never leaves a partial file
+func (s *LocalObjectStorage) Put(ctx context.Context, key string,
+	r io.ReadSeeker, size int64, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	_, err = io.Copy(f, r)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// Delete succeeds even if the file does not exist
+func (s *LocalObjectStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *LocalObjectStorage) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+// path map the key to a file under dir, the key must not escape dir
+func (s *LocalObjectStorage) path(key string) (string, error) {
+	if key == "" || !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", errInvalidObjectKey
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,102 @@
+package dao
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalObjectStorage(t *testing.T) {
+	dir := t.TempDir()
+	s := NewLocalObjectStorage(dir, "/uploads/")
+	ctx := context.Background()
+
+	err := s.Put(ctx, "article/1/a.png", strings.NewReader("hello"), 5, "image/png")
+	assert.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(dir, "article", "1", "a.png"))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+	assert.Equal(t, "/uploads/article/1/a.png", s.URL("article/1/a.png"))
+
+	// No temporary file is left
+	entries, err := os.ReadDir(filepath.Join(dir, "article", "1"))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	assert.NoError(t, s.Delete(ctx, "article/1/a.png"))
+	_, err = os.Stat(filepath.Join(dir, "article", "1", "a.png"))
+	assert.True(t, os.IsNotExist(err))
+	// Deleting again is fine
+	assert.NoError(t, s.Delete(ctx, "article/1/a.png"))
+
+	assert.Equal(t, errInvalidObjectKey,
+		s.Put(ctx, "../escape.png", strings.NewReader("x"), 1, "image/png"))
+	assert.Equal(t, errInvalidObjectKey, s.Delete(ctx, "/etc/passwd"))
+}
+
+// fakeS3 is a minimal path style S3 server standing in for MinIO
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.objects[key] = data
+		f.types[key] = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestS3ObjectStorage(t *testing.T) {
+	fake := &fakeS3{objects: map[string][]byte{}, types: map[string]string{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint:         aws.String(server.URL),
+		Region:           aws.String("us-east-1"),
+		Credentials:      credentials.NewStaticCredentials("ak", "sk", ""),
+		S3ForcePathStyle: aws.Bool(true),
+		DisableSSL:       aws.Bool(true),
+	})
+	assert.NoError(t, err)
+	s := NewS3ObjectStorage(s3.New(sess), "webook", "https://cdn.example.com/")
+	ctx := context.Background()
+
+	err = s.Put(ctx, "article/1/a.png", strings.NewReader("hello"), 5, "image/png")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(fake.objects["webook/article/1/a.png"]))
+	assert.Equal(t, "image/png", fake.types["webook/article/1/a.png"])
+	assert.Equal(t, "https://cdn.example.com/article/1/a.png", s.URL("article/1/a.png"))
+
+	assert.NoError(t, s.Delete(ctx, "article/1/a.png"))
+	assert.NotContains(t, fake.objects, "webook/article/1/a.png")
+}
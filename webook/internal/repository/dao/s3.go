@@ -1,3 +1,52 @@
 package dao
 
-// TODO: oss in 八.4
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// S3ObjectStorage works with any S3 compatible service,
+// such as AWS S3, Tencent COS or MinIO
+type S3ObjectStorage struct {
+	client *s3.S3
+	bucket string
+	// baseURL is the public url of the bucket, such as a CDN
+	baseURL string
+}
+
+func NewS3ObjectStorage(client *s3.S3, bucket string, baseURL string) ObjectStorage {
+	return &S3ObjectStorage{
+		client:  client,
+		bucket:  bucket,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+func (s *S3ObjectStorage) Put(ctx context.Context, key string,
+	r io.ReadSeeker, size int64, contentType string) error {
+	_, err := s.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(key),
+		Body:          r,
+		ContentLength: aws.Int64(size),
+		ContentType:   aws.String(contentType),
+	})
+	return err
+}
+
+// Delete succeeds even if the object does not exist
+func (s *S3ObjectStorage) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+func (s *S3ObjectStorage) URL(key string) string {
+	return s.baseURL + "/" + key
+}
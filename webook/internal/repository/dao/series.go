@@ -0,0 +1,138 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+var ErrSeriesArticleDuplicate = errors.New("article is in another series")
+
+type Series struct {
+	Id       int64  `gorm:"primaryKey;autoIncrement"`
+	Title    string `gorm:"type:varchar(1024)"`
+	AuthorId int64  `gorm:"index"`
+	Ctime    int64
+	Utime    int64
+}
+
+// SeriesArticle is a chapter of the series,
+// an article belongs to one series at most
+type SeriesArticle struct {
+	Id        int64 `gorm:"primaryKey;autoIncrement"`
+	SeriesId  int64 `gorm:"index:idx_series_position,priority:1"`
+	ArticleId int64 `gorm:"uniqueIndex"`
+	Position  int   `gorm:"index:idx_series_position,priority:2"`
+	Ctime     int64
+}
+
+type SeriesDAO interface {
+	Insert(ctx context.Context, s Series) (int64, error)
+	GetById(ctx context.Context, id int64) (Series, error)
+	GetByAuthorId(ctx context.Context, authorId int64, offset, limit int64) ([]Series, error)
+	// GetByArticleId return ErrRecordNotFound if the article is in no series
+	GetByArticleId(ctx context.Context, articleId int64) (Series, error)
+	// GetArticleIds return the article ids of the series in order
+	GetArticleIds(ctx context.Context, seriesId int64) ([]int64, error)
+	// SetArticleIds replace the chapters of the series
+	SetArticleIds(ctx context.Context, seriesId int64, articleIds []int64) error
+	DeleteArticles(ctx context.Context, articleIds []int64) error
+}
+
+type GORMSeriesDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMSeriesDAO(db *gorm.DB) SeriesDAO {
+	return &GORMSeriesDAO{
+		db: db,
+	}
+}
+
+func (d *GORMSeriesDAO) Insert(ctx context.Context, s Series) (int64, error) {
+	now := time.Now().UnixMilli()
+	s.Ctime = now
+	s.Utime = now
+	err := d.db.WithContext(ctx).Create(&s).Error
+	return s.Id, err
+}
+
+func (d *GORMSeriesDAO) GetById(ctx context.Context, id int64) (Series, error) {
+	var s Series
+	err := d.db.WithContext(ctx).Where("id = ?", id).First(&s).Error
+	return s, err
+}
+
+func (d *GORMSeriesDAO) GetByAuthorId(ctx context.Context,
+	authorId int64, offset, limit int64) ([]Series, error) {
+	var res []Series
+	err := d.db.WithContext(ctx).
+		Where("author_id = ?", authorId).
+		Order("utime DESC").
+		Offset(int(offset)).
+		Limit(int(limit)).
+		Find(&res).Error
+	return res, err
+}
+
+func (d *GORMSeriesDAO) GetByArticleId(ctx context.Context, articleId int64) (Series, error) {
+	var s Series
+	err := d.db.WithContext(ctx).
+		Joins("JOIN series_articles ON series_articles.series_id = series.id").
+		Where("series_articles.article_id = ?", articleId).
+		First(&s).Error
+	return s, err
+}
+
+func (d *GORMSeriesDAO) GetArticleIds(ctx context.Context, seriesId int64) ([]int64, error) {
+	var ids []int64
+	err := d.db.WithContext(ctx).Model(&SeriesArticle{}).
+		Where("series_id = ?", seriesId).
+		Order("position ASC").
+		Pluck("article_id", &ids).Error
+	return ids, err
+}
+
+func (d *GORMSeriesDAO) SetArticleIds(ctx context.Context,
+	seriesId int64, articleIds []int64) error {
+	now := time.Now().UnixMilli()
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("series_id = ?", seriesId).Delete(&SeriesArticle{}).Error
+		if err != nil {
+			return err
+		}
+		if len(articleIds) > 0 {
+			rows := make([]SeriesArticle, 0, len(articleIds))
+			for i, aid := range articleIds {
+				rows = append(rows, SeriesArticle{
+					SeriesId:  seriesId,
+					ArticleId: aid,
+					Position:  i,
+					Ctime:     now,
+				})
+			}
+			err = tx.Create(&rows).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Model(&Series{}).Where("id = ?", seriesId).
+			Update("utime", now).Error
+	})
+	if me, ok := err.(*mysql.MySQLError); ok {
+		const duplicateErr uint16 = 1062
+		if me.Number == duplicateErr {
+			return ErrSeriesArticleDuplicate
+		}
+	}
+	return err
+}
+
+func (d *GORMSeriesDAO) DeleteArticles(ctx context.Context, articleIds []int64) error {
+	return d.db.WithContext(ctx).
+		Where("article_id IN ?", articleIds).
+		Delete(&SeriesArticle{}).Error
+}
@@ -0,0 +1,116 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Tag struct {
+	Id    int64  `gorm:"primaryKey;autoIncrement"`
+	Name  string `gorm:"type:varchar(64);uniqueIndex"`
+	Ctime int64
+	Utime int64
+}
+
+// ArticleTag is the many-to-many relation between articles and tags
+type ArticleTag struct {
+	Id        int64 `gorm:"primaryKey;autoIncrement"`
+	ArticleId int64 `gorm:"uniqueIndex:aid_tid"`
+	TagId     int64 `gorm:"uniqueIndex:aid_tid;index"`
+	Ctime     int64
+}
+
+// PublicArticleTag is the tags of the published articles,
+// they are kept apart so editing a draft won't change the published one
+type PublicArticleTag ArticleTag
+
+type TagCount struct {
+	Id   int64
+	Name string
+	Cnt  int64
+}
+
+// setArticleTags replace the tags of the article in the relation table,
+// article_tags or public_article_tags, the tags not existing will be created
+func setArticleTags(tx *gorm.DB, table string, aid int64, names []string) error {
+	err := tx.Table(table).Where("article_id = ?", aid).Delete(&ArticleTag{}).Error
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	now := time.Now().UnixMilli()
+	tags := make([]Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, Tag{Name: name, Ctime: now, Utime: now})
+	}
+	err = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error
+	if err != nil {
+		return err
+	}
+
+	var ids []int64
+	err = tx.Model(&Tag{}).Where("name IN ?", names).Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+	relations := make([]ArticleTag, 0, len(ids))
+	for _, id := range ids {
+		relations = append(relations, ArticleTag{
+			ArticleId: aid,
+			TagId:     id,
+			Ctime:     now,
+		})
+	}
+	return tx.Table(table).Create(&relations).Error
+}
+
+// getArticleTags return the tag names of each article
+// from the relation table
+func getArticleTags(ctx context.Context, db *gorm.DB, table string,
+	aids []int64) (map[int64][]string, error) {
+	res := make(map[int64][]string, len(aids))
+	if len(aids) == 0 {
+		return res, nil
+	}
+
+	var rows []struct {
+		ArticleId int64
+		Name      string
+	}
+	err := db.WithContext(ctx).
+		Table(table+" AS rel").
+		Select("rel.article_id, tags.name").
+		Joins("JOIN tags ON tags.id = rel.tag_id").
+		Where("rel.article_id IN ?", aids).
+		Order("rel.id ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		res[row.ArticleId] = append(res[row.ArticleId], row.Name)
+	}
+	return res, nil
+}
+
+// fillTags set the tags of each article from the relation table
+func fillTags(ctx context.Context, db *gorm.DB, table string, artis []Article) error {
+	ids := make([]int64, 0, len(artis))
+	for _, arti := range artis {
+		ids = append(ids, arti.Id)
+	}
+	tags, err := getArticleTags(ctx, db, table, ids)
+	if err != nil {
+		return err
+	}
+	for i := range artis {
+		artis[i].Tags = tags[artis[i].Id]
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Upload records a file put into the object storage for an article
+type Upload struct {
+	Id          int64  `gorm:"primaryKey;autoIncrement"`
+	ObjectKey   string `gorm:"type:varchar(512);uniqueIndex"`
+	ArticleId   int64  `gorm:"index"`
+	Uid         int64
+	Size        int64
+	ContentType string `gorm:"type:varchar(128)"`
+
+	Ctime int64 `gorm:"index"`
+}
+
+type UploadDAO interface {
+	Insert(ctx context.Context, u Upload) (int64, error)
+	// ListBefore return the uploads created before the given time
+	// with id greater than startId, ordered by id
+	ListBefore(ctx context.Context, before int64, startId int64, limit int64) ([]Upload, error)
+	ListByArticleIds(ctx context.Context, aids []int64) ([]Upload, error)
+	DeleteByIds(ctx context.Context, ids []int64) error
+}
+
+type GORMUploadDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMUploadDAO(db *gorm.DB) UploadDAO {
+	return &GORMUploadDAO{
+		db: db,
+	}
+}
+
+func (d *GORMUploadDAO) Insert(ctx context.Context, u Upload) (int64, error) {
+	u.Ctime = time.Now().UnixMilli()
+	err := d.db.WithContext(ctx).Create(&u).Error
+	return u.Id, err
+}
+
+func (d *GORMUploadDAO) ListBefore(ctx context.Context,
+	before int64, startId int64, limit int64) ([]Upload, error) {
+	var res []Upload
+	err := d.db.WithContext(ctx).
+		Where("ctime < ? AND id > ?", before, startId).
+		Order("id ASC").
+		Limit(int(limit)).
+		Find(&res).Error
+	return res, err
+}
+
+func (d *GORMUploadDAO) ListByArticleIds(ctx context.Context,
+	aids []int64) ([]Upload, error) {
+	var res []Upload
+	if len(aids) == 0 {
+		return res, nil
+	}
+	err := d.db.WithContext(ctx).
+		Where("article_id IN ?", aids).
+		Find(&res).Error
+	return res, err
+}
+
+func (d *GORMUploadDAO) DeleteByIds(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return d.db.WithContext(ctx).
+		Where("id IN ?", ids).
+		Delete(&Upload{}).Error
+}
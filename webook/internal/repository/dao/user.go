@@ -18,6 +18,7 @@ type UserDAO interface {
 	Insert(ctx context.Context, u User) error
 	FindByEmail(ctx context.Context, email string) (User, error)
 	FindByID(ctx context.Context, id int64) (User, error)
+	FindByIds(ctx context.Context, ids []int64) ([]User, error)
 	FindByPhone(ctx context.Context, phone string) (User, error)
 	Edit(ctx context.Context, u User) error
 	FindByWechatOpenID(ctx context.Context, openId string) (User, error)
@@ -96,6 +97,15 @@ func (dao *GORMUserDAO) FindByID(ctx context.Context, id int64) (User, error) {
 	return u, err
 }
 
+func (dao *GORMUserDAO) FindByIds(ctx context.Context, ids []int64) ([]User, error) {
+	var us []User
+	if len(ids) == 0 {
+		return us, nil
+	}
+	err := dao.db.WithContext(ctx).Where("id IN ?", ids).Find(&us).Error
+	return us, err
+}
+
 func (dao *GORMUserDAO) FindByPhone(ctx context.Context, phone string) (User, error) {
 	var u User
 	err := dao.db.WithContext(ctx).Where("phone=?", phone).First(&u).Error
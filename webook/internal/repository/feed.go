@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository/cache"
+)
+
+// FeedRepository keep the rendered feeds, they are only cached and
+// rebuilt from the published articles on a miss
+type FeedRepository interface {
+	Get(ctx context.Context, format domain.FeedFormat, uid int64) (domain.Feed, error)
+	Set(ctx context.Context, uid int64, feed domain.Feed) error
+	// Invalidate drop the feeds containing the articles of the author
+	Invalidate(ctx context.Context, uid int64) error
+}
+
+type CachedFeedRepository struct {
+	cache cache.FeedCache
+}
+
+func NewCachedFeedRepository(cache cache.FeedCache) FeedRepository {
+	return &CachedFeedRepository{cache: cache}
+}
+
+func (r *CachedFeedRepository) Get(ctx context.Context,
+	format domain.FeedFormat, uid int64) (domain.Feed, error) {
+	return r.cache.Get(ctx, format, uid)
+}
+
+func (r *CachedFeedRepository) Set(ctx context.Context, uid int64, feed domain.Feed) error {
+	return r.cache.Set(ctx, uid, feed)
+}
+
+func (r *CachedFeedRepository) Invalidate(ctx context.Context, uid int64) error {
+	return r.cache.Del(ctx, uid)
+}
@@ -10,104 +10,118 @@ import (
 )
 
 type InteractiveRepository interface {
+	// IncreaseViewCount and IncreaseViewCountBatch buffer the views in the
+	// ViewCountAggregator, they are written a while later
 	IncreaseViewCount(ctx context.Context, biz string, bizId int64) error
 	IncreaseViewCountBatch(ctx context.Context, bizs []string, bizIds []int64) error
-	IncreaseLike(ctx context.Context, biz string, id int64, uid int64) error
+	// IncreaseLike set the reaction of the user, it replaces the one before
+	IncreaseLike(ctx context.Context, biz string, id int64, uid int64, reaction string) error
 	DecreaseLike(ctx context.Context, biz string, id int64, uid int64) error
+	// AddCollectionItem and DeleteCollectionItem only change the count
+	// if the item is added or removed, doing them again is a no-op
 	AddCollectionItem(ctx context.Context, biz string, id int64, cid int64, uid int64) error
 	DeleteCollectionItem(ctx context.Context, biz string, id int64, uid int64) error
 	Get(ctx context.Context, biz string, id int64) (domain.InteractiveCount, error)
-	Liked(ctx context.Context, biz string, id int64, uid int64) (bool, error)
+	// Reaction return the reaction of the user, "" if there is none
+	Reaction(ctx context.Context, biz string, id int64, uid int64) (string, error)
 	Collected(ctx context.Context, biz string, id int64, uid int64) (bool, error)
+	// ListLikers return the likes of the resource after the cursor
+	ListLikers(ctx context.Context, biz string, id int64, cursor domain.Cursor, limit int64) ([]domain.Like, error)
+	// ListLiked return the likes of the user after the cursor
+	ListLiked(ctx context.Context, biz string, uid int64, cursor domain.Cursor, limit int64) ([]domain.Like, error)
+	// GetByIds get the counts from the cache, only the missed ones are
+	// read from the database and put into the cache
 	GetByIds(ctx context.Context, biz string, ids []int64) ([]domain.InteractiveCount, error)
+	// GetUserStates return the Liked, Reaction and Collected of the user,
+	// the resources neither liked nor collected are not in the map
+	GetUserStates(ctx context.Context, biz string, uid int64, ids []int64) (map[int64]domain.InteractiveCount, error)
+	// DeleteByBizIds remove the counts, reactions and collections of the resources
+	DeleteByBizIds(ctx context.Context, biz string, ids []int64) error
+	// ReconcileCounts recompute the counts of a batch after afterId and
+	// repair them unless dryRun, the repaired ones are evicted from the cache
+	ReconcileCounts(ctx context.Context, biz string, afterId int64, limit int, dryRun bool) (domain.ReconcileBatch, error)
+	// AddUniqueView count the visitor once a day
+	AddUniqueView(ctx context.Context, biz string, id int64, visitor string, now time.Time) error
+	// FoldUniqueViews add the unique views of the day to the totals, batch by
+	// batch, it can run again and again on the same day as the views grow.
+	// It returns the number of the resources changed
+	FoldUniqueViews(ctx context.Context, biz string, day time.Time, batchSize int64) (int, error)
 }
 
 type CachedInteractiveRepository struct {
-	dao   dao.InteractiveDAO
-	cache cache.InteractiveCache
-	l     logger.Logger
+	dao     dao.InteractiveDAO
+	cache   cache.InteractiveCache
+	uvCache cache.UniqueViewCache
+	views   *ViewCountAggregator
+	l       logger.Logger
 }
 
 func NewCachedInteractiveRepository(dao dao.InteractiveDAO,
-	cache cache.InteractiveCache, l logger.Logger) InteractiveRepository {
+	cache cache.InteractiveCache, uvCache cache.UniqueViewCache,
+	views *ViewCountAggregator, l logger.Logger) InteractiveRepository {
 	return &CachedInteractiveRepository{
-		dao:   dao,
-		cache: cache,
-		l:     l,
+		dao:     dao,
+		cache:   cache,
+		uvCache: uvCache,
+		views:   views,
+		l:       l,
 	}
 }
 
 func (r *CachedInteractiveRepository) IncreaseViewCount(ctx context.Context,
 	biz string, bizId int64) error {
-
-	err := r.dao.IncreaseViewCount(ctx, biz, bizId)
-	if err != nil {
-		return err
-	}
-
-	return r.cache.IncreaseViewCountIfPresent(ctx, biz, bizId)
+	return r.views.Add(ctx, biz, bizId, 1)
 }
 
 func (r *CachedInteractiveRepository) IncreaseViewCountBatch(ctx context.Context,
 	bizs []string, bizIds []int64) error {
-
-	err := r.dao.IncreaseViewCountBatch(ctx, bizs, bizIds)
-	if err != nil {
-		return err
-	}
-
-	go func() {
-		newCtx := context.Background()
-		ctxTimeout, cancel := context.WithTimeout(newCtx, 5*time.Second)
-		defer cancel()
-
-		for i := range bizs {
-			er := r.cache.IncreaseViewCountIfPresent(ctxTimeout, bizs[i], bizIds[i])
-			if er != nil {
-				r.l.Error("Failed to increase view count Cache",
-					logger.Error(er),
-					logger.String("biz", bizs[i]),
-					logger.Int64("bizId", bizIds[i]),
-				)
-			}
+	for i := range bizs {
+		err := r.views.Add(ctx, bizs[i], bizIds[i], 1)
+		if err != nil {
+			return err
 		}
-	}()
-
+	}
 	return nil
 }
 
 func (r *CachedInteractiveRepository) IncreaseLike(ctx context.Context,
-	biz string, id int64, uid int64) error {
-	err := r.dao.InsertLikeInfo(ctx, biz, id, uid)
+	biz string, id int64, uid int64, reaction string) error {
+	prev, err := r.dao.InsertLikeInfo(ctx, biz, id, uid, reaction)
 	if err != nil {
 		return err
 	}
-	return r.cache.IncreaseLikeIfPresent(ctx, biz, id)
+	switch prev {
+	case reaction:
+		return nil
+	case "":
+		return r.cache.IncreaseLikeIfPresent(ctx, biz, id, reaction)
+	default:
+		return r.cache.ChangeReactionIfPresent(ctx, biz, id, prev, reaction)
+	}
 }
 
 func (r *CachedInteractiveRepository) DecreaseLike(ctx context.Context,
 	biz string, id int64, uid int64) error {
-	err := r.dao.DeleteLikeInfo(ctx, biz, id, uid)
-	if err != nil {
+	prev, err := r.dao.DeleteLikeInfo(ctx, biz, id, uid)
+	if err != nil || prev == "" {
 		return err
 	}
-	return r.cache.DecreaseLikeIfPresent(ctx, biz, id)
+	return r.cache.DecreaseLikeIfPresent(ctx, biz, id, prev)
 }
 
 func (r *CachedInteractiveRepository) AddCollectionItem(ctx context.Context,
 	biz string, id int64, cid int64, uid int64) error {
-	err := r.dao.InsertCollectionBiz(ctx, biz, id, cid, uid)
-	if err != nil {
+	collected, err := r.dao.InsertCollectionBiz(ctx, biz, id, cid, uid)
+	if err != nil || !collected {
 		return err
 	}
 	return r.cache.IncreaseCollectCntIfPresent(ctx, biz, id)
-
 }
 
 func (r *CachedInteractiveRepository) DeleteCollectionItem(ctx context.Context,
 	biz string, id int64, uid int64) error {
-	err := r.dao.DeleteCollectionBiz(ctx, biz, id, uid)
-	if err != nil {
+	uncollected, err := r.dao.DeleteCollectionBiz(ctx, biz, id, uid)
+	if err != nil || !uncollected {
 		return err
 	}
 	return r.cache.DecreaseCollectCntIfPresent(ctx, biz, id)
@@ -125,9 +139,16 @@ func (r *CachedInteractiveRepository) Get(ctx context.Context,
 	if err != nil {
 		return domain.InteractiveCount{}, err
 	}
+	reactions, err := r.dao.GetReactions(ctx, biz, id)
+	if err != nil {
+		return domain.InteractiveCount{}, err
+	}
 
 	// if no err
 	res := r.toDomain(inter)
+	for _, reaction := range reactions {
+		addReaction(&res, reaction)
+	}
 	err = r.cache.Set(ctx, biz, id, res)
 	if err != nil {
 		r.l.Error("failed to set cache", logger.Error(err))
@@ -136,18 +157,18 @@ func (r *CachedInteractiveRepository) Get(ctx context.Context,
 
 }
 
-func (r *CachedInteractiveRepository) Liked(ctx context.Context,
-	biz string, id int64, uid int64) (bool, error) {
+func (r *CachedInteractiveRepository) Reaction(ctx context.Context,
+	biz string, id int64, uid int64) (string, error) {
 
-	_, err := r.dao.GetLikeInfo(ctx, biz, id, uid)
+	like, err := r.dao.GetLikeInfo(ctx, biz, id, uid)
 
 	switch err {
 	case nil:
-		return true, nil
+		return like.Reaction, nil
 	case dao.ErrRecordNotFound:
-		return false, nil
+		return "", nil
 	default:
-		return false, err
+		return "", err
 	}
 }
 
@@ -164,29 +185,241 @@ func (r *CachedInteractiveRepository) Collected(ctx context.Context,
 	}
 }
 
+func (r *CachedInteractiveRepository) ListLikers(ctx context.Context,
+	biz string, id int64, cursor domain.Cursor, limit int64) ([]domain.Like, error) {
+	utime, lid := toCursorArgs(cursor)
+	likes, err := r.dao.ListLikers(ctx, biz, id, utime, lid, limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.likesToDomain(likes), nil
+}
+
+func (r *CachedInteractiveRepository) ListLiked(ctx context.Context,
+	biz string, uid int64, cursor domain.Cursor, limit int64) ([]domain.Like, error) {
+	utime, lid := toCursorArgs(cursor)
+	likes, err := r.dao.ListLiked(ctx, biz, uid, utime, lid, limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.likesToDomain(likes), nil
+}
+
 func (r *CachedInteractiveRepository) GetByIds(ctx context.Context,
 	biz string, ids []int64) ([]domain.InteractiveCount, error) {
-	inters, err := r.dao.GetByIds(ctx, biz, ids)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	cached, err := r.cache.GetByIds(ctx, biz, ids)
+	if err != nil {
+		// All of them are read from the database
+		r.l.Error("failed to get cache", logger.Error(err))
+	}
+
+	res := make([]domain.InteractiveCount, 0, len(ids))
+	var misses []int64
+	for _, id := range ids {
+		inter, ok := cached[id]
+		if ok {
+			res = append(res, inter)
+			continue
+		}
+		misses = append(misses, id)
+	}
+	if len(misses) == 0 {
+		return res, nil
+	}
+
+	inters, err := r.dao.GetByIds(ctx, biz, misses)
+	if err != nil {
+		return nil, err
+	}
+	reactions, err := r.dao.GetReactionsByIds(ctx, biz, misses)
+	if err != nil {
+		return nil, err
+	}
+	// The ones without counts are cached as zero as well,
+	// so they don't go to the database every time
+	loaded := make(map[int64]domain.InteractiveCount, len(misses))
+	for _, id := range misses {
+		loaded[id] = domain.InteractiveCount{BizId: id}
+	}
+	for _, inter := range inters {
+		loaded[inter.BizId] = r.toDomain(inter)
+	}
+	for _, reaction := range reactions {
+		inter := loaded[reaction.BizId]
+		addReaction(&inter, reaction)
+		loaded[reaction.BizId] = inter
+	}
+
+	fills := make([]domain.InteractiveCount, 0, len(loaded))
+	for _, id := range misses {
+		res = append(res, loaded[id])
+	}
+	for _, inter := range loaded {
+		fills = append(fills, inter)
+	}
+	err = r.cache.SetBatch(ctx, biz, fills)
+	if err != nil {
+		r.l.Error("failed to set cache", logger.Error(err))
+	}
+	return res, nil
+}
+
+func (r *CachedInteractiveRepository) GetUserStates(ctx context.Context,
+	biz string, uid int64, ids []int64) (map[int64]domain.InteractiveCount, error) {
+	res := make(map[int64]domain.InteractiveCount)
+	if len(ids) == 0 {
+		return res, nil
+	}
+	states, err := r.dao.GetUserStates(ctx, biz, uid, ids)
 	if err != nil {
 		return nil, err
 	}
-	return r.toDomains(inters), nil
+	for _, state := range states {
+		inter := res[state.BizId]
+		inter.BizId = state.BizId
+		switch state.Kind {
+		case dao.UserBizStateLike:
+			inter.Liked = true
+			inter.Reaction = state.Reaction
+		case dao.UserBizStateCollect:
+			inter.Collected = true
+		}
+		res[state.BizId] = inter
+	}
+	return res, nil
+}
+
+func (r *CachedInteractiveRepository) DeleteByBizIds(ctx context.Context,
+	biz string, ids []int64) error {
+	err := r.dao.DeleteByBizIds(ctx, biz, ids)
+	if err != nil {
+		return err
+	}
+
+	err = r.cache.Del(ctx, biz, ids...)
+	if err != nil {
+		r.l.Error("failed to delete cache", logger.Error(err))
+	}
+	return nil
+}
+
+func (r *CachedInteractiveRepository) ReconcileCounts(ctx context.Context,
+	biz string, afterId int64, limit int, dryRun bool) (domain.ReconcileBatch, error) {
+	batch, err := r.dao.ReconcileCounts(ctx, biz, afterId, limit, dryRun)
+	if err != nil {
+		return domain.ReconcileBatch{}, err
+	}
+	res := domain.ReconcileBatch{
+		LastId:  batch.LastId,
+		Scanned: batch.Scanned,
+		Diffs:   make([]domain.CounterDiff, 0, len(batch.Diffs)),
+	}
+	var ids []int64
+	for _, diff := range batch.Diffs {
+		res.Diffs = append(res.Diffs, domain.CounterDiff{
+			Biz:     biz,
+			BizId:   diff.BizId,
+			Counter: diff.Counter,
+			Old:     diff.Old,
+			New:     diff.New,
+		})
+		if len(ids) == 0 || ids[len(ids)-1] != diff.BizId {
+			ids = append(ids, diff.BizId)
+		}
+	}
+	if dryRun || len(ids) == 0 {
+		return res, nil
+	}
+	err = r.cache.Del(ctx, biz, ids...)
+	if err != nil {
+		r.l.Error("failed to delete cache", logger.Error(err))
+	}
+	return res, nil
+}
+
+func (r *CachedInteractiveRepository) AddUniqueView(ctx context.Context,
+	biz string, id int64, visitor string, now time.Time) error {
+	return r.uvCache.Add(ctx, biz, id, visitor, now)
+}
+
+func (r *CachedInteractiveRepository) FoldUniqueViews(ctx context.Context,
+	biz string, day time.Time, batchSize int64) (int, error) {
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	var (
+		cursor uint64
+		cnt    int
+	)
+	for {
+		ids, next, err := r.uvCache.Scan(ctx, biz, day, cursor, batchSize)
+		if err != nil {
+			return cnt, err
+		}
+		if len(ids) > 0 {
+			estimates, err := r.uvCache.Counts(ctx, biz, day, ids)
+			if err != nil {
+				return cnt, err
+			}
+			cnts := make(map[int64]int64, len(ids))
+			for i, id := range ids {
+				cnts[id] = estimates[i]
+			}
+			diffs, err := r.dao.SetDailyUniqueViews(ctx, biz, start.UnixMilli(), cnts)
+			if err != nil {
+				return cnt, err
+			}
+			for id, diff := range diffs {
+				er := r.cache.IncreaseUniqueViewCntIfPresent(ctx, biz, id, diff)
+				if er != nil {
+					r.l.Error("failed to increase unique view count cache",
+						logger.Error(er),
+						logger.Int64("bizId", id))
+				}
+			}
+			cnt += len(diffs)
+		}
+		if next == 0 {
+			return cnt, nil
+		}
+		cursor = next
+	}
 }
 
 func (r *CachedInteractiveRepository) toDomain(inter dao.InteractiveCount) domain.InteractiveCount {
 	return domain.InteractiveCount{
-		BizId:      inter.BizId,
-		ViewCnt:    inter.ViewCnt,
-		LikeCnt:    inter.LikeCnt,
-		CollectCnt: inter.CollectCnt,
+		BizId:         inter.BizId,
+		ViewCnt:       inter.ViewCnt,
+		UniqueViewCnt: inter.UniqueViewCnt,
+		LikeCnt:       inter.LikeCnt,
+		CollectCnt:    inter.CollectCnt,
+	}
+}
+
+// addReaction add the count of the reaction to the breakdown,
+// the ones with nobody left are dropped
+func addReaction(inter *domain.InteractiveCount, reaction dao.InteractiveReaction) {
+	if reaction.Cnt <= 0 {
+		return
+	}
+	if inter.Reactions == nil {
+		inter.Reactions = make(map[string]int64)
 	}
+	inter.Reactions[reaction.Reaction] = reaction.Cnt
 }
 
-// toDomains
-func (r *CachedInteractiveRepository) toDomains(inters []dao.InteractiveCount) []domain.InteractiveCount {
-	res := make([]domain.InteractiveCount, len(inters))
-	for i := range inters {
-		res[i] = r.toDomain(inters[i])
+func (r *CachedInteractiveRepository) likesToDomain(likes []dao.UserLikeBiz) []domain.Like {
+	res := make([]domain.Like, len(likes))
+	for i, like := range likes {
+		res[i] = domain.Like{
+			Id:       like.Id,
+			Biz:      like.Biz,
+			BizId:    like.BizId,
+			Uid:      like.Uid,
+			Reaction: like.Reaction,
+			Utime:    time.UnixMilli(like.Utime),
+		}
 	}
 	return res
 }
@@ -0,0 +1,335 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository/cache"
+	cachemocks "webook/webook/internal/repository/cache/mocks"
+	"webook/webook/internal/repository/dao"
+	daomocks "webook/webook/internal/repository/dao/mocks"
+	"webook/webook/pkg/logger"
+)
+
+func TestCachedInteractiveRepository_FoldUniqueViews(t *testing.T) {
+	day := time.Date(2024, 5, 1, 15, 30, 0, 0, time.Local)
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local).UnixMilli()
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (dao.InteractiveDAO,
+			cache.InteractiveCache, cache.UniqueViewCache)
+
+		wantCnt int
+		wantErr error
+	}{
+		{
+			name: "fold in batches",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO,
+				cache.InteractiveCache, cache.UniqueViewCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				uv := cachemocks.NewMockUniqueViewCache(ctrl)
+				uv.EXPECT().Scan(gomock.Any(), "article", day, uint64(0), int64(2)).
+					Return([]int64{1, 2}, uint64(7), nil)
+				uv.EXPECT().Counts(gomock.Any(), "article", day, []int64{1, 2}).
+					Return([]int64{10, 3}, nil)
+				// 2 is folded already
+				d.EXPECT().SetDailyUniqueViews(gomock.Any(), "article", start,
+					map[int64]int64{1: 10, 2: 3}).
+					Return(map[int64]int64{1: 4}, nil)
+				c.EXPECT().IncreaseUniqueViewCntIfPresent(gomock.Any(), "article",
+					int64(1), int64(4)).Return(nil)
+				// An empty page doesn't mean the end
+				uv.EXPECT().Scan(gomock.Any(), "article", day, uint64(7), int64(2)).
+					Return(nil, uint64(9), nil)
+				uv.EXPECT().Scan(gomock.Any(), "article", day, uint64(9), int64(2)).
+					Return([]int64{3}, uint64(0), nil)
+				uv.EXPECT().Counts(gomock.Any(), "article", day, []int64{3}).
+					Return([]int64{1}, nil)
+				d.EXPECT().SetDailyUniqueViews(gomock.Any(), "article", start,
+					map[int64]int64{3: 1}).
+					Return(map[int64]int64{3: 1}, nil)
+				// The cache failure is only logged
+				c.EXPECT().IncreaseUniqueViewCntIfPresent(gomock.Any(), "article",
+					int64(3), int64(1)).Return(errors.New("redis error"))
+				return d, c, uv
+			},
+			wantCnt: 2,
+		},
+		{
+			name: "db error",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO,
+				cache.InteractiveCache, cache.UniqueViewCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				uv := cachemocks.NewMockUniqueViewCache(ctrl)
+				uv.EXPECT().Scan(gomock.Any(), "article", day, uint64(0), int64(2)).
+					Return([]int64{1}, uint64(0), nil)
+				uv.EXPECT().Counts(gomock.Any(), "article", day, []int64{1}).
+					Return([]int64{10}, nil)
+				d.EXPECT().SetDailyUniqueViews(gomock.Any(), "article", start,
+					map[int64]int64{1: 10}).
+					Return(nil, errors.New("db error"))
+				return d, nil, uv
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c, uv := tc.mock(ctrl)
+			repo := NewCachedInteractiveRepository(d, c, uv, nil, logger.NewNopLogger())
+			cnt, err := repo.FoldUniqueViews(context.Background(), "article", day, 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCnt, cnt)
+		})
+	}
+}
+
+func TestCachedInteractiveRepository_IncreaseLike(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache)
+
+		reaction string
+		wantErr  error
+	}{
+		{
+			name: "new reaction",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				d.EXPECT().InsertLikeInfo(gomock.Any(), "article", int64(1), int64(123), "funny").
+					Return("", nil)
+				c.EXPECT().IncreaseLikeIfPresent(gomock.Any(), "article", int64(1), "funny").
+					Return(nil)
+				return d, c
+			},
+			reaction: "funny",
+		},
+		{
+			name: "change reaction",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				d.EXPECT().InsertLikeInfo(gomock.Any(), "article", int64(1), int64(123), "funny").
+					Return("like", nil)
+				c.EXPECT().ChangeReactionIfPresent(gomock.Any(), "article", int64(1),
+					"like", "funny").Return(nil)
+				return d, c
+			},
+			reaction: "funny",
+		},
+		{
+			name: "same reaction",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				d.EXPECT().InsertLikeInfo(gomock.Any(), "article", int64(1), int64(123), "funny").
+					Return("funny", nil)
+				return d, cachemocks.NewMockInteractiveCache(ctrl)
+			},
+			reaction: "funny",
+		},
+		{
+			name: "db error",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				d.EXPECT().InsertLikeInfo(gomock.Any(), "article", int64(1), int64(123), "funny").
+					Return("", errors.New("db error"))
+				return d, cachemocks.NewMockInteractiveCache(ctrl)
+			},
+			reaction: "funny",
+			wantErr:  errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c := tc.mock(ctrl)
+			repo := NewCachedInteractiveRepository(d, c, nil, nil, logger.NewNopLogger())
+			err := repo.IncreaseLike(context.Background(), "article", 1, 123, tc.reaction)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestCachedInteractiveRepository_DeleteCollectionItem(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache)
+	}{
+		{
+			name: "removed",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				d.EXPECT().DeleteCollectionBiz(gomock.Any(), "article", int64(1), int64(123)).
+					Return(true, nil)
+				c.EXPECT().DecreaseCollectCntIfPresent(gomock.Any(), "article", int64(1)).
+					Return(nil)
+				return d, c
+			},
+		},
+		{
+			name: "not collected",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				d.EXPECT().DeleteCollectionBiz(gomock.Any(), "article", int64(1), int64(123)).
+					Return(false, nil)
+				return d, cachemocks.NewMockInteractiveCache(ctrl)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c := tc.mock(ctrl)
+			repo := NewCachedInteractiveRepository(d, c, nil, nil, logger.NewNopLogger())
+			err := repo.DeleteCollectionItem(context.Background(), "article", 1, 123)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCachedInteractiveRepository_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	d := daomocks.NewMockInteractiveDAO(ctrl)
+	c := cachemocks.NewMockInteractiveCache(ctrl)
+	c.EXPECT().Get(gomock.Any(), "article", int64(1)).
+		Return(domain.InteractiveCount{}, errors.New("not found"))
+	d.EXPECT().Get(gomock.Any(), "article", int64(1)).
+		Return(dao.InteractiveCount{BizId: 1, LikeCnt: 3}, nil)
+	// The reactions with nobody left are dropped
+	d.EXPECT().GetReactions(gomock.Any(), "article", int64(1)).
+		Return([]dao.InteractiveReaction{
+			{Reaction: "like", Cnt: 2},
+			{Reaction: "funny", Cnt: 1},
+			{Reaction: "insightful", Cnt: 0},
+		}, nil)
+	want := domain.InteractiveCount{
+		BizId:     1,
+		LikeCnt:   3,
+		Reactions: map[string]int64{"like": 2, "funny": 1},
+	}
+	c.EXPECT().Set(gomock.Any(), "article", int64(1), want).Return(nil)
+
+	repo := NewCachedInteractiveRepository(d, c, nil, nil, logger.NewNopLogger())
+	res, err := repo.Get(context.Background(), "article", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, want, res)
+}
+
+func TestCachedInteractiveRepository_GetByIds(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache)
+
+		want    []domain.InteractiveCount
+		wantErr error
+	}{
+		{
+			name: "all cached",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().GetByIds(gomock.Any(), "article", []int64{1, 2, 3}).
+					Return(map[int64]domain.InteractiveCount{
+						1: {BizId: 1, ViewCnt: 10},
+						2: {BizId: 2, ViewCnt: 20},
+						3: {BizId: 3, ViewCnt: 30},
+					}, nil)
+				return daomocks.NewMockInteractiveDAO(ctrl), c
+			},
+			want: []domain.InteractiveCount{
+				{BizId: 1, ViewCnt: 10},
+				{BizId: 2, ViewCnt: 20},
+				{BizId: 3, ViewCnt: 30},
+			},
+		},
+		{
+			name: "misses refilled",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().GetByIds(gomock.Any(), "article", []int64{1, 2, 3}).
+					Return(map[int64]domain.InteractiveCount{
+						2: {BizId: 2, ViewCnt: 20},
+					}, nil)
+				d.EXPECT().GetByIds(gomock.Any(), "article", []int64{1, 3}).
+					Return([]dao.InteractiveCount{{BizId: 1, ViewCnt: 10, LikeCnt: 1}}, nil)
+				d.EXPECT().GetReactionsByIds(gomock.Any(), "article", []int64{1, 3}).
+					Return([]dao.InteractiveReaction{{BizId: 1, Reaction: "funny", Cnt: 1}}, nil)
+				// 3 has no counts yet, it's cached as zero
+				c.EXPECT().SetBatch(gomock.Any(), "article", gomock.InAnyOrder([]domain.InteractiveCount{
+					{BizId: 1, ViewCnt: 10, LikeCnt: 1, Reactions: map[string]int64{"funny": 1}},
+					{BizId: 3},
+				})).Return(errors.New("redis error"))
+				return d, c
+			},
+			want: []domain.InteractiveCount{
+				{BizId: 2, ViewCnt: 20},
+				{BizId: 1, ViewCnt: 10, LikeCnt: 1, Reactions: map[string]int64{"funny": 1}},
+				{BizId: 3},
+			},
+		},
+		{
+			name: "cache error",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().GetByIds(gomock.Any(), "article", []int64{1, 2, 3}).
+					Return(nil, errors.New("redis error"))
+				d.EXPECT().GetByIds(gomock.Any(), "article", []int64{1, 2, 3}).
+					Return(nil, errors.New("db error"))
+				return d, c
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c := tc.mock(ctrl)
+			repo := NewCachedInteractiveRepository(d, c, nil, nil, logger.NewNopLogger())
+			res, err := repo.GetByIds(context.Background(), "article", []int64{1, 2, 3})
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func TestCachedInteractiveRepository_GetUserStates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	d := daomocks.NewMockInteractiveDAO(ctrl)
+	d.EXPECT().GetUserStates(gomock.Any(), "article", int64(123), []int64{1, 2, 3}).
+		Return([]dao.UserBizState{
+			{BizId: 1, Kind: dao.UserBizStateLike, Reaction: "funny"},
+			{BizId: 1, Kind: dao.UserBizStateCollect},
+			{BizId: 3, Kind: dao.UserBizStateCollect},
+		}, nil)
+
+	repo := NewCachedInteractiveRepository(d, nil, nil, nil, logger.NewNopLogger())
+	res, err := repo.GetUserStates(context.Background(), "article", 123, []int64{1, 2, 3})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int64]domain.InteractiveCount{
+		1: {BizId: 1, Liked: true, Reaction: "funny", Collected: true},
+		3: {BizId: 3, Collected: true},
+	}, res)
+}
@@ -0,0 +1,262 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/article_author.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/article_author.go -package=repomocks -destination=./webook/internal/repository/mocks/article_author_mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	domain "webook/webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleAuthorRepository is a mock of ArticleAuthorRepository interface.
+type MockArticleAuthorRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleAuthorRepositoryMockRecorder
+}
+
+// MockArticleAuthorRepositoryMockRecorder is the mock recorder for MockArticleAuthorRepository.
+type MockArticleAuthorRepositoryMockRecorder struct {
+	mock *MockArticleAuthorRepository
+}
+
+// NewMockArticleAuthorRepository creates a new mock instance.
+func NewMockArticleAuthorRepository(ctrl *gomock.Controller) *MockArticleAuthorRepository {
+	mock := &MockArticleAuthorRepository{ctrl: ctrl}
+	mock.recorder = &MockArticleAuthorRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleAuthorRepository) EXPECT() *MockArticleAuthorRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CancelSchedule mocks base method.
+func (m *MockArticleAuthorRepository) CancelSchedule(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSchedule", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelSchedule indicates an expected call of CancelSchedule.
+func (mr *MockArticleAuthorRepositoryMockRecorder) CancelSchedule(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSchedule", reflect.TypeOf((*MockArticleAuthorRepository)(nil).CancelSchedule), ctx, uid, id)
+}
+
+// Create mocks base method.
+func (m *MockArticleAuthorRepository) Create(ctx context.Context, arti domain.Article) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, arti)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockArticleAuthorRepositoryMockRecorder) Create(ctx, arti any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockArticleAuthorRepository)(nil).Create), ctx, arti)
+}
+
+// Delete mocks base method.
+func (m *MockArticleAuthorRepository) Delete(ctx context.Context, uid, id int64, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, uid, id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArticleAuthorRepositoryMockRecorder) Delete(ctx, uid, id, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleAuthorRepository)(nil).Delete), ctx, uid, id, now)
+}
+
+// GetByAuthor mocks base method.
+func (m *MockArticleAuthorRepository) GetByAuthor(ctx context.Context, uid, offset, limit int64) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAuthor", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByAuthor indicates an expected call of GetByAuthor.
+func (mr *MockArticleAuthorRepositoryMockRecorder) GetByAuthor(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthor", reflect.TypeOf((*MockArticleAuthorRepository)(nil).GetByAuthor), ctx, uid, offset, limit)
+}
+
+// GetByAuthorAfter mocks base method.
+func (m *MockArticleAuthorRepository) GetByAuthorAfter(ctx context.Context, uid int64, cursor domain.Cursor, limit int64) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAuthorAfter", ctx, uid, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByAuthorAfter indicates an expected call of GetByAuthorAfter.
+func (mr *MockArticleAuthorRepositoryMockRecorder) GetByAuthorAfter(ctx, uid, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthorAfter", reflect.TypeOf((*MockArticleAuthorRepository)(nil).GetByAuthorAfter), ctx, uid, cursor, limit)
+}
+
+// GetById mocks base method.
+func (m *MockArticleAuthorRepository) GetById(ctx context.Context, id int64) (domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockArticleAuthorRepositoryMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockArticleAuthorRepository)(nil).GetById), ctx, id)
+}
+
+// ListExpired mocks base method.
+func (m *MockArticleAuthorRepository) ListExpired(ctx context.Context, before time.Time, limit int64) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpired", ctx, before, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpired indicates an expected call of ListExpired.
+func (mr *MockArticleAuthorRepositoryMockRecorder) ListExpired(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpired", reflect.TypeOf((*MockArticleAuthorRepository)(nil).ListExpired), ctx, before, limit)
+}
+
+// ListScheduled mocks base method.
+func (m *MockArticleAuthorRepository) ListScheduled(ctx context.Context, before time.Time, limit int64) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduled", ctx, before, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduled indicates an expected call of ListScheduled.
+func (mr *MockArticleAuthorRepositoryMockRecorder) ListScheduled(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduled", reflect.TypeOf((*MockArticleAuthorRepository)(nil).ListScheduled), ctx, before, limit)
+}
+
+// ListTrash mocks base method.
+func (m *MockArticleAuthorRepository) ListTrash(ctx context.Context, uid, offset, limit int64) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockArticleAuthorRepositoryMockRecorder) ListTrash(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockArticleAuthorRepository)(nil).ListTrash), ctx, uid, offset, limit)
+}
+
+// PublishScheduled mocks base method.
+func (m *MockArticleAuthorRepository) PublishScheduled(ctx context.Context, id int64, now time.Time) (domain.Article, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishScheduled", ctx, id, now)
+	ret0, _ := ret[0].(domain.Article)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PublishScheduled indicates an expected call of PublishScheduled.
+func (mr *MockArticleAuthorRepositoryMockRecorder) PublishScheduled(ctx, id, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishScheduled", reflect.TypeOf((*MockArticleAuthorRepository)(nil).PublishScheduled), ctx, id, now)
+}
+
+// Purge mocks base method.
+func (m *MockArticleAuthorRepository) Purge(ctx context.Context, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockArticleAuthorRepositoryMockRecorder) Purge(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockArticleAuthorRepository)(nil).Purge), ctx, ids)
+}
+
+// Restore mocks base method.
+func (m *MockArticleAuthorRepository) Restore(ctx context.Context, uid, id int64, after time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, uid, id, after)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockArticleAuthorRepositoryMockRecorder) Restore(ctx, uid, id, after any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockArticleAuthorRepository)(nil).Restore), ctx, uid, id, after)
+}
+
+// ScanByAuthor mocks base method.
+func (m *MockArticleAuthorRepository) ScanByAuthor(ctx context.Context, uid int64, cursor domain.Cursor, limit int64) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScanByAuthor", ctx, uid, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScanByAuthor indicates an expected call of ScanByAuthor.
+func (mr *MockArticleAuthorRepositoryMockRecorder) ScanByAuthor(ctx, uid, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanByAuthor", reflect.TypeOf((*MockArticleAuthorRepository)(nil).ScanByAuthor), ctx, uid, cursor, limit)
+}
+
+// Update mocks base method.
+func (m *MockArticleAuthorRepository) Update(ctx context.Context, arti domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, arti)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockArticleAuthorRepositoryMockRecorder) Update(ctx, arti any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockArticleAuthorRepository)(nil).Update), ctx, arti)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockArticleAuthorRepository) UpdateStatus(ctx context.Context, uid, id int64, status domain.ArticleStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, uid, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockArticleAuthorRepositoryMockRecorder) UpdateStatus(ctx, uid, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockArticleAuthorRepository)(nil).UpdateStatus), ctx, uid, id, status)
+}
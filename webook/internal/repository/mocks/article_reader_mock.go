@@ -0,0 +1,217 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/article_reader.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/article_reader.go -package=repomocks -destination=./webook/internal/repository/mocks/article_reader_mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	domain "webook/webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleReaderRepository is a mock of ArticleReaderRepository interface.
+type MockArticleReaderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleReaderRepositoryMockRecorder
+}
+
+// MockArticleReaderRepositoryMockRecorder is the mock recorder for MockArticleReaderRepository.
+type MockArticleReaderRepositoryMockRecorder struct {
+	mock *MockArticleReaderRepository
+}
+
+// NewMockArticleReaderRepository creates a new mock instance.
+func NewMockArticleReaderRepository(ctrl *gomock.Controller) *MockArticleReaderRepository {
+	mock := &MockArticleReaderRepository{ctrl: ctrl}
+	mock.recorder = &MockArticleReaderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleReaderRepository) EXPECT() *MockArticleReaderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockArticleReaderRepository) Delete(ctx context.Context, id int64, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArticleReaderRepositoryMockRecorder) Delete(ctx, id, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleReaderRepository)(nil).Delete), ctx, id, now)
+}
+
+// GetPubById mocks base method.
+func (m *MockArticleReaderRepository) GetPubById(ctx context.Context, id int64) (domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubById", ctx, id)
+	ret0, _ := ret[0].(domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubById indicates an expected call of GetPubById.
+func (mr *MockArticleReaderRepositoryMockRecorder) GetPubById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubById", reflect.TypeOf((*MockArticleReaderRepository)(nil).GetPubById), ctx, id)
+}
+
+// ListPopularTags mocks base method.
+func (m *MockArticleReaderRepository) ListPopularTags(ctx context.Context, limit int64) ([]domain.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPopularTags", ctx, limit)
+	ret0, _ := ret[0].([]domain.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPopularTags indicates an expected call of ListPopularTags.
+func (mr *MockArticleReaderRepositoryMockRecorder) ListPopularTags(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPopularTags", reflect.TypeOf((*MockArticleReaderRepository)(nil).ListPopularTags), ctx, limit)
+}
+
+// ListPub mocks base method.
+func (m *MockArticleReaderRepository) ListPub(ctx context.Context, start time.Time, offset, limit int64) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPub", ctx, start, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPub indicates an expected call of ListPub.
+func (mr *MockArticleReaderRepositoryMockRecorder) ListPub(ctx, start, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleReaderRepository)(nil).ListPub), ctx, start, offset, limit)
+}
+
+// ListPubAfter mocks base method.
+func (m *MockArticleReaderRepository) ListPubAfter(ctx context.Context, cursor domain.Cursor, limit int64) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubAfter", ctx, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubAfter indicates an expected call of ListPubAfter.
+func (mr *MockArticleReaderRepositoryMockRecorder) ListPubAfter(ctx, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubAfter", reflect.TypeOf((*MockArticleReaderRepository)(nil).ListPubAfter), ctx, cursor, limit)
+}
+
+// ListPubByAuthor mocks base method.
+func (m *MockArticleReaderRepository) ListPubByAuthor(ctx context.Context, uid int64, start time.Time, offset, limit int64) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByAuthor", ctx, uid, start, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByAuthor indicates an expected call of ListPubByAuthor.
+func (mr *MockArticleReaderRepositoryMockRecorder) ListPubByAuthor(ctx, uid, start, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByAuthor", reflect.TypeOf((*MockArticleReaderRepository)(nil).ListPubByAuthor), ctx, uid, start, offset, limit)
+}
+
+// ListPubByIds mocks base method.
+func (m *MockArticleReaderRepository) ListPubByIds(ctx context.Context, ids []int64, statuses []domain.ArticleStatus) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByIds", ctx, ids, statuses)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByIds indicates an expected call of ListPubByIds.
+func (mr *MockArticleReaderRepositoryMockRecorder) ListPubByIds(ctx, ids, statuses any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByIds", reflect.TypeOf((*MockArticleReaderRepository)(nil).ListPubByIds), ctx, ids, statuses)
+}
+
+// ListPubByTag mocks base method.
+func (m *MockArticleReaderRepository) ListPubByTag(ctx context.Context, tag string, start time.Time, offset, limit int64) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByTag", ctx, tag, start, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByTag indicates an expected call of ListPubByTag.
+func (mr *MockArticleReaderRepositoryMockRecorder) ListPubByTag(ctx, tag, start, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByTag", reflect.TypeOf((*MockArticleReaderRepository)(nil).ListPubByTag), ctx, tag, start, offset, limit)
+}
+
+// Purge mocks base method.
+func (m *MockArticleReaderRepository) Purge(ctx context.Context, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockArticleReaderRepositoryMockRecorder) Purge(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockArticleReaderRepository)(nil).Purge), ctx, ids)
+}
+
+// Restore mocks base method.
+func (m *MockArticleReaderRepository) Restore(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockArticleReaderRepositoryMockRecorder) Restore(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockArticleReaderRepository)(nil).Restore), ctx, id)
+}
+
+// Save mocks base method.
+func (m *MockArticleReaderRepository) Save(ctx context.Context, arti domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, arti)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockArticleReaderRepositoryMockRecorder) Save(ctx, arti any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockArticleReaderRepository)(nil).Save), ctx, arti)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockArticleReaderRepository) UpdateStatus(ctx context.Context, id int64, status domain.ArticleStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockArticleReaderRepositoryMockRecorder) UpdateStatus(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockArticleReaderRepository)(nil).UpdateStatus), ctx, id, status)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/article_revision.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/article_revision.go -package=repomocks -destination=./webook/internal/repository/mocks/article_revision_mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "webook/webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleRevisionRepository is a mock of ArticleRevisionRepository interface.
+type MockArticleRevisionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleRevisionRepositoryMockRecorder
+}
+
+// MockArticleRevisionRepositoryMockRecorder is the mock recorder for MockArticleRevisionRepository.
+type MockArticleRevisionRepositoryMockRecorder struct {
+	mock *MockArticleRevisionRepository
+}
+
+// NewMockArticleRevisionRepository creates a new mock instance.
+func NewMockArticleRevisionRepository(ctrl *gomock.Controller) *MockArticleRevisionRepository {
+	mock := &MockArticleRevisionRepository{ctrl: ctrl}
+	mock.recorder = &MockArticleRevisionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleRevisionRepository) EXPECT() *MockArticleRevisionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockArticleRevisionRepository) Create(ctx context.Context, arti domain.Article) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, arti)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockArticleRevisionRepositoryMockRecorder) Create(ctx, arti any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockArticleRevisionRepository)(nil).Create), ctx, arti)
+}
+
+// DeleteByArticleIds mocks base method.
+func (m *MockArticleRevisionRepository) DeleteByArticleIds(ctx context.Context, aids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByArticleIds", ctx, aids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByArticleIds indicates an expected call of DeleteByArticleIds.
+func (mr *MockArticleRevisionRepositoryMockRecorder) DeleteByArticleIds(ctx, aids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByArticleIds", reflect.TypeOf((*MockArticleRevisionRepository)(nil).DeleteByArticleIds), ctx, aids)
+}
+
+// GetByArticle mocks base method.
+func (m *MockArticleRevisionRepository) GetByArticle(ctx context.Context, aid, uid, offset, limit int64) ([]domain.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByArticle", ctx, aid, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByArticle indicates an expected call of GetByArticle.
+func (mr *MockArticleRevisionRepositoryMockRecorder) GetByArticle(ctx, aid, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByArticle", reflect.TypeOf((*MockArticleRevisionRepository)(nil).GetByArticle), ctx, aid, uid, offset, limit)
+}
+
+// GetById mocks base method.
+func (m *MockArticleRevisionRepository) GetById(ctx context.Context, id int64) (domain.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(domain.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockArticleRevisionRepositoryMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockArticleRevisionRepository)(nil).GetById), ctx, id)
+}
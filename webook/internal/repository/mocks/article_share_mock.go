@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/article_share.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/article_share.go -package=repomocks -destination=./webook/internal/repository/mocks/article_share_mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "webook/webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleShareRepository is a mock of ArticleShareRepository interface.
+type MockArticleShareRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleShareRepositoryMockRecorder
+}
+
+// MockArticleShareRepositoryMockRecorder is the mock recorder for MockArticleShareRepository.
+type MockArticleShareRepositoryMockRecorder struct {
+	mock *MockArticleShareRepository
+}
+
+// NewMockArticleShareRepository creates a new mock instance.
+func NewMockArticleShareRepository(ctrl *gomock.Controller) *MockArticleShareRepository {
+	mock := &MockArticleShareRepository{ctrl: ctrl}
+	mock.recorder = &MockArticleShareRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleShareRepository) EXPECT() *MockArticleShareRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockArticleShareRepository) Create(ctx context.Context, s domain.ArticleShare) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, s)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockArticleShareRepositoryMockRecorder) Create(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockArticleShareRepository)(nil).Create), ctx, s)
+}
+
+// DeleteByArticleIds mocks base method.
+func (m *MockArticleShareRepository) DeleteByArticleIds(ctx context.Context, aids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByArticleIds", ctx, aids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByArticleIds indicates an expected call of DeleteByArticleIds.
+func (mr *MockArticleShareRepositoryMockRecorder) DeleteByArticleIds(ctx, aids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByArticleIds", reflect.TypeOf((*MockArticleShareRepository)(nil).DeleteByArticleIds), ctx, aids)
+}
+
+// GetById mocks base method.
+func (m *MockArticleShareRepository) GetById(ctx context.Context, id int64) (domain.ArticleShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(domain.ArticleShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockArticleShareRepositoryMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockArticleShareRepository)(nil).GetById), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockArticleShareRepository) List(ctx context.Context, aid int64) ([]domain.ArticleShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, aid)
+	ret0, _ := ret[0].([]domain.ArticleShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockArticleShareRepositoryMockRecorder) List(ctx, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockArticleShareRepository)(nil).List), ctx, aid)
+}
+
+// Revoke mocks base method.
+func (m *MockArticleShareRepository) Revoke(ctx context.Context, aid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, aid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockArticleShareRepositoryMockRecorder) Revoke(ctx, aid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockArticleShareRepository)(nil).Revoke), ctx, aid, id)
+}
@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/collaborator.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/collaborator.go -package=repomocks -destination=./webook/internal/repository/mocks/collaborator_mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "webook/webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCollaboratorRepository is a mock of CollaboratorRepository interface.
+type MockCollaboratorRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCollaboratorRepositoryMockRecorder
+}
+
+// MockCollaboratorRepositoryMockRecorder is the mock recorder for MockCollaboratorRepository.
+type MockCollaboratorRepositoryMockRecorder struct {
+	mock *MockCollaboratorRepository
+}
+
+// NewMockCollaboratorRepository creates a new mock instance.
+func NewMockCollaboratorRepository(ctrl *gomock.Controller) *MockCollaboratorRepository {
+	mock := &MockCollaboratorRepository{ctrl: ctrl}
+	mock.recorder = &MockCollaboratorRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCollaboratorRepository) EXPECT() *MockCollaboratorRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockCollaboratorRepository) Accept(ctx context.Context, aid, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", ctx, aid, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockCollaboratorRepositoryMockRecorder) Accept(ctx, aid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockCollaboratorRepository)(nil).Accept), ctx, aid, uid)
+}
+
+// DeleteByArticleIds mocks base method.
+func (m *MockCollaboratorRepository) DeleteByArticleIds(ctx context.Context, aids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByArticleIds", ctx, aids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByArticleIds indicates an expected call of DeleteByArticleIds.
+func (mr *MockCollaboratorRepositoryMockRecorder) DeleteByArticleIds(ctx, aids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByArticleIds", reflect.TypeOf((*MockCollaboratorRepository)(nil).DeleteByArticleIds), ctx, aids)
+}
+
+// GetRole mocks base method.
+func (m *MockCollaboratorRepository) GetRole(ctx context.Context, aid, uid int64) (domain.CollaboratorRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", ctx, aid, uid)
+	ret0, _ := ret[0].(domain.CollaboratorRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockCollaboratorRepositoryMockRecorder) GetRole(ctx, aid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockCollaboratorRepository)(nil).GetRole), ctx, aid, uid)
+}
+
+// Invite mocks base method.
+func (m *MockCollaboratorRepository) Invite(ctx context.Context, c domain.Collaborator) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", ctx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockCollaboratorRepositoryMockRecorder) Invite(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockCollaboratorRepository)(nil).Invite), ctx, c)
+}
+
+// List mocks base method.
+func (m *MockCollaboratorRepository) List(ctx context.Context, aid int64) ([]domain.Collaborator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, aid)
+	ret0, _ := ret[0].([]domain.Collaborator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockCollaboratorRepositoryMockRecorder) List(ctx, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCollaboratorRepository)(nil).List), ctx, aid)
+}
+
+// Remove mocks base method.
+func (m *MockCollaboratorRepository) Remove(ctx context.Context, aid, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, aid, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockCollaboratorRepositoryMockRecorder) Remove(ctx, aid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockCollaboratorRepository)(nil).Remove), ctx, aid, uid)
+}
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/comment.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/comment.go -package=repomocks -destination=./webook/internal/repository/mocks/comment_mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "webook/webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCommentRepository is a mock of CommentRepository interface.
+type MockCommentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentRepositoryMockRecorder
+}
+
+// MockCommentRepositoryMockRecorder is the mock recorder for MockCommentRepository.
+type MockCommentRepositoryMockRecorder struct {
+	mock *MockCommentRepository
+}
+
+// NewMockCommentRepository creates a new mock instance.
+func NewMockCommentRepository(ctrl *gomock.Controller) *MockCommentRepository {
+	mock := &MockCommentRepository{ctrl: ctrl}
+	mock.recorder = &MockCommentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentRepository) EXPECT() *MockCommentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCommentRepository) Create(ctx context.Context, comment domain.Comment) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, comment)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommentRepositoryMockRecorder) Create(ctx, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommentRepository)(nil).Create), ctx, comment)
+}
+
+// DeleteByArticleIds mocks base method.
+func (m *MockCommentRepository) DeleteByArticleIds(ctx context.Context, articleIds []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByArticleIds", ctx, articleIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByArticleIds indicates an expected call of DeleteByArticleIds.
+func (mr *MockCommentRepositoryMockRecorder) DeleteByArticleIds(ctx, articleIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByArticleIds", reflect.TypeOf((*MockCommentRepository)(nil).DeleteByArticleIds), ctx, articleIds)
+}
+
+// DeleteById mocks base method.
+func (m *MockCommentRepository) DeleteById(ctx context.Context, id, userId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockCommentRepositoryMockRecorder) DeleteById(ctx, id, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockCommentRepository)(nil).DeleteById), ctx, id, userId)
+}
+
+// GetByArticleId mocks base method.
+func (m *MockCommentRepository) GetByArticleId(ctx context.Context, articleId, offset, limit int64) ([]domain.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByArticleId", ctx, articleId, offset, limit)
+	ret0, _ := ret[0].([]domain.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByArticleId indicates an expected call of GetByArticleId.
+func (mr *MockCommentRepositoryMockRecorder) GetByArticleId(ctx, articleId, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByArticleId", reflect.TypeOf((*MockCommentRepository)(nil).GetByArticleId), ctx, articleId, offset, limit)
+}
+
+// GetByArticleIdAfter mocks base method.
+func (m *MockCommentRepository) GetByArticleIdAfter(ctx context.Context, articleId int64, cursor domain.Cursor, limit int64) ([]domain.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByArticleIdAfter", ctx, articleId, cursor, limit)
+	ret0, _ := ret[0].([]domain.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByArticleIdAfter indicates an expected call of GetByArticleIdAfter.
+func (mr *MockCommentRepositoryMockRecorder) GetByArticleIdAfter(ctx, articleId, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByArticleIdAfter", reflect.TypeOf((*MockCommentRepository)(nil).GetByArticleIdAfter), ctx, articleId, cursor, limit)
+}
@@ -0,0 +1,289 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/interactive.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/interactive.go -package=repomocks -destination=./webook/internal/repository/mocks/interactive_mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	domain "webook/webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractiveRepository is a mock of InteractiveRepository interface.
+type MockInteractiveRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractiveRepositoryMockRecorder
+}
+
+// MockInteractiveRepositoryMockRecorder is the mock recorder for MockInteractiveRepository.
+type MockInteractiveRepositoryMockRecorder struct {
+	mock *MockInteractiveRepository
+}
+
+// NewMockInteractiveRepository creates a new mock instance.
+func NewMockInteractiveRepository(ctrl *gomock.Controller) *MockInteractiveRepository {
+	mock := &MockInteractiveRepository{ctrl: ctrl}
+	mock.recorder = &MockInteractiveRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractiveRepository) EXPECT() *MockInteractiveRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddCollectionItem mocks base method.
+func (m *MockInteractiveRepository) AddCollectionItem(ctx context.Context, biz string, id, cid, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCollectionItem", ctx, biz, id, cid, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCollectionItem indicates an expected call of AddCollectionItem.
+func (mr *MockInteractiveRepositoryMockRecorder) AddCollectionItem(ctx, biz, id, cid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCollectionItem", reflect.TypeOf((*MockInteractiveRepository)(nil).AddCollectionItem), ctx, biz, id, cid, uid)
+}
+
+// AddUniqueView mocks base method.
+func (m *MockInteractiveRepository) AddUniqueView(ctx context.Context, biz string, id int64, visitor string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUniqueView", ctx, biz, id, visitor, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUniqueView indicates an expected call of AddUniqueView.
+func (mr *MockInteractiveRepositoryMockRecorder) AddUniqueView(ctx, biz, id, visitor, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUniqueView", reflect.TypeOf((*MockInteractiveRepository)(nil).AddUniqueView), ctx, biz, id, visitor, now)
+}
+
+// Collected mocks base method.
+func (m *MockInteractiveRepository) Collected(ctx context.Context, biz string, id, uid int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Collected", ctx, biz, id, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Collected indicates an expected call of Collected.
+func (mr *MockInteractiveRepositoryMockRecorder) Collected(ctx, biz, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collected", reflect.TypeOf((*MockInteractiveRepository)(nil).Collected), ctx, biz, id, uid)
+}
+
+// DecreaseLike mocks base method.
+func (m *MockInteractiveRepository) DecreaseLike(ctx context.Context, biz string, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecreaseLike", ctx, biz, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecreaseLike indicates an expected call of DecreaseLike.
+func (mr *MockInteractiveRepositoryMockRecorder) DecreaseLike(ctx, biz, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecreaseLike", reflect.TypeOf((*MockInteractiveRepository)(nil).DecreaseLike), ctx, biz, id, uid)
+}
+
+// DeleteByBizIds mocks base method.
+func (m *MockInteractiveRepository) DeleteByBizIds(ctx context.Context, biz string, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByBizIds", ctx, biz, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByBizIds indicates an expected call of DeleteByBizIds.
+func (mr *MockInteractiveRepositoryMockRecorder) DeleteByBizIds(ctx, biz, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByBizIds", reflect.TypeOf((*MockInteractiveRepository)(nil).DeleteByBizIds), ctx, biz, ids)
+}
+
+// DeleteCollectionItem mocks base method.
+func (m *MockInteractiveRepository) DeleteCollectionItem(ctx context.Context, biz string, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollectionItem", ctx, biz, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollectionItem indicates an expected call of DeleteCollectionItem.
+func (mr *MockInteractiveRepositoryMockRecorder) DeleteCollectionItem(ctx, biz, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollectionItem", reflect.TypeOf((*MockInteractiveRepository)(nil).DeleteCollectionItem), ctx, biz, id, uid)
+}
+
+// FoldUniqueViews mocks base method.
+func (m *MockInteractiveRepository) FoldUniqueViews(ctx context.Context, biz string, day time.Time, batchSize int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FoldUniqueViews", ctx, biz, day, batchSize)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FoldUniqueViews indicates an expected call of FoldUniqueViews.
+func (mr *MockInteractiveRepositoryMockRecorder) FoldUniqueViews(ctx, biz, day, batchSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FoldUniqueViews", reflect.TypeOf((*MockInteractiveRepository)(nil).FoldUniqueViews), ctx, biz, day, batchSize)
+}
+
+// Get mocks base method.
+func (m *MockInteractiveRepository) Get(ctx context.Context, biz string, id int64) (domain.InteractiveCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, biz, id)
+	ret0, _ := ret[0].(domain.InteractiveCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInteractiveRepositoryMockRecorder) Get(ctx, biz, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInteractiveRepository)(nil).Get), ctx, biz, id)
+}
+
+// GetByIds mocks base method.
+func (m *MockInteractiveRepository) GetByIds(ctx context.Context, biz string, ids []int64) ([]domain.InteractiveCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIds", ctx, biz, ids)
+	ret0, _ := ret[0].([]domain.InteractiveCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIds indicates an expected call of GetByIds.
+func (mr *MockInteractiveRepositoryMockRecorder) GetByIds(ctx, biz, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractiveRepository)(nil).GetByIds), ctx, biz, ids)
+}
+
+// GetUserStates mocks base method.
+func (m *MockInteractiveRepository) GetUserStates(ctx context.Context, biz string, uid int64, ids []int64) (map[int64]domain.InteractiveCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserStates", ctx, biz, uid, ids)
+	ret0, _ := ret[0].(map[int64]domain.InteractiveCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserStates indicates an expected call of GetUserStates.
+func (mr *MockInteractiveRepositoryMockRecorder) GetUserStates(ctx, biz, uid, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserStates", reflect.TypeOf((*MockInteractiveRepository)(nil).GetUserStates), ctx, biz, uid, ids)
+}
+
+// IncreaseLike mocks base method.
+func (m *MockInteractiveRepository) IncreaseLike(ctx context.Context, biz string, id, uid int64, reaction string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncreaseLike", ctx, biz, id, uid, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncreaseLike indicates an expected call of IncreaseLike.
+func (mr *MockInteractiveRepositoryMockRecorder) IncreaseLike(ctx, biz, id, uid, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseLike", reflect.TypeOf((*MockInteractiveRepository)(nil).IncreaseLike), ctx, biz, id, uid, reaction)
+}
+
+// IncreaseViewCount mocks base method.
+func (m *MockInteractiveRepository) IncreaseViewCount(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncreaseViewCount", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncreaseViewCount indicates an expected call of IncreaseViewCount.
+func (mr *MockInteractiveRepositoryMockRecorder) IncreaseViewCount(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseViewCount", reflect.TypeOf((*MockInteractiveRepository)(nil).IncreaseViewCount), ctx, biz, bizId)
+}
+
+// IncreaseViewCountBatch mocks base method.
+func (m *MockInteractiveRepository) IncreaseViewCountBatch(ctx context.Context, bizs []string, bizIds []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncreaseViewCountBatch", ctx, bizs, bizIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncreaseViewCountBatch indicates an expected call of IncreaseViewCountBatch.
+func (mr *MockInteractiveRepositoryMockRecorder) IncreaseViewCountBatch(ctx, bizs, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseViewCountBatch", reflect.TypeOf((*MockInteractiveRepository)(nil).IncreaseViewCountBatch), ctx, bizs, bizIds)
+}
+
+// ListLiked mocks base method.
+func (m *MockInteractiveRepository) ListLiked(ctx context.Context, biz string, uid int64, cursor domain.Cursor, limit int64) ([]domain.Like, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLiked", ctx, biz, uid, cursor, limit)
+	ret0, _ := ret[0].([]domain.Like)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLiked indicates an expected call of ListLiked.
+func (mr *MockInteractiveRepositoryMockRecorder) ListLiked(ctx, biz, uid, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLiked", reflect.TypeOf((*MockInteractiveRepository)(nil).ListLiked), ctx, biz, uid, cursor, limit)
+}
+
+// ListLikers mocks base method.
+func (m *MockInteractiveRepository) ListLikers(ctx context.Context, biz string, id int64, cursor domain.Cursor, limit int64) ([]domain.Like, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLikers", ctx, biz, id, cursor, limit)
+	ret0, _ := ret[0].([]domain.Like)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLikers indicates an expected call of ListLikers.
+func (mr *MockInteractiveRepositoryMockRecorder) ListLikers(ctx, biz, id, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikers", reflect.TypeOf((*MockInteractiveRepository)(nil).ListLikers), ctx, biz, id, cursor, limit)
+}
+
+// Reaction mocks base method.
+func (m *MockInteractiveRepository) Reaction(ctx context.Context, biz string, id, uid int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reaction", ctx, biz, id, uid)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reaction indicates an expected call of Reaction.
+func (mr *MockInteractiveRepositoryMockRecorder) Reaction(ctx, biz, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reaction", reflect.TypeOf((*MockInteractiveRepository)(nil).Reaction), ctx, biz, id, uid)
+}
+
+// ReconcileCounts mocks base method.
+func (m *MockInteractiveRepository) ReconcileCounts(ctx context.Context, biz string, afterId int64, limit int, dryRun bool) (domain.ReconcileBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileCounts", ctx, biz, afterId, limit, dryRun)
+	ret0, _ := ret[0].(domain.ReconcileBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileCounts indicates an expected call of ReconcileCounts.
+func (mr *MockInteractiveRepositoryMockRecorder) ReconcileCounts(ctx, biz, afterId, limit, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileCounts", reflect.TypeOf((*MockInteractiveRepository)(nil).ReconcileCounts), ctx, biz, afterId, limit, dryRun)
+}
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/repository/upload.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/repository/upload.go -package=repomocks -destination=./webook/internal/repository/mocks/upload_mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+	time "time"
+	domain "webook/webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUploadRepository is a mock of UploadRepository interface.
+type MockUploadRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadRepositoryMockRecorder
+}
+
+// MockUploadRepositoryMockRecorder is the mock recorder for MockUploadRepository.
+type MockUploadRepositoryMockRecorder struct {
+	mock *MockUploadRepository
+}
+
+// NewMockUploadRepository creates a new mock instance.
+func NewMockUploadRepository(ctrl *gomock.Controller) *MockUploadRepository {
+	mock := &MockUploadRepository{ctrl: ctrl}
+	mock.recorder = &MockUploadRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadRepository) EXPECT() *MockUploadRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUploadRepository) Create(ctx context.Context, u domain.Upload, r io.ReadSeeker) (domain.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, u, r)
+	ret0, _ := ret[0].(domain.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUploadRepositoryMockRecorder) Create(ctx, u, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUploadRepository)(nil).Create), ctx, u, r)
+}
+
+// Delete mocks base method.
+func (m *MockUploadRepository) Delete(ctx context.Context, uploads []domain.Upload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, uploads)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUploadRepositoryMockRecorder) Delete(ctx, uploads any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUploadRepository)(nil).Delete), ctx, uploads)
+}
+
+// DeleteByArticleIds mocks base method.
+func (m *MockUploadRepository) DeleteByArticleIds(ctx context.Context, aids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByArticleIds", ctx, aids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByArticleIds indicates an expected call of DeleteByArticleIds.
+func (mr *MockUploadRepositoryMockRecorder) DeleteByArticleIds(ctx, aids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByArticleIds", reflect.TypeOf((*MockUploadRepository)(nil).DeleteByArticleIds), ctx, aids)
+}
+
+// ListBefore mocks base method.
+func (m *MockUploadRepository) ListBefore(ctx context.Context, before time.Time, startId, limit int64) ([]domain.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBefore", ctx, before, startId, limit)
+	ret0, _ := ret[0].([]domain.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBefore indicates an expected call of ListBefore.
+func (mr *MockUploadRepositoryMockRecorder) ListBefore(ctx, before, startId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBefore", reflect.TypeOf((*MockUploadRepository)(nil).ListBefore), ctx, before, startId, limit)
+}
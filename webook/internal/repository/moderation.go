@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository/dao"
+)
+
+// ErrModerationItemNotFound is also returned if the item is reviewed already
+var ErrModerationItemNotFound = dao.ErrRecordNotFound
+
+type ModerationRepository interface {
+	Create(ctx context.Context, item domain.ModerationItem) (int64, error)
+	GetById(ctx context.Context, id int64) (domain.ModerationItem, error)
+	ListPending(ctx context.Context, offset int64, limit int64) ([]domain.ModerationItem, error)
+	// Review mark the pending item approved or rejected,
+	// return ErrModerationItemNotFound if it's not pending
+	Review(ctx context.Context, id int64, reviewer int64, status domain.ModerationStatus) error
+	// Reopen put the approved item back to pending,
+	// return ErrModerationItemNotFound if it's not approved
+	Reopen(ctx context.Context, id int64) error
+}
+
+type ModerationRepo struct {
+	dao dao.ModerationDAO
+}
+
+func NewModerationRepo(dao dao.ModerationDAO) ModerationRepository {
+	return &ModerationRepo{
+		dao: dao,
+	}
+}
+
+func (r *ModerationRepo) Create(ctx context.Context, item domain.ModerationItem) (int64, error) {
+	var unlisted uint8
+	if item.Unlisted {
+		unlisted = 1
+	}
+	return r.dao.Insert(ctx, dao.ModerationItem{
+		Biz:      item.Biz,
+		BizId:    item.BizId,
+		Uid:      item.Uid,
+		Title:    item.Title,
+		Content:  item.Content,
+		Version:  item.Version,
+		Words:    strings.Join(item.Words, "\n"),
+		Unlisted: unlisted,
+		Status:   uint8(domain.ModerationStatusPending),
+	})
+}
+
+func (r *ModerationRepo) GetById(ctx context.Context, id int64) (domain.ModerationItem, error) {
+	item, err := r.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.ModerationItem{}, err
+	}
+	return r.toDomain(item), nil
+}
+
+func (r *ModerationRepo) ListPending(ctx context.Context,
+	offset int64, limit int64) ([]domain.ModerationItem, error) {
+	items, err := r.dao.ListByStatus(ctx, uint8(domain.ModerationStatusPending), offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.ModerationItem, 0, len(items))
+	for _, item := range items {
+		res = append(res, r.toDomain(item))
+	}
+	return res, nil
+}
+
+func (r *ModerationRepo) Review(ctx context.Context,
+	id int64, reviewer int64, status domain.ModerationStatus) error {
+	return r.dao.UpdateStatus(ctx, id, uint8(domain.ModerationStatusPending),
+		uint8(status), reviewer)
+}
+
+func (r *ModerationRepo) Reopen(ctx context.Context, id int64) error {
+	return r.dao.UpdateStatus(ctx, id, uint8(domain.ModerationStatusApproved),
+		uint8(domain.ModerationStatusPending), 0)
+}
+
+func (r *ModerationRepo) toDomain(item dao.ModerationItem) domain.ModerationItem {
+	var words []string
+	if item.Words != "" {
+		words = strings.Split(item.Words, "\n")
+	}
+	return domain.ModerationItem{
+		Id:       item.Id,
+		Biz:      item.Biz,
+		BizId:    item.BizId,
+		Uid:      item.Uid,
+		Title:    item.Title,
+		Content:  item.Content,
+		Version:  item.Version,
+		Words:    words,
+		Unlisted: item.Unlisted == 1,
+		Status:   domain.ModerationStatus(item.Status),
+		Reviewer: item.Reviewer,
+		Ctime:    time.UnixMilli(item.Ctime),
+		Utime:    time.UnixMilli(item.Utime),
+	}
+}
@@ -9,6 +9,9 @@ import (
 type RankingRepository interface {
 	ReplaceTopN(ctx context.Context, artis []domain.Article) error
 	GetTopN(ctx context.Context) ([]domain.Article, error)
+	// RemoveFromTopN drop the article from the cached top n,
+	// it's gone from the next ranking anyway
+	RemoveFromTopN(ctx context.Context, id int64) error
 }
 
 type CachedRankingRepository struct {
@@ -48,3 +51,8 @@ func (r *CachedRankingRepository) GetTopN(ctx context.Context) ([]domain.Article
 		return res, nil
 	}
 }
+
+func (r *CachedRankingRepository) RemoveFromTopN(ctx context.Context, id int64) error {
+	_ = r.localCache.Remove(ctx, id)
+	return r.redisCache.Remove(ctx, id)
+}
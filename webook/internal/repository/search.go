@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"webook/webook/internal/domain"
+	"webook/webook/pkg/fulltext"
+)
+
+// SearchRepository is the full-text index of the published articles,
+// it could be backed by MySQL FULLTEXT or an external search engine.
+type SearchRepository interface {
+	InputArticle(ctx context.Context, arti domain.Article) error
+	DeleteArticle(ctx context.Context, id int64) error
+	// ReplaceArticles drop the whole index and rebuild it from artis
+	ReplaceArticles(ctx context.Context, artis []domain.Article) error
+	SearchArticle(ctx context.Context, query string, offset, limit int) (domain.ArticleSearchResult, error)
+}
+
+// MemorySearchRepository keeps the index in the memory of each instance
+type MemorySearchRepository struct {
+	idx *fulltext.Index
+}
+
+func NewMemorySearchRepository() SearchRepository {
+	return &MemorySearchRepository{
+		idx: fulltext.NewIndex(),
+	}
+}
+
+func (r *MemorySearchRepository) InputArticle(ctx context.Context,
+	arti domain.Article) error {
+	r.idx.Put(r.toDocument(arti))
+	return nil
+}
+
+func (r *MemorySearchRepository) DeleteArticle(ctx context.Context,
+	id int64) error {
+	r.idx.Delete(id)
+	return nil
+}
+
+func (r *MemorySearchRepository) ReplaceArticles(ctx context.Context,
+	artis []domain.Article) error {
+	docs := make([]fulltext.Document, 0, len(artis))
+	for _, arti := range artis {
+		docs = append(docs, r.toDocument(arti))
+	}
+	r.idx.Replace(docs)
+	return nil
+}
+
+func (r *MemorySearchRepository) SearchArticle(ctx context.Context,
+	query string, offset, limit int) (domain.ArticleSearchResult, error) {
+	hits, total := r.idx.Search(query, offset, limit)
+	res := domain.ArticleSearchResult{
+		Total: total,
+		Hits:  make([]domain.ArticleSearchHit, 0, len(hits)),
+	}
+	for _, hit := range hits {
+		author, _ := hit.Payload.(domain.Author)
+		res.Hits = append(res.Hits, domain.ArticleSearchHit{
+			Id:      hit.Id,
+			Author:  author,
+			Score:   hit.Score,
+			Title:   hit.Title,
+			Snippet: hit.Snippet,
+		})
+	}
+	return res, nil
+}
+
+func (r *MemorySearchRepository) toDocument(arti domain.Article) fulltext.Document {
+	return fulltext.Document{
+		Id:      arti.Id,
+		Title:   arti.Title,
+		Content: arti.Content,
+		Payload: arti.Author,
+	}
+}
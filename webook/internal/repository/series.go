@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository/dao"
+)
+
+var (
+	ErrSeriesNotFound         = dao.ErrRecordNotFound
+	ErrSeriesArticleDuplicate = dao.ErrSeriesArticleDuplicate
+)
+
+type SeriesRepository interface {
+	Create(ctx context.Context, s domain.Series) (int64, error)
+	// GetById return the series with its chapters
+	GetById(ctx context.Context, id int64) (domain.Series, error)
+	ListByAuthor(ctx context.Context, authorId int64, offset, limit int64) ([]domain.Series, error)
+	// GetByArticleId return the series with its chapters which contains
+	// the article, ErrSeriesNotFound if there is no such series
+	GetByArticleId(ctx context.Context, articleId int64) (domain.Series, error)
+	SetArticleIds(ctx context.Context, id int64, articleIds []int64) error
+	// DeleteArticles remove the articles from their series
+	DeleteArticles(ctx context.Context, articleIds []int64) error
+}
+
+type SeriesRepo struct {
+	dao dao.SeriesDAO
+}
+
+func NewSeriesRepo(dao dao.SeriesDAO) SeriesRepository {
+	return &SeriesRepo{
+		dao: dao,
+	}
+}
+
+func (r *SeriesRepo) Create(ctx context.Context, s domain.Series) (int64, error) {
+	return r.dao.Insert(ctx, dao.Series{
+		Title:    s.Title,
+		AuthorId: s.AuthorId,
+	})
+}
+
+func (r *SeriesRepo) GetById(ctx context.Context, id int64) (domain.Series, error) {
+	s, err := r.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	return r.withArticleIds(ctx, s)
+}
+
+func (r *SeriesRepo) ListByAuthor(ctx context.Context,
+	authorId int64, offset, limit int64) ([]domain.Series, error) {
+	series, err := r.dao.GetByAuthorId(ctx, authorId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Series, 0, len(series))
+	for _, s := range series {
+		res = append(res, r.toDomain(s, nil))
+	}
+	return res, nil
+}
+
+func (r *SeriesRepo) GetByArticleId(ctx context.Context,
+	articleId int64) (domain.Series, error) {
+	s, err := r.dao.GetByArticleId(ctx, articleId)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	return r.withArticleIds(ctx, s)
+}
+
+func (r *SeriesRepo) SetArticleIds(ctx context.Context,
+	id int64, articleIds []int64) error {
+	return r.dao.SetArticleIds(ctx, id, articleIds)
+}
+
+func (r *SeriesRepo) DeleteArticles(ctx context.Context, articleIds []int64) error {
+	return r.dao.DeleteArticles(ctx, articleIds)
+}
+
+func (r *SeriesRepo) withArticleIds(ctx context.Context,
+	s dao.Series) (domain.Series, error) {
+	ids, err := r.dao.GetArticleIds(ctx, s.Id)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	return r.toDomain(s, ids), nil
+}
+
+func (r *SeriesRepo) toDomain(s dao.Series, articleIds []int64) domain.Series {
+	return domain.Series{
+		Id:         s.Id,
+		Title:      s.Title,
+		AuthorId:   s.AuthorId,
+		ArticleIds: articleIds,
+		Ctime:      time.UnixMilli(s.Ctime),
+		Utime:      time.UnixMilli(s.Utime),
+	}
+}
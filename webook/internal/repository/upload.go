@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository/dao"
+)
+
+type UploadRepository interface {
+	// Create put the file into the object storage and record it,
+	// the returned upload has the id and the url
+	Create(ctx context.Context, u domain.Upload, r io.ReadSeeker) (domain.Upload, error)
+	ListBefore(ctx context.Context, before time.Time, startId int64, limit int64) ([]domain.Upload, error)
+	// Delete remove the files and then their records
+	Delete(ctx context.Context, uploads []domain.Upload) error
+	DeleteByArticleIds(ctx context.Context, aids []int64) error
+}
+
+type UploadRepo struct {
+	dao     dao.UploadDAO
+	storage dao.ObjectStorage
+}
+
+func NewUploadRepo(dao dao.UploadDAO, storage dao.ObjectStorage) UploadRepository {
+	return &UploadRepo{
+		dao:     dao,
+		storage: storage,
+	}
+}
+
+func (r *UploadRepo) Create(ctx context.Context,
+	u domain.Upload, reader io.ReadSeeker) (domain.Upload, error) {
+	err := r.storage.Put(ctx, u.Key, reader, u.Size, u.ContentType)
+	if err != nil {
+		return domain.Upload{}, err
+	}
+	id, err := r.dao.Insert(ctx, dao.Upload{
+		ObjectKey:   u.Key,
+		ArticleId:   u.ArticleId,
+		Uid:         u.Uid,
+		Size:        u.Size,
+		ContentType: u.ContentType,
+	})
+	if err != nil {
+		// The file is not recorded, nothing refers to it
+		er := r.storage.Delete(ctx, u.Key)
+		if er != nil {
+			// log
+		}
+		return domain.Upload{}, err
+	}
+	u.Id = id
+	u.URL = r.storage.URL(u.Key)
+	return u, nil
+}
+
+func (r *UploadRepo) ListBefore(ctx context.Context,
+	before time.Time, startId int64, limit int64) ([]domain.Upload, error) {
+	uploads, err := r.dao.ListBefore(ctx, before.UnixMilli(), startId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomains(uploads), nil
+}
+
+// Delete keep the records if any file fails,
+// so they are deleted again next time
+func (r *UploadRepo) Delete(ctx context.Context, uploads []domain.Upload) error {
+	ids := make([]int64, 0, len(uploads))
+	for _, u := range uploads {
+		err := r.storage.Delete(ctx, u.Key)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, u.Id)
+	}
+	return r.dao.DeleteByIds(ctx, ids)
+}
+
+func (r *UploadRepo) DeleteByArticleIds(ctx context.Context, aids []int64) error {
+	uploads, err := r.dao.ListByArticleIds(ctx, aids)
+	if err != nil {
+		return err
+	}
+	return r.Delete(ctx, r.toDomains(uploads))
+}
+
+func (r *UploadRepo) toDomains(uploads []dao.Upload) []domain.Upload {
+	res := make([]domain.Upload, 0, len(uploads))
+	for _, u := range uploads {
+		res = append(res, domain.Upload{
+			Id:          u.Id,
+			Key:         u.ObjectKey,
+			URL:         r.storage.URL(u.ObjectKey),
+			ArticleId:   u.ArticleId,
+			Uid:         u.Uid,
+			Size:        u.Size,
+			ContentType: u.ContentType,
+			Ctime:       time.UnixMilli(u.Ctime),
+		})
+	}
+	return res
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+	"webook/webook/internal/repository/cache"
+	"webook/webook/internal/repository/dao"
+	"webook/webook/pkg/logger"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// viewFlushTimeout is the timeout of a flush by the window
+const viewFlushTimeout = 5 * time.Second
+
+type viewKey struct {
+	biz string
+	id  int64
+}
+
+// ViewCountAggregator buffer the view increments and merge them by the
+// resource, they are written in one upsert every window. The views in the
+// buffer are lost if the process crashes, it's fine for the view counts.
+// It's a prometheus.Collector of the buffer size and the flush latency
+type ViewCountAggregator struct {
+	dao    dao.InteractiveDAO
+	cache  cache.InteractiveCache
+	window time.Duration
+	l      logger.Logger
+
+	mu     sync.Mutex
+	buf    map[viewKey]int64
+	closed bool
+	stop   chan struct{}
+	done   chan struct{}
+
+	bufSize      prometheus.Gauge
+	flushLatency *prometheus.SummaryVec
+}
+
+// NewViewCountAggregator start flushing every window until it's closed
+func NewViewCountAggregator(dao dao.InteractiveDAO, cache cache.InteractiveCache,
+	window time.Duration, l logger.Logger) *ViewCountAggregator {
+	a := &ViewCountAggregator{
+		dao:    dao,
+		cache:  cache,
+		window: window,
+		l:      l,
+		buf:    make(map[viewKey]int64),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+		bufSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "webook",
+			Subsystem: "interactive",
+			Name:      "view_buffer_size",
+			Help:      "The resources with the views not written yet",
+		}),
+		flushLatency: prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Namespace: "webook",
+			Subsystem: "interactive",
+			Name:      "view_flush_latency",
+			Help:      "The milliseconds to write the buffered views",
+			Objectives: map[float64]float64{
+				0.5:  0.05,
+				0.9:  0.01,
+				0.99: 0.001,
+			},
+		}, []string{"success"}),
+	}
+	go a.loop()
+	return a
+}
+
+// Add buffer the views of the resource, they are written
+// at once after the aggregator is closed
+func (a *ViewCountAggregator) Add(ctx context.Context,
+	biz string, id int64, delta int64) error {
+	a.mu.Lock()
+	a.buf[viewKey{biz: biz, id: id}] += delta
+	a.bufSize.Set(float64(len(a.buf)))
+	closed := a.closed
+	a.mu.Unlock()
+	if !closed {
+		return nil
+	}
+	return a.Flush(ctx)
+}
+
+// Flush write the buffered views in one upsert, they are put back into
+// the buffer for the next flush if it fails
+func (a *ViewCountAggregator) Flush(ctx context.Context) error {
+	a.mu.Lock()
+	buf := a.buf
+	a.buf = make(map[viewKey]int64, len(buf))
+	a.bufSize.Set(0)
+	a.mu.Unlock()
+	if len(buf) == 0 {
+		return nil
+	}
+
+	cnts := make([]dao.InteractiveCount, 0, len(buf))
+	for k, delta := range buf {
+		cnts = append(cnts, dao.InteractiveCount{
+			Biz:     k.biz,
+			BizId:   k.id,
+			ViewCnt: delta,
+		})
+	}
+	// The same order in all the instances, so they don't deadlock
+	slices.SortFunc(cnts, func(x, y dao.InteractiveCount) int {
+		if x.Biz != y.Biz {
+			return cmp.Compare(x.Biz, y.Biz)
+		}
+		return cmp.Compare(x.BizId, y.BizId)
+	})
+
+	start := time.Now()
+	err := a.dao.IncreaseViewCountBatch(ctx, cnts)
+	a.flushLatency.WithLabelValues(strconv.FormatBool(err == nil)).
+		Observe(float64(time.Since(start).Milliseconds()))
+	if err != nil {
+		a.mu.Lock()
+		for k, delta := range buf {
+			a.buf[k] += delta
+		}
+		a.bufSize.Set(float64(len(a.buf)))
+		a.mu.Unlock()
+		return err
+	}
+
+	for _, cnt := range cnts {
+		er := a.cache.IncreaseViewCountIfPresent(ctx, cnt.Biz, cnt.BizId, cnt.ViewCnt)
+		if er != nil {
+			a.l.Error("Failed to increase view count Cache",
+				logger.Error(er),
+				logger.String("biz", cnt.Biz),
+				logger.Int64("bizId", cnt.BizId))
+		}
+	}
+	return nil
+}
+
+// Close stop the flushing by the window and flush the rest,
+// it's called on shutdown
+func (a *ViewCountAggregator) Close(ctx context.Context) error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return nil
+	}
+	a.closed = true
+	a.mu.Unlock()
+	close(a.stop)
+	<-a.done
+	return a.Flush(ctx)
+}
+
+func (a *ViewCountAggregator) loop() {
+	defer close(a.done)
+	ticker := time.NewTicker(a.window)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), viewFlushTimeout)
+			err := a.Flush(ctx)
+			cancel()
+			if err != nil {
+				a.l.Error("Failed to flush view counts", logger.Error(err))
+			}
+		case <-a.stop:
+			return
+		}
+	}
+}
+
+func (a *ViewCountAggregator) Describe(ch chan<- *prometheus.Desc) {
+	a.bufSize.Describe(ch)
+	a.flushLatency.Describe(ch)
+}
+
+func (a *ViewCountAggregator) Collect(ch chan<- prometheus.Metric) {
+	a.bufSize.Collect(ch)
+	a.flushLatency.Collect(ch)
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	cachemocks "webook/webook/internal/repository/cache/mocks"
+	"webook/webook/internal/repository/dao"
+	daomocks "webook/webook/internal/repository/dao/mocks"
+	"webook/webook/pkg/logger"
+)
+
+func TestViewCountAggregator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	d := daomocks.NewMockInteractiveDAO(ctrl)
+	c := cachemocks.NewMockInteractiveCache(ctrl)
+	// The window is too long to flush in the test
+	views := NewViewCountAggregator(d, c, time.Hour, logger.NewNopLogger())
+	repo := NewCachedInteractiveRepository(d, c, nil, views, logger.NewNopLogger())
+	ctx := context.Background()
+
+	// Both the single and the batch increments are merged
+	require.NoError(t, repo.IncreaseViewCount(ctx, "article", 2))
+	require.NoError(t, repo.IncreaseViewCountBatch(ctx,
+		[]string{"article", "article", "comment"}, []int64{1, 2, 1}))
+	require.NoError(t, repo.IncreaseViewCount(ctx, "article", 2))
+
+	merged := []dao.InteractiveCount{
+		{Biz: "article", BizId: 1, ViewCnt: 1},
+		{Biz: "article", BizId: 2, ViewCnt: 3},
+		{Biz: "comment", BizId: 1, ViewCnt: 1},
+	}
+	d.EXPECT().IncreaseViewCountBatch(gomock.Any(), merged).
+		Return(errors.New("db error"))
+	assert.Equal(t, errors.New("db error"), views.Flush(ctx))
+
+	// The failed ones are kept for the next flush
+	require.NoError(t, repo.IncreaseViewCount(ctx, "article", 1))
+	merged[0].ViewCnt = 2
+	d.EXPECT().IncreaseViewCountBatch(gomock.Any(), merged).Return(nil)
+	c.EXPECT().IncreaseViewCountIfPresent(gomock.Any(), "article", int64(1), int64(2)).
+		Return(nil)
+	c.EXPECT().IncreaseViewCountIfPresent(gomock.Any(), "article", int64(2), int64(3)).
+		Return(errors.New("redis error"))
+	c.EXPECT().IncreaseViewCountIfPresent(gomock.Any(), "comment", int64(1), int64(1)).
+		Return(nil)
+	require.NoError(t, views.Flush(ctx))
+	// Nothing to flush
+	require.NoError(t, views.Flush(ctx))
+
+	// The rest is flushed on close, and the views after it are written at once
+	require.NoError(t, repo.IncreaseViewCount(ctx, "article", 3))
+	d.EXPECT().IncreaseViewCountBatch(gomock.Any(), []dao.InteractiveCount{
+		{Biz: "article", BizId: 3, ViewCnt: 1},
+	}).Return(nil).Times(2)
+	c.EXPECT().IncreaseViewCountIfPresent(gomock.Any(), "article", int64(3), int64(1)).
+		Return(nil).Times(2)
+	require.NoError(t, views.Close(ctx))
+	require.NoError(t, views.Close(ctx))
+	require.NoError(t, repo.IncreaseViewCount(ctx, "article", 3))
+}
+
+func TestViewCountAggregator_Window(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	d := daomocks.NewMockInteractiveDAO(ctrl)
+	c := cachemocks.NewMockInteractiveCache(ctrl)
+	flushed := make(chan struct{})
+	d.EXPECT().IncreaseViewCountBatch(gomock.Any(), []dao.InteractiveCount{
+		{Biz: "article", BizId: 1, ViewCnt: 2},
+	}).DoAndReturn(func(ctx context.Context, cnts []dao.InteractiveCount) error {
+		close(flushed)
+		return nil
+	})
+	c.EXPECT().IncreaseViewCountIfPresent(gomock.Any(), "article", int64(1), int64(2)).
+		Return(nil)
+
+	views := NewViewCountAggregator(d, c, 10*time.Millisecond, logger.NewNopLogger())
+	require.NoError(t, views.Add(context.Background(), "article", 1, 2))
+	select {
+	case <-flushed:
+	case <-time.After(time.Second):
+		t.Fatal("not flushed in the window")
+	}
+	require.NoError(t, views.Close(context.Background()))
+}
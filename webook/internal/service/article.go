@@ -2,11 +2,23 @@ package service
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
 	"webook/webook/internal/domain"
 	"webook/webook/internal/events/article"
 	"webook/webook/internal/repository"
+	"webook/webook/pkg/linediff"
 	"webook/webook/pkg/logger"
+	"webook/webook/pkg/moderation"
+)
+
+var (
+	ErrArticleRevisionNotFound = errors.New("article revision not found")
+	ErrArticleDiffTooLarge     = linediff.ErrTooLarge
+	ErrArticleNotScheduled     = repository.ErrArticleNotScheduled
+	ErrArticleVersionConflict  = repository.ErrArticleVersionConflict
+	ErrArticleNotFound         = repository.ErrArticleNotFound
 )
 
 type ArticleService interface {
@@ -14,63 +26,262 @@ type ArticleService interface {
 	Publish(ctx context.Context, arti domain.Article) (int64, error)
 	Withdraw(ctx context.Context, uid int64, id int64) error
 	GetByAuthor(ctx context.Context, uid int64, offset int64, limit int64) ([]domain.Article, error)
-	GetById(ctx context.Context, id int64) (domain.Article, error)
+	GetByAuthorAfter(ctx context.Context, uid int64, cursor domain.Cursor, limit int64) ([]domain.Article, error)
+	// GetById return the draft for the owner and the collaborators
+	GetById(ctx context.Context, uid, id int64) (domain.Article, error)
+	// GetPubById return ErrArticleNotFound for the withdrawn article, and
+	// for the unlisted one which is only read by the share links
 	GetPubById(ctx context.Context, uid, id int64) (domain.Article, error)
 	ListPub(ctx context.Context, start time.Time, offset, limit int64) ([]domain.Article, error)
+	ListPubAfter(ctx context.Context, cursor domain.Cursor, limit int64) ([]domain.Article, error)
+	ListRevisions(ctx context.Context, uid, aid, offset, limit int64) ([]domain.ArticleRevision, error)
+	DiffRevisions(ctx context.Context, uid, aid, from, to int64) (domain.ArticleRevisionDiff, error)
+	RestoreRevision(ctx context.Context, uid, aid, rid int64) (int64, error)
+	ListPubByTag(ctx context.Context, tag string, start time.Time, offset, limit int64) ([]domain.Article, error)
+	// ListPubByAuthor is ListPub of the author
+	ListPubByAuthor(ctx context.Context, uid int64, start time.Time, offset, limit int64) ([]domain.Article, error)
+	ListPopularTags(ctx context.Context, limit int64) ([]domain.Tag, error)
+	CancelSchedule(ctx context.Context, uid, id int64) error
+	PublishDue(ctx context.Context, now time.Time, limit int64) (int, error)
+	// PublishReviewed publish the article approved by the manual review,
+	// return ErrArticleVersionConflict if it's edited after submitting
+	PublishReviewed(ctx context.Context, id, version int64, unlisted bool) error
 }
 
 type ImplArticleService struct {
-	repo     repository.ArticleRepository
-	producer article.Producer
-	l        logger.Logger
+	authorRepo repository.ArticleAuthorRepository
+	readerRepo repository.ArticleReaderRepository
+	revRepo    repository.ArticleRevisionRepository
+	feedRepo   repository.FeedRepository
+	searchSvc  SearchService
+	moderator  ModerationService
+	producer   article.Producer
+	l          logger.Logger
+
+	perm articlePermission
 }
 
-func NewImplArticleService(repo repository.ArticleRepository,
+func NewImplArticleService(authorRepo repository.ArticleAuthorRepository,
+	readerRepo repository.ArticleReaderRepository,
+	revRepo repository.ArticleRevisionRepository,
+	collabRepo repository.CollaboratorRepository,
+	feedRepo repository.FeedRepository,
+	searchSvc SearchService,
+	moderator ModerationService,
 	producer article.Producer,
 	l logger.Logger) ArticleService {
 	return &ImplArticleService{
-		repo:     repo,
-		producer: producer,
-		l:        l,
+		authorRepo: authorRepo,
+		readerRepo: readerRepo,
+		revRepo:    revRepo,
+		feedRepo:   feedRepo,
+		searchSvc:  searchSvc,
+		moderator:  moderator,
+		producer:   producer,
+		l:          l,
+		perm: articlePermission{
+			authorRepo: authorRepo,
+			collabRepo: collabRepo,
+		},
 	}
 }
 
+// Save save the article as a draft, a scheduled article is unscheduled.
+// arti.Author is the one saving it, the owner or an editor.
+// It returns ErrArticleVersionConflict if arti.Version is not the current one
 func (s *ImplArticleService) Save(ctx context.Context, arti domain.Article) (int64, error) {
-	arti.Status = domain.ArticleStatusUnpublished
 	if arti.Id > 0 {
-		err := s.repo.Update(ctx, arti)
-		return arti.Id, err
-	} else {
-		return s.repo.Create(ctx, arti)
+		cur, err := s.perm.check(ctx, arti.Author.Id, arti.Id, domain.CollaboratorRoleEditor)
+		if err != nil {
+			return 0, err
+		}
+		// The article and its revisions stay the owner's
+		arti.Author = cur.Author
 	}
+	return s.saveDraft(ctx, arti)
+}
+
+func (s *ImplArticleService) saveDraft(ctx context.Context, arti domain.Article) (int64, error) {
+	arti.Tags = normalizeTags(arti.Tags)
+	arti.Status = domain.ArticleStatusUnpublished
+	arti.PublishAt = time.Time{}
+	return s.save(ctx, arti)
 }
 
+// Publish publish the article right now, or schedule it
+// when PublishAt is in the future.
+// If arti.Status is ArticleStatusUnlisted, it's published unlisted right now,
+// PublishAt is ignored.
+// The title and the content are moderated first, ErrContentRejected is
+// returned if they have the words to reject. If they need the manual
+// review, the article is saved as a draft and queued, the id is returned
+// with ErrContentUnderReview.
+// The author side is saved before the reader side, they may be on
+// different databases, so if the reader side fails, publishing again repairs it
 func (s *ImplArticleService) Publish(ctx context.Context, arti domain.Article) (int64, error) {
+	if arti.Id > 0 {
+		_, err := s.perm.check(ctx, arti.Author.Id, arti.Id, domain.CollaboratorRoleOwner)
+		if err != nil {
+			return 0, err
+		}
+	}
+	res := s.moderator.Check(ctx, arti.Title, arti.Content)
+	switch res.Action {
+	case moderation.ActionReject:
+		return 0, ErrContentRejected
+	case moderation.ActionReview:
+		id, err := s.submitForReview(ctx, arti, res.Words)
+		if err != nil {
+			return 0, err
+		}
+		return id, ErrContentUnderReview
+	}
+	arti.Title, arti.Content = res.Texts[0], res.Texts[1]
 
-	arti.Status = domain.ArticleStatusPublished
-	return s.repo.Sync(ctx, arti)
+	arti.Tags = normalizeTags(arti.Tags)
+	if arti.Status == domain.ArticleStatusUnlisted {
+		return s.publish(ctx, arti)
+	}
+	if arti.PublishAt.After(time.Now()) {
+		arti.Status = domain.ArticleStatusScheduled
+		return s.save(ctx, arti)
+	}
+	return s.publish(ctx, arti)
+}
+
+// publish keep the unlisted status, the others are published
+func (s *ImplArticleService) publish(ctx context.Context, arti domain.Article) (int64, error) {
+	if arti.Status != domain.ArticleStatusUnlisted {
+		arti.Status = domain.ArticleStatusPublished
+	}
+	arti.PublishAt = time.Time{}
+	id, err := s.save(ctx, arti)
+	if err != nil {
+		return 0, err
+	}
+	arti.Id = id
+	err = s.readerRepo.Save(ctx, arti)
+	if err != nil {
+		return 0, err
+	}
+	s.indexArticle(ctx, id)
+	s.invalidateFeeds(ctx, arti.Author.Id)
+	return id, nil
+}
+
+// submitForReview save the article as a draft and queue the version saved
+func (s *ImplArticleService) submitForReview(ctx context.Context,
+	arti domain.Article, words []string) (int64, error) {
+	unlisted := arti.Status == domain.ArticleStatusUnlisted
+	id, err := s.saveDraft(ctx, arti)
+	if err != nil {
+		return 0, err
+	}
+	draft, err := s.authorRepo.GetById(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	err = s.moderator.Submit(ctx, domain.ModerationItem{
+		Biz:      "article",
+		BizId:    id,
+		Uid:      draft.Author.Id,
+		Title:    draft.Title,
+		Content:  draft.Content,
+		Version:  draft.Version,
+		Words:    words,
+		Unlisted: unlisted,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (s *ImplArticleService) PublishReviewed(ctx context.Context,
+	id, version int64, unlisted bool) error {
+	arti, err := s.authorRepo.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if arti.Version != version {
+		return ErrArticleVersionConflict
+	}
+	// The words may be changed since submitting
+	res := s.moderator.Check(ctx, arti.Title, arti.Content)
+	arti.Title, arti.Content = res.Texts[0], res.Texts[1]
+	if unlisted {
+		arti.Status = domain.ArticleStatusUnlisted
+	}
+	_, err = s.publish(ctx, arti)
+	return err
+}
+
+func (s *ImplArticleService) save(ctx context.Context, arti domain.Article) (int64, error) {
+	if arti.Id > 0 {
+		err := s.authorRepo.Update(ctx, arti)
+		if err != nil {
+			return 0, err
+		}
+	} else {
+		id, err := s.authorRepo.Create(ctx, arti)
+		if err != nil {
+			return 0, err
+		}
+		arti.Id = id
+	}
+	s.recordRevision(ctx, arti)
+	return arti.Id, nil
 }
 
 func (s *ImplArticleService) Withdraw(ctx context.Context,
 	uid int64, id int64) error {
+	_, err := s.perm.check(ctx, uid, id, domain.CollaboratorRoleOwner)
+	if err != nil {
+		return err
+	}
 
-	return s.repo.SyncStatus(ctx, uid, id, domain.ArticleStatusPrivate)
+	err = s.authorRepo.UpdateStatus(ctx, uid, id, domain.ArticleStatusPrivate)
+	if err != nil {
+		return err
+	}
+	err = s.readerRepo.UpdateStatus(ctx, id, domain.ArticleStatusPrivate)
+	if err != nil {
+		return err
+	}
+	s.invalidateFeeds(ctx, uid)
+	err = s.searchSvc.DeleteArticle(ctx, id)
+	if err != nil {
+		s.l.Error("Failed to delete article from search index",
+			logger.Error(err),
+			logger.Int64("id", id))
+	}
+	return nil
 }
 
 func (s *ImplArticleService) GetByAuthor(ctx context.Context,
 	uid int64, offset int64, limit int64) ([]domain.Article, error) {
 
-	return s.repo.GetByAuthor(ctx, uid, offset, limit)
+	return s.authorRepo.GetByAuthor(ctx, uid, offset, limit)
 }
 
-func (s *ImplArticleService) GetById(ctx context.Context, id int64) (domain.Article, error) {
-	return s.repo.GetById(ctx, id)
+func (s *ImplArticleService) GetByAuthorAfter(ctx context.Context,
+	uid int64, cursor domain.Cursor, limit int64) ([]domain.Article, error) {
+
+	return s.authorRepo.GetByAuthorAfter(ctx, uid, cursor, limit)
+}
+
+func (s *ImplArticleService) GetById(ctx context.Context, uid, id int64) (domain.Article, error) {
+	return s.perm.check(ctx, uid, id, domain.CollaboratorRoleViewer)
 }
 
 func (s *ImplArticleService) GetPubById(ctx context.Context,
 	uid, id int64) (domain.Article, error) {
 
-	res, err := s.repo.GetPubById(ctx, id)
+	res, err := s.readerRepo.GetPubById(ctx, id)
+	if err == nil && (res.Status == domain.ArticleStatusUnlisted ||
+		res.Status == domain.ArticleStatusPrivate) {
+		return domain.Article{}, ErrArticleNotFound
+	}
 
 	go func() {
 		if err == nil {
@@ -90,5 +301,215 @@ func (s *ImplArticleService) GetPubById(ctx context.Context,
 func (s *ImplArticleService) ListPub(ctx context.Context,
 	start time.Time, offset, limit int64) ([]domain.Article, error) {
 
-	return s.repo.ListPub(ctx, start, offset, limit)
+	return s.readerRepo.ListPub(ctx, start, offset, limit)
+}
+
+func (s *ImplArticleService) ListPubAfter(ctx context.Context,
+	cursor domain.Cursor, limit int64) ([]domain.Article, error) {
+
+	return s.readerRepo.ListPubAfter(ctx, cursor, limit)
+}
+
+func (s *ImplArticleService) ListPubByTag(ctx context.Context,
+	tag string, start time.Time, offset, limit int64) ([]domain.Article, error) {
+
+	return s.readerRepo.ListPubByTag(ctx, strings.TrimSpace(tag), start, offset, limit)
+}
+
+func (s *ImplArticleService) ListPubByAuthor(ctx context.Context,
+	uid int64, start time.Time, offset, limit int64) ([]domain.Article, error) {
+
+	return s.readerRepo.ListPubByAuthor(ctx, uid, start, offset, limit)
+}
+
+func (s *ImplArticleService) ListPopularTags(ctx context.Context,
+	limit int64) ([]domain.Tag, error) {
+
+	return s.readerRepo.ListPopularTags(ctx, limit)
+}
+
+func (s *ImplArticleService) CancelSchedule(ctx context.Context,
+	uid, id int64) error {
+	_, err := s.perm.check(ctx, uid, id, domain.CollaboratorRoleOwner)
+	if err != nil {
+		return err
+	}
+	return s.authorRepo.CancelSchedule(ctx, uid, id)
+}
+
+// PublishDue publish at most limit scheduled articles which are due,
+// return the number of articles published
+func (s *ImplArticleService) PublishDue(ctx context.Context,
+	now time.Time, limit int64) (int, error) {
+
+	artis, err := s.authorRepo.ListScheduled(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	cnt := 0
+	for _, arti := range artis {
+		pub, ok, er := s.authorRepo.PublishScheduled(ctx, arti.Id, now)
+		if er != nil {
+			s.l.Error("Failed to publish scheduled article",
+				logger.Error(er),
+				logger.Int64("id", arti.Id))
+			continue
+		}
+		if !ok {
+			// published by another instance or canceled
+			continue
+		}
+		er = s.readerRepo.Save(ctx, pub)
+		if er != nil {
+			// The author side is published already, the author can publish again
+			s.l.Error("Failed to save scheduled article to reader side",
+				logger.Error(er),
+				logger.Int64("id", arti.Id))
+			continue
+		}
+		cnt++
+		s.indexArticle(ctx, arti.Id)
+		s.invalidateFeeds(ctx, pub.Author.Id)
+	}
+	return cnt, nil
+}
+
+func (s *ImplArticleService) ListRevisions(ctx context.Context,
+	uid, aid, offset, limit int64) ([]domain.ArticleRevision, error) {
+	arti, err := s.perm.check(ctx, uid, aid, domain.CollaboratorRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	return s.revRepo.GetByArticle(ctx, aid, arti.Author.Id, offset, limit)
+}
+
+func (s *ImplArticleService) DiffRevisions(ctx context.Context,
+	uid, aid, from, to int64) (domain.ArticleRevisionDiff, error) {
+	_, err := s.perm.check(ctx, uid, aid, domain.CollaboratorRoleViewer)
+	if err != nil {
+		return domain.ArticleRevisionDiff{}, err
+	}
+
+	fromRev, err := s.getRevision(ctx, aid, from)
+	if err != nil {
+		return domain.ArticleRevisionDiff{}, err
+	}
+	toRev, err := s.getRevision(ctx, aid, to)
+	if err != nil {
+		return domain.ArticleRevisionDiff{}, err
+	}
+
+	// Diff title and content together, title as the first line
+	lines, err := linediff.Diff(fromRev.Title+"\n"+fromRev.Content,
+		toRev.Title+"\n"+toRev.Content)
+	if err != nil {
+		return domain.ArticleRevisionDiff{}, err
+	}
+	res := domain.ArticleRevisionDiff{
+		From:  fromRev,
+		To:    toRev,
+		Lines: make([]domain.DiffLine, 0, len(lines)),
+	}
+	for _, line := range lines {
+		res.Lines = append(res.Lines, domain.DiffLine{
+			Op:   string(line.Op),
+			Text: line.Text,
+		})
+	}
+	return res, nil
+}
+
+// RestoreRevision save the revision as the current draft,
+// the public article will not change until it is published again
+func (s *ImplArticleService) RestoreRevision(ctx context.Context,
+	uid, aid, rid int64) (int64, error) {
+	cur, err := s.perm.check(ctx, uid, aid, domain.CollaboratorRoleEditor)
+	if err != nil {
+		return 0, err
+	}
+
+	rev, err := s.getRevision(ctx, aid, rid)
+	if err != nil {
+		return 0, err
+	}
+	// Revisions only keep title and content, tags stay as they are
+	return s.saveDraft(ctx, domain.Article{
+		Id:      aid,
+		Title:   rev.Title,
+		Content: rev.Content,
+		Tags:    cur.Tags,
+		Version: cur.Version,
+		Author:  cur.Author,
+	})
+}
+
+func (s *ImplArticleService) getRevision(ctx context.Context,
+	aid, rid int64) (domain.ArticleRevision, error) {
+
+	rev, err := s.revRepo.GetById(ctx, rid)
+	if err == repository.ErrArticleRevisionNotFound {
+		return domain.ArticleRevision{}, ErrArticleRevisionNotFound
+	}
+	if err != nil {
+		return domain.ArticleRevision{}, err
+	}
+	if rev.ArticleId != aid {
+		return domain.ArticleRevision{}, ErrArticleRevisionNotFound
+	}
+	return rev, nil
+}
+
+// recordRevision snapshot the article, the article itself is already saved,
+// so the failure is only logged
+func (s *ImplArticleService) recordRevision(ctx context.Context, arti domain.Article) {
+	_, err := s.revRepo.Create(ctx, arti)
+	if err != nil {
+		s.l.Error("Failed to record article revision",
+			logger.Error(err),
+			logger.Int64("id", arti.Id))
+	}
+}
+
+// normalizeTags trim the tags and remove the empty and duplicated ones
+func normalizeTags(tags []string) []string {
+	if len(tags) == 0 {
+		return tags
+	}
+	res := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		res = append(res, tag)
+	}
+	return res
+}
+
+// indexArticle update the search index, the article is already published,
+// so the failure is only logged
+func (s *ImplArticleService) indexArticle(ctx context.Context, id int64) {
+	err := s.searchSvc.IndexArticle(ctx, id)
+	if err != nil {
+		s.l.Error("Failed to index article",
+			logger.Error(err),
+			logger.Int64("id", id))
+	}
+}
+
+// invalidateFeeds drop the cached feeds after publishing or withdrawing,
+// they expire soon anyway if it fails
+func (s *ImplArticleService) invalidateFeeds(ctx context.Context, uid int64) {
+	err := s.feedRepo.Invalidate(ctx, uid)
+	if err != nil {
+		s.l.Error("Failed to invalidate feeds",
+			logger.Error(err),
+			logger.Int64("uid", uid))
+	}
 }
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"unicode/utf8"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+	"webook/webook/pkg/logger"
+	"webook/webook/pkg/mdarchive"
+)
+
+var (
+	ErrArticleArchiveInvalid = errors.New("invalid article archive")
+	// ErrArticleImportInvalid is the error of a file in the archive,
+	// the message is safe to show to the author
+	ErrArticleImportInvalid = errors.New("invalid article file")
+)
+
+const (
+	// The limits of the imported archive
+	maxImportFiles    = 500
+	maxImportFileSize = 1 << 20
+	// The same limits of tags as the editor
+	maxImportTagCnt = 10
+	maxImportTagLen = 64
+
+	exportPageSize = 100
+)
+
+// ArticleArchiveService move the articles of an author in and out as a
+// zip of Markdown files with YAML front matter
+type ArticleArchiveService interface {
+	// Export write the articles of the author into w, the trashed ones
+	// are not included
+	Export(ctx context.Context, uid int64, w io.Writer) error
+	// Import create a draft for every Markdown file in the archive,
+	// a file is created or not as a whole, and the others go on if it fails
+	Import(ctx context.Context, uid int64, r io.ReaderAt, size int64) ([]domain.ArticleImportResult, error)
+}
+
+type ImplArticleArchiveService struct {
+	svc ArticleService
+	// authorRepo is for the export, it reads the full content
+	// which the cached pages don't keep
+	authorRepo repository.ArticleAuthorRepository
+	l          logger.Logger
+}
+
+func NewImplArticleArchiveService(svc ArticleService,
+	authorRepo repository.ArticleAuthorRepository, l logger.Logger) ArticleArchiveService {
+	return &ImplArticleArchiveService{
+		svc:        svc,
+		authorRepo: authorRepo,
+		l:          l,
+	}
+}
+
+func (s *ImplArticleArchiveService) Export(ctx context.Context, uid int64, w io.Writer) error {
+	aw := mdarchive.NewWriter(w)
+	var cursor domain.Cursor
+	for {
+		artis, err := s.authorRepo.ScanByAuthor(ctx, uid, cursor, exportPageSize)
+		if err != nil {
+			return err
+		}
+		// A short page doesn't mean the end if the articles are changed
+		// meanwhile, only an empty one does
+		if len(artis) == 0 {
+			break
+		}
+		for _, arti := range artis {
+			err = aw.Write(arti.Title, mdarchive.Document{
+				FrontMatter: mdarchive.FrontMatter{
+					Title:  arti.Title,
+					Status: arti.Status.String(),
+					Ctime:  arti.Ctime,
+					Utime:  arti.Utime,
+					Tags:   arti.Tags,
+				},
+				Content: arti.Content,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		last := artis[len(artis)-1]
+		cursor = domain.Cursor{Utime: last.Utime, Id: last.Id}
+	}
+	return aw.Close()
+}
+
+func (s *ImplArticleArchiveService) Import(ctx context.Context,
+	uid int64, r io.ReaderAt, size int64) ([]domain.ArticleImportResult, error) {
+	// The files are saved as they're read, only one is in memory
+	var res []domain.ArticleImportResult
+	err := mdarchive.Walk(r, size, maxImportFiles, maxImportFileSize, func(f mdarchive.File) error {
+		id, err := s.importFile(ctx, uid, f)
+		if err != nil && !errors.Is(err, ErrArticleImportInvalid) {
+			s.l.Error("Failed to import article",
+				logger.Error(err),
+				logger.Int64("uid", uid),
+				logger.String("file", f.Name))
+		}
+		res = append(res, domain.ArticleImportResult{
+			File: f.Name,
+			Id:   id,
+			Err:  err,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrArticleArchiveInvalid, err)
+	}
+	return res, nil
+}
+
+// importFile save the file as a new draft, the status and the times in
+// the front matter are ignored
+func (s *ImplArticleArchiveService) importFile(ctx context.Context,
+	uid int64, f mdarchive.File) (int64, error) {
+	if f.Err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrArticleImportInvalid, f.Err)
+	}
+	title := strings.TrimSpace(f.Doc.Title)
+	if title == "" {
+		title = strings.TrimSuffix(path.Base(f.Name), path.Ext(f.Name))
+	}
+	if len(f.Doc.Tags) > maxImportTagCnt {
+		return 0, fmt.Errorf("%w: too many tags", ErrArticleImportInvalid)
+	}
+	for _, tag := range f.Doc.Tags {
+		if utf8.RuneCountInString(tag) > maxImportTagLen {
+			return 0, fmt.Errorf("%w: tag %q is too long", ErrArticleImportInvalid, tag)
+		}
+	}
+	return s.svc.Save(ctx, domain.Article{
+		Title:   title,
+		Content: f.Doc.Content,
+		Tags:    f.Doc.Tags,
+		Author:  domain.Author{Id: uid},
+	})
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"io"
+	"testing"
+	"time"
+	"webook/webook/internal/domain"
+	repomocks "webook/webook/internal/repository/mocks"
+	svcmocks "webook/webook/internal/service/mocks"
+	"webook/webook/pkg/logger"
+	"webook/webook/pkg/mdarchive"
+)
+
+func TestImplArticleArchiveService_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	utime := time.UnixMilli(1700000000000)
+	repo := repomocks.NewMockArticleAuthorRepository(ctrl)
+	page := make([]domain.Article, exportPageSize)
+	for i := range page {
+		page[i] = domain.Article{
+			Id:     int64(exportPageSize + 1 - i),
+			Title:  "Same title",
+			Status: domain.ArticleStatusPublished,
+			Utime:  utime,
+		}
+	}
+	repo.EXPECT().ScanByAuthor(gomock.Any(), int64(123), domain.Cursor{},
+		int64(exportPageSize)).Return(page, nil)
+	repo.EXPECT().ScanByAuthor(gomock.Any(), int64(123),
+		domain.Cursor{Utime: utime, Id: 2}, int64(exportPageSize)).
+		Return([]domain.Article{{
+			Id:      1,
+			Title:   "Hello",
+			Content: "# Hello",
+			Tags:    []string{"go"},
+			Status:  domain.ArticleStatusUnpublished,
+			Utime:   utime,
+		}}, nil)
+	repo.EXPECT().ScanByAuthor(gomock.Any(), int64(123),
+		domain.Cursor{Utime: utime, Id: 1}, int64(exportPageSize)).
+		Return(nil, nil)
+
+	var buf bytes.Buffer
+	archiveSvc := NewImplArticleArchiveService(svcmocks.NewMockArticleService(ctrl),
+		repo, logger.NewNopLogger())
+	err := archiveSvc.Export(context.Background(), 123, &buf)
+	require.NoError(t, err)
+
+	files, err := mdarchive.Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()),
+		exportPageSize+1, 1<<10)
+	require.NoError(t, err)
+	require.Len(t, files, exportPageSize+1)
+	assert.Equal(t, "same-title.md", files[0].Name)
+	assert.Equal(t, "same-title-2.md", files[1].Name)
+	last := files[exportPageSize]
+	assert.Equal(t, "hello.md", last.Name)
+	assert.Equal(t, "draft", last.Doc.Status)
+	assert.Equal(t, []string{"go"}, last.Doc.Tags)
+	assert.Equal(t, "# Hello", last.Doc.Content)
+}
+
+func TestImplArticleArchiveService_Import(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range []struct {
+		name    string
+		content string
+	}{
+		{name: "hello.md", content: "---\ntitle: Hello\nstatus: published\ntags: [go]\n---\n\n# Hello"},
+		{name: "posts/no-front-matter.md", content: "just text"},
+		{name: "broken.md", content: "---\ntitle: [Broken\n---\n"},
+		{name: "db-error.md", content: "---\ntitle: DB\n---\n"},
+		{name: "cover.png", content: "png"},
+	} {
+		fw, err := zw.Create(f.name)
+		require.NoError(t, err)
+		_, err = io.WriteString(fw, f.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc := svcmocks.NewMockArticleService(ctrl)
+	// The status is ignored, they're all drafts
+	svc.EXPECT().Save(gomock.Any(), domain.Article{
+		Title:   "Hello",
+		Content: "# Hello",
+		Tags:    []string{"go"},
+		Author:  domain.Author{Id: 123},
+	}).Return(int64(1), nil)
+	svc.EXPECT().Save(gomock.Any(), domain.Article{
+		Title:   "no-front-matter",
+		Content: "just text",
+		Author:  domain.Author{Id: 123},
+	}).Return(int64(2), nil)
+	svc.EXPECT().Save(gomock.Any(), domain.Article{
+		Title:  "DB",
+		Author: domain.Author{Id: 123},
+	}).Return(int64(0), errors.New("db error"))
+
+	archiveSvc := NewImplArticleArchiveService(svc, nil, logger.NewNopLogger())
+	res, err := archiveSvc.Import(context.Background(), 123,
+		bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, res, 5)
+
+	assert.Equal(t, domain.ArticleImportResult{File: "hello.md", Id: 1}, res[0])
+	assert.Equal(t, domain.ArticleImportResult{File: "posts/no-front-matter.md", Id: 2}, res[1])
+	assert.ErrorIs(t, res[2].Err, ErrArticleImportInvalid)
+	assert.ErrorIs(t, res[2].Err, mdarchive.ErrFrontMatter)
+	assert.Equal(t, errors.New("db error"), res[3].Err)
+	assert.ErrorIs(t, res[4].Err, mdarchive.ErrNotMarkdown)
+
+	_, err = archiveSvc.Import(context.Background(), 123,
+		bytes.NewReader([]byte("not a zip")), 9)
+	assert.ErrorIs(t, err, ErrArticleArchiveInvalid)
+}
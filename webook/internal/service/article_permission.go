@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+)
+
+var ErrArticlePermissionDenied = errors.New("no permission on the article")
+
+// articlePermission is the only place checking who can do what on an
+// article. The author is the owner, the others get their roles by
+// accepting the invitations of the owner
+type articlePermission struct {
+	authorRepo repository.ArticleAuthorRepository
+	collabRepo repository.CollaboratorRepository
+}
+
+// check return the draft if uid has the role or a higher one on it,
+// otherwise ErrArticlePermissionDenied, including the article is not found
+func (p articlePermission) check(ctx context.Context,
+	uid, aid int64, role domain.CollaboratorRole) (domain.Article, error) {
+	arti, err := p.authorRepo.GetById(ctx, aid)
+	if errors.Is(err, repository.ErrArticleNotFound) {
+		return domain.Article{}, ErrArticlePermissionDenied
+	}
+	if err != nil {
+		return domain.Article{}, err
+	}
+	if arti.Author.Id == uid {
+		return arti, nil
+	}
+	if role == domain.CollaboratorRoleOwner {
+		return domain.Article{}, ErrArticlePermissionDenied
+	}
+
+	cur, err := p.collabRepo.GetRole(ctx, aid, uid)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	if cur < role {
+		return domain.Article{}, ErrArticlePermissionDenied
+	}
+	return arti, nil
+}
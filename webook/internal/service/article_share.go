@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+	"webook/webook/pkg/markdown"
+	"webook/webook/pkg/moderation"
+)
+
+var (
+	ErrArticleShareNotFound = errors.New("share is not found, revoked or expired")
+	ErrArticleNotShareable  = errors.New("private or unpublished article can't be shared")
+)
+
+// ArticleShareService manage the secret links of the articles,
+// only the owner can create, list and revoke them
+type ArticleShareService interface {
+	// Create a link expiring at expireAt, zero for never. The draft link
+	// shows the latest version, the others need the article published
+	Create(ctx context.Context, uid, aid int64, draft bool, expireAt time.Time) (domain.ArticleShare, error)
+	List(ctx context.Context, uid, aid int64) ([]domain.ArticleShare, error)
+	Revoke(ctx context.Context, uid, aid, id int64) error
+	// GetByToken return the public article, or the draft for the draft link.
+	// ErrArticleShareNotFound is returned if the token is invalid, revoked
+	// or expired, or the article is withdrawn. The draft is moderated as
+	// publishing it, ErrContentRejected or ErrContentUnderReview is returned
+	// if it can't be published at once
+	GetByToken(ctx context.Context, token string) (domain.Article, error)
+}
+
+type ImplArticleShareService struct {
+	repo       repository.ArticleShareRepository
+	authorRepo repository.ArticleAuthorRepository
+	readerRepo repository.ArticleReaderRepository
+	userRepo   repository.UserRepository
+	moderator  ModerationService
+	// secret signs the tokens, changing it revokes all the links
+	secret []byte
+
+	perm articlePermission
+}
+
+func NewImplArticleShareService(repo repository.ArticleShareRepository,
+	authorRepo repository.ArticleAuthorRepository,
+	readerRepo repository.ArticleReaderRepository,
+	collabRepo repository.CollaboratorRepository,
+	userRepo repository.UserRepository,
+	moderator ModerationService,
+	secret []byte) ArticleShareService {
+	return &ImplArticleShareService{
+		repo:       repo,
+		authorRepo: authorRepo,
+		readerRepo: readerRepo,
+		userRepo:   userRepo,
+		moderator:  moderator,
+		secret:     secret,
+		perm: articlePermission{
+			authorRepo: authorRepo,
+			collabRepo: collabRepo,
+		},
+	}
+}
+
+func (s *ImplArticleShareService) Create(ctx context.Context,
+	uid, aid int64, draft bool, expireAt time.Time) (domain.ArticleShare, error) {
+	arti, err := s.perm.check(ctx, uid, aid, domain.CollaboratorRoleOwner)
+	if err != nil {
+		return domain.ArticleShare{}, err
+	}
+	if draft && arti.Status == domain.ArticleStatusPrivate {
+		return domain.ArticleShare{}, ErrArticleNotShareable
+	}
+	if !draft {
+		// The author side is unpublished after editing, the public one decides
+		_, err = s.getPub(ctx, aid)
+		if errors.Is(err, ErrArticleShareNotFound) {
+			return domain.ArticleShare{}, ErrArticleNotShareable
+		}
+		if err != nil {
+			return domain.ArticleShare{}, err
+		}
+	}
+	share := domain.ArticleShare{
+		ArticleId: aid,
+		Draft:     draft,
+		ExpireAt:  expireAt,
+		Ctime:     time.Now(),
+	}
+	share.Id, err = s.repo.Create(ctx, share)
+	if err != nil {
+		return domain.ArticleShare{}, err
+	}
+	share.Token = s.token(share.Id)
+	return share, nil
+}
+
+func (s *ImplArticleShareService) List(ctx context.Context,
+	uid, aid int64) ([]domain.ArticleShare, error) {
+	_, err := s.perm.check(ctx, uid, aid, domain.CollaboratorRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.repo.List(ctx, aid)
+	if err != nil {
+		return nil, err
+	}
+	for i := range res {
+		res[i].Token = s.token(res[i].Id)
+	}
+	return res, nil
+}
+
+func (s *ImplArticleShareService) Revoke(ctx context.Context, uid, aid, id int64) error {
+	_, err := s.perm.check(ctx, uid, aid, domain.CollaboratorRoleOwner)
+	if err != nil {
+		return err
+	}
+	err = s.repo.Revoke(ctx, aid, id)
+	if errors.Is(err, repository.ErrArticleShareNotFound) {
+		return ErrArticleShareNotFound
+	}
+	return err
+}
+
+func (s *ImplArticleShareService) GetByToken(ctx context.Context,
+	token string) (domain.Article, error) {
+	id, ok := s.parseToken(token)
+	if !ok {
+		return domain.Article{}, ErrArticleShareNotFound
+	}
+	share, err := s.repo.GetById(ctx, id)
+	if errors.Is(err, repository.ErrArticleShareNotFound) {
+		return domain.Article{}, ErrArticleShareNotFound
+	}
+	if err != nil {
+		return domain.Article{}, err
+	}
+	if share.Expired(time.Now()) {
+		return domain.Article{}, ErrArticleShareNotFound
+	}
+
+	if !share.Draft {
+		return s.getPub(ctx, share.ArticleId)
+	}
+
+	// The author side is in the trash too, so the deleted ones are not found
+	arti, err := s.authorRepo.GetById(ctx, share.ArticleId)
+	if errors.Is(err, repository.ErrArticleNotFound) {
+		return domain.Article{}, ErrArticleShareNotFound
+	}
+	if err != nil {
+		return domain.Article{}, err
+	}
+	if arti.Status == domain.ArticleStatusPrivate {
+		return domain.Article{}, ErrArticleShareNotFound
+	}
+
+	// The draft is moderated and rendered like the published one
+	res := s.moderator.Check(ctx, arti.Title, arti.Content)
+	switch res.Action {
+	case moderation.ActionReject:
+		return domain.Article{}, ErrContentRejected
+	case moderation.ActionReview:
+		return domain.Article{}, ErrContentUnderReview
+	}
+	arti.Title, arti.Content = res.Texts[0], res.Texts[1]
+
+	author, err := s.userRepo.FindByID(ctx, arti.Author.Id)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	arti.Author.Name = author.NickName
+	arti.HTML = markdown.Render(arti.Content)
+	return arti, nil
+}
+
+// getPub return ErrArticleShareNotFound if the article is not published,
+// withdrawn or deleted
+func (s *ImplArticleShareService) getPub(ctx context.Context, aid int64) (domain.Article, error) {
+	arti, err := s.readerRepo.GetPubById(ctx, aid)
+	if errors.Is(err, repository.ErrArticleNotFound) {
+		return domain.Article{}, ErrArticleShareNotFound
+	}
+	if err != nil {
+		return domain.Article{}, err
+	}
+	if arti.Status != domain.ArticleStatusPublished &&
+		arti.Status != domain.ArticleStatusUnlisted {
+		return domain.Article{}, ErrArticleShareNotFound
+	}
+	return arti, nil
+}
+
+// token is the id with its signature, the id is not a secret
+// but the signature can't be guessed
+func (s *ImplArticleShareService) token(id int64) string {
+	idStr := strconv.FormatInt(id, 10)
+	return idStr + "." + base64.RawURLEncoding.EncodeToString(s.sign(idStr))
+}
+
+func (s *ImplArticleShareService) parseToken(token string) (int64, bool) {
+	idStr, sigStr, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, false
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(sigStr)
+	if err != nil || !hmac.Equal(sig, s.sign(idStr)) {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+func (s *ImplArticleShareService) sign(id string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte("article_share:" + id))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+	repomocks "webook/webook/internal/repository/mocks"
+	"webook/webook/pkg/moderation"
+)
+
+func TestImplArticleShareService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockArticleShareRepository(ctrl)
+	authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+	expireAt := time.Now().Add(time.Hour)
+	authorRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+		Return(domain.Article{Id: 1, Author: domain.Author{Id: 123},
+			Status: domain.ArticleStatusUnpublished}, nil)
+	repo.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, s domain.ArticleShare) (int64, error) {
+			assert.Equal(t, int64(1), s.ArticleId)
+			assert.Equal(t, expireAt, s.ExpireAt)
+			return 7, nil
+		})
+	authorRepo.EXPECT().GetById(gomock.Any(), int64(2)).
+		Return(domain.Article{Id: 2, Author: domain.Author{Id: 123},
+			Status: domain.ArticleStatusPrivate}, nil)
+	authorRepo.EXPECT().GetById(gomock.Any(), int64(3)).
+		Return(domain.Article{Id: 3, Author: domain.Author{Id: 234}}, nil)
+	// It's never published, only the draft link can be created
+	authorRepo.EXPECT().GetById(gomock.Any(), int64(4)).
+		Return(domain.Article{Id: 4, Author: domain.Author{Id: 123},
+			Status: domain.ArticleStatusUnpublished}, nil)
+	readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+	readerRepo.EXPECT().GetPubById(gomock.Any(), int64(4)).
+		Return(domain.Article{}, repository.ErrArticleNotFound)
+
+	svc := NewImplArticleShareService(repo, authorRepo, readerRepo, nil, nil, nil, []byte("secret"))
+	share, err := svc.Create(context.Background(), 123, 1, true, expireAt)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), share.Id)
+	assert.True(t, share.Draft)
+	id, ok := svc.(*ImplArticleShareService).parseToken(share.Token)
+	assert.True(t, ok)
+	assert.Equal(t, int64(7), id)
+
+	_, err = svc.Create(context.Background(), 123, 2, true, time.Time{})
+	assert.Equal(t, ErrArticleNotShareable, err)
+	// Only the owner shares it
+	_, err = svc.Create(context.Background(), 123, 3, true, time.Time{})
+	assert.Equal(t, ErrArticlePermissionDenied, err)
+	_, err = svc.Create(context.Background(), 123, 4, false, time.Time{})
+	assert.Equal(t, ErrArticleNotShareable, err)
+}
+
+func TestImplArticleShareService_GetByToken(t *testing.T) {
+	secret := []byte("secret")
+	token := NewImplArticleShareService(nil, nil, nil, nil, nil, nil, secret).(*ImplArticleShareService).token(7)
+	moderator := NewImplModerationService(nil, moderation.NewFilter(map[string]moderation.Category{
+		"ads":     {Action: moderation.ActionMask, Words: []string{"buy now"}},
+		"gamble":  {Action: moderation.ActionReview, Words: []string{"casino"}},
+		"illegal": {Action: moderation.ActionReject, Words: []string{"drugs"}},
+	}))
+	// draft mock the share of a draft with the content
+	draft := func(ctrl *gomock.Controller, content string) (*repomocks.MockArticleShareRepository,
+		*repomocks.MockArticleAuthorRepository) {
+		repo := repomocks.NewMockArticleShareRepository(ctrl)
+		authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+		repo.EXPECT().GetById(gomock.Any(), int64(7)).
+			Return(domain.ArticleShare{Id: 7, ArticleId: 1, Draft: true}, nil)
+		authorRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+			Return(domain.Article{Id: 1, Content: content,
+				Author: domain.Author{Id: 123},
+				Status: domain.ArticleStatusUnpublished}, nil)
+		return repo, authorRepo
+	}
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (repository.ArticleShareRepository,
+			repository.ArticleAuthorRepository, repository.ArticleReaderRepository,
+			repository.UserRepository)
+
+		token string
+
+		want    domain.Article
+		wantErr error
+	}{
+		{
+			name: "unlisted",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleShareRepository,
+				repository.ArticleAuthorRepository, repository.ArticleReaderRepository,
+				repository.UserRepository) {
+				repo := repomocks.NewMockArticleShareRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(7)).
+					Return(domain.ArticleShare{Id: 7, ArticleId: 1}, nil)
+				readerRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, HTML: "<p>pub</p>",
+						Status: domain.ArticleStatusUnlisted}, nil)
+				return repo, nil, readerRepo, nil
+			},
+			token: token,
+			want: domain.Article{Id: 1, HTML: "<p>pub</p>",
+				Status: domain.ArticleStatusUnlisted},
+		},
+		{
+			// The author side is unpublished after editing,
+			// the reader still sees the published version
+			name: "edited after publishing",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleShareRepository,
+				repository.ArticleAuthorRepository, repository.ArticleReaderRepository,
+				repository.UserRepository) {
+				repo := repomocks.NewMockArticleShareRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(7)).
+					Return(domain.ArticleShare{Id: 7, ArticleId: 1}, nil)
+				readerRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, HTML: "<p>pub</p>",
+						Status: domain.ArticleStatusPublished}, nil)
+				return repo, nil, readerRepo, nil
+			},
+			token: token,
+			want: domain.Article{Id: 1, HTML: "<p>pub</p>",
+				Status: domain.ArticleStatusPublished},
+		},
+		{
+			name: "withdrawn",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleShareRepository,
+				repository.ArticleAuthorRepository, repository.ArticleReaderRepository,
+				repository.UserRepository) {
+				repo := repomocks.NewMockArticleShareRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(7)).
+					Return(domain.ArticleShare{Id: 7, ArticleId: 1}, nil)
+				readerRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Status: domain.ArticleStatusPrivate}, nil)
+				return repo, nil, readerRepo, nil
+			},
+			token:   token,
+			wantErr: ErrArticleShareNotFound,
+		},
+		{
+			name: "not published",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleShareRepository,
+				repository.ArticleAuthorRepository, repository.ArticleReaderRepository,
+				repository.UserRepository) {
+				repo := repomocks.NewMockArticleShareRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(7)).
+					Return(domain.ArticleShare{Id: 7, ArticleId: 1}, nil)
+				readerRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(domain.Article{}, repository.ErrArticleNotFound)
+				return repo, nil, readerRepo, nil
+			},
+			token:   token,
+			wantErr: ErrArticleShareNotFound,
+		},
+		{
+			name: "draft",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleShareRepository,
+				repository.ArticleAuthorRepository, repository.ArticleReaderRepository,
+				repository.UserRepository) {
+				repo := repomocks.NewMockArticleShareRepository(ctrl)
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(7)).
+					Return(domain.ArticleShare{Id: 7, ArticleId: 1, Draft: true,
+						ExpireAt: time.Now().Add(time.Hour)}, nil)
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Content: "draft",
+						Author: domain.Author{Id: 123},
+						Status: domain.ArticleStatusUnpublished}, nil)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(123)).
+					Return(domain.User{Id: 123, NickName: "Tom"}, nil)
+				return repo, authorRepo, nil, userRepo
+			},
+			token: token,
+			want: domain.Article{Id: 1, Content: "draft", HTML: "<p>draft</p>\n",
+				Author: domain.Author{Id: 123, Name: "Tom"},
+				Status: domain.ArticleStatusUnpublished},
+		},
+		{
+			name: "draft masked",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleShareRepository,
+				repository.ArticleAuthorRepository, repository.ArticleReaderRepository,
+				repository.UserRepository) {
+				repo, authorRepo := draft(ctrl, "Please buy now")
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(123)).
+					Return(domain.User{Id: 123, NickName: "Tom"}, nil)
+				return repo, authorRepo, nil, userRepo
+			},
+			token: token,
+			want: domain.Article{Id: 1, Content: "Please *******", HTML: "<p>Please *******</p>\n",
+				Author: domain.Author{Id: 123, Name: "Tom"},
+				Status: domain.ArticleStatusUnpublished},
+		},
+		{
+			name: "draft rejected",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleShareRepository,
+				repository.ArticleAuthorRepository, repository.ArticleReaderRepository,
+				repository.UserRepository) {
+				repo, authorRepo := draft(ctrl, "drugs")
+				return repo, authorRepo, nil, nil
+			},
+			token:   token,
+			wantErr: ErrContentRejected,
+		},
+		{
+			name: "draft needs review",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleShareRepository,
+				repository.ArticleAuthorRepository, repository.ArticleReaderRepository,
+				repository.UserRepository) {
+				repo, authorRepo := draft(ctrl, "casino")
+				return repo, authorRepo, nil, nil
+			},
+			token:   token,
+			wantErr: ErrContentUnderReview,
+		},
+		{
+			name: "draft of private",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleShareRepository,
+				repository.ArticleAuthorRepository, repository.ArticleReaderRepository,
+				repository.UserRepository) {
+				repo := repomocks.NewMockArticleShareRepository(ctrl)
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(7)).
+					Return(domain.ArticleShare{Id: 7, ArticleId: 1, Draft: true}, nil)
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Status: domain.ArticleStatusPrivate}, nil)
+				return repo, authorRepo, nil, nil
+			},
+			token:   token,
+			wantErr: ErrArticleShareNotFound,
+		},
+		{
+			name: "expired",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleShareRepository,
+				repository.ArticleAuthorRepository, repository.ArticleReaderRepository,
+				repository.UserRepository) {
+				repo := repomocks.NewMockArticleShareRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(7)).
+					Return(domain.ArticleShare{Id: 7, ArticleId: 1,
+						ExpireAt: time.Now().Add(-time.Second)}, nil)
+				return repo, nil, nil, nil
+			},
+			token:   token,
+			wantErr: ErrArticleShareNotFound,
+		},
+		{
+			name: "revoked",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleShareRepository,
+				repository.ArticleAuthorRepository, repository.ArticleReaderRepository,
+				repository.UserRepository) {
+				repo := repomocks.NewMockArticleShareRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(7)).
+					Return(domain.ArticleShare{}, repository.ErrArticleShareNotFound)
+				return repo, nil, nil, nil
+			},
+			token:   token,
+			wantErr: ErrArticleShareNotFound,
+		},
+		{
+			name: "db error",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleShareRepository,
+				repository.ArticleAuthorRepository, repository.ArticleReaderRepository,
+				repository.UserRepository) {
+				repo := repomocks.NewMockArticleShareRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(7)).
+					Return(domain.ArticleShare{}, errors.New("db error"))
+				return repo, nil, nil, nil
+			},
+			token:   token,
+			wantErr: errors.New("db error"),
+		},
+		{
+			name: "forged id",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleShareRepository,
+				repository.ArticleAuthorRepository, repository.ArticleReaderRepository,
+				repository.UserRepository) {
+				return nil, nil, nil, nil
+			},
+			token:   "8" + token[1:],
+			wantErr: ErrArticleShareNotFound,
+		},
+		{
+			name: "malformed",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleShareRepository,
+				repository.ArticleAuthorRepository, repository.ArticleReaderRepository,
+				repository.UserRepository) {
+				return nil, nil, nil, nil
+			},
+			token:   "7",
+			wantErr: ErrArticleShareNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, authorRepo, readerRepo, userRepo := tc.mock(ctrl)
+			svc := NewImplArticleShareService(repo, authorRepo, readerRepo,
+				nil, userRepo, moderator, secret)
+			arti, err := svc.GetByToken(context.Background(), tc.token)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, arti)
+		})
+	}
+}
@@ -1,45 +1,637 @@
 package service
 
-//func Test_artileService_Publish(t *testing.T) {
-//	testCases := []struct {
-//		name string
-//
-//		mock func(ctrl *gomock.Controller) repository.ArticleRepository
-//
-//		arti domain.Article
-//
-//		wantId  int64
-//		wantErr error
-//	}{
-//		{
-//			name: "success",
-//			mock: func(ctrl *gomock.Controller) (
-//				repository.ArticleAuthorRepository,
-//				repository.ArticleReaderRepository) {
-//				repo := repomocks.NewMockArticleRepository(ctrl)
-//				return repo, nil
-//			},
-//			arti: domain.Article{
-//				Title:   "My title",
-//				Content: "My content",
-//				Author: domain.Author{
-//					Id:   123,
-//					Name: "",
-//				},
-//			},
-//			wantId:  123,
-//			wantErr: nil,
-//		},
-//	}
-//
-//	for _, tc := range testCases {
-//		t.Run(tc.name, func(t *testing.T) {
-//			ctrl := gomock.NewController(t)
-//			defer ctrl.Finish()
-//			svc := NewImplArticleService(tc.mock(ctrl))
-//			id, err := svc.Publish(context.Background(), tc.arti)
-//			assert.Equal(t, tc.wantErr, err)
-//			assert.Equal(t, tc.wantId, id)
-//		})
-//	}
-//}
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+	repomocks "webook/webook/internal/repository/mocks"
+	svcmocks "webook/webook/internal/service/mocks"
+	"webook/webook/pkg/logger"
+	"webook/webook/pkg/moderation"
+)
+
+func TestImplArticleService_Publish(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+			repository.ArticleReaderRepository)
+
+		arti domain.Article
+
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name: "new article",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+				repository.ArticleReaderRepository) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				authorRepo.EXPECT().Create(gomock.Any(), domain.Article{
+					Title:   "My title",
+					Content: "My content",
+					Author:  domain.Author{Id: 123},
+					Status:  domain.ArticleStatusPublished,
+				}).Return(int64(1), nil)
+				readerRepo.EXPECT().Save(gomock.Any(), domain.Article{
+					Id:      1,
+					Title:   "My title",
+					Content: "My content",
+					Author:  domain.Author{Id: 123},
+					Status:  domain.ArticleStatusPublished,
+				}).Return(nil)
+				return authorRepo, readerRepo
+			},
+			arti: domain.Article{
+				Title:   "My title",
+				Content: "My content",
+				Author:  domain.Author{Id: 123},
+			},
+			wantId: 1,
+		},
+		{
+			name: "existing article",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+				repository.ArticleReaderRepository) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				arti := domain.Article{
+					Id:      2,
+					Title:   "My title",
+					Content: "My content",
+					Author:  domain.Author{Id: 123},
+					Status:  domain.ArticleStatusPublished,
+				}
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(2)).
+					Return(domain.Article{Id: 2, Author: domain.Author{Id: 123}}, nil)
+				authorRepo.EXPECT().Update(gomock.Any(), arti).Return(nil)
+				readerRepo.EXPECT().Save(gomock.Any(), arti).Return(nil)
+				return authorRepo, readerRepo
+			},
+			arti: domain.Article{
+				Id:      2,
+				Title:   "My title",
+				Content: "My content",
+				Author:  domain.Author{Id: 123},
+			},
+			wantId: 2,
+		},
+		{
+			name: "unlisted article",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+				repository.ArticleReaderRepository) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				arti := domain.Article{
+					Title:   "My title",
+					Content: "My content",
+					Author:  domain.Author{Id: 123},
+					Status:  domain.ArticleStatusUnlisted,
+				}
+				// It's not scheduled
+				authorRepo.EXPECT().Create(gomock.Any(), arti).Return(int64(1), nil)
+				arti.Id = 1
+				readerRepo.EXPECT().Save(gomock.Any(), arti).Return(nil)
+				return authorRepo, readerRepo
+			},
+			arti: domain.Article{
+				Title:     "My title",
+				Content:   "My content",
+				Author:    domain.Author{Id: 123},
+				Status:    domain.ArticleStatusUnlisted,
+				PublishAt: time.Now().Add(time.Hour),
+			},
+			wantId: 1,
+		},
+		{
+			name: "author side failed",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+				repository.ArticleReaderRepository) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(2)).
+					Return(domain.Article{Id: 2, Author: domain.Author{Id: 123}}, nil)
+				authorRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					Return(errors.New("db error"))
+				return authorRepo, nil
+			},
+			arti: domain.Article{
+				Id:     2,
+				Author: domain.Author{Id: 123},
+			},
+			wantErr: errors.New("db error"),
+		},
+		{
+			name: "reader side failed",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+				repository.ArticleReaderRepository) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(2)).
+					Return(domain.Article{Id: 2, Author: domain.Author{Id: 123}}, nil)
+				authorRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+				readerRepo.EXPECT().Save(gomock.Any(), gomock.Any()).
+					Return(errors.New("db error"))
+				return authorRepo, readerRepo
+			},
+			arti: domain.Article{
+				Id:     2,
+				Author: domain.Author{Id: 123},
+			},
+			wantErr: errors.New("db error"),
+		},
+		{
+			name: "editor can't publish",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+				repository.ArticleReaderRepository) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(2)).
+					Return(domain.Article{Id: 2, Author: domain.Author{Id: 234}}, nil)
+				return authorRepo, nil
+			},
+			arti: domain.Article{
+				Id:     2,
+				Author: domain.Author{Id: 123},
+			},
+			wantErr: ErrArticlePermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			authorRepo, readerRepo := tc.mock(ctrl)
+			revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+			revRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+				Return(int64(1), nil).AnyTimes()
+			searchSvc := svcmocks.NewMockSearchService(ctrl)
+			searchSvc.EXPECT().IndexArticle(gomock.Any(), tc.wantId).
+				Return(nil).AnyTimes()
+			feedRepo := repomocks.NewMockFeedRepository(ctrl)
+			feedRepo.EXPECT().Invalidate(gomock.Any(), int64(123)).
+				Return(nil).AnyTimes()
+			moderator := NewImplModerationService(nil, moderation.NewFilter(nil))
+			svc := NewImplArticleService(authorRepo, readerRepo, revRepo, nil,
+				feedRepo, searchSvc, moderator, nil, logger.NewNopLogger())
+			id, err := svc.Publish(context.Background(), tc.arti)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
+
+func TestImplArticleService_PublishModerated(t *testing.T) {
+	filter := moderation.NewFilter(map[string]moderation.Category{
+		"ads":     {Action: moderation.ActionMask, Words: []string{"buy now"}},
+		"gamble":  {Action: moderation.ActionReview, Words: []string{"casino"}},
+		"illegal": {Action: moderation.ActionReject, Words: []string{"drugs"}},
+	})
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+			repository.ArticleReaderRepository, repository.ModerationRepository)
+
+		arti domain.Article
+
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name: "rejected",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+				repository.ArticleReaderRepository, repository.ModerationRepository) {
+				return nil, nil, nil
+			},
+			arti: domain.Article{
+				Title:   "Cheap DRUGS",
+				Content: "My content",
+				Author:  domain.Author{Id: 123},
+			},
+			wantErr: ErrContentRejected,
+		},
+		{
+			name: "masked",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+				repository.ArticleReaderRepository, repository.ModerationRepository) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				arti := domain.Article{
+					Title:   "My title",
+					Content: "******* at the shop",
+					Author:  domain.Author{Id: 123},
+					Status:  domain.ArticleStatusPublished,
+				}
+				authorRepo.EXPECT().Create(gomock.Any(), arti).Return(int64(1), nil)
+				arti.Id = 1
+				readerRepo.EXPECT().Save(gomock.Any(), arti).Return(nil)
+				return authorRepo, readerRepo, nil
+			},
+			arti: domain.Article{
+				Title:   "My title",
+				Content: "buy now at the shop",
+				Author:  domain.Author{Id: 123},
+			},
+			wantId: 1,
+		},
+		{
+			name: "under review",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+				repository.ArticleReaderRepository, repository.ModerationRepository) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				modRepo := repomocks.NewMockModerationRepository(ctrl)
+				draft := domain.Article{
+					Title:   "My title",
+					Content: "Go to the casino",
+					Author:  domain.Author{Id: 123},
+					Status:  domain.ArticleStatusUnpublished,
+				}
+				authorRepo.EXPECT().Create(gomock.Any(), draft).Return(int64(1), nil)
+				draft.Id = 1
+				draft.Version = 1
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(1)).Return(draft, nil)
+				modRepo.EXPECT().Create(gomock.Any(), domain.ModerationItem{
+					Biz:     "article",
+					BizId:   1,
+					Uid:     123,
+					Title:   "My title",
+					Content: "Go to the casino",
+					Version: 1,
+					Words:   []string{"casino"},
+				}).Return(int64(1), nil)
+				return authorRepo, nil, modRepo
+			},
+			arti: domain.Article{
+				Title:   "My title",
+				Content: "Go to the casino",
+				Author:  domain.Author{Id: 123},
+			},
+			wantId:  1,
+			wantErr: ErrContentUnderReview,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			authorRepo, readerRepo, modRepo := tc.mock(ctrl)
+			revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+			revRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+				Return(int64(1), nil).AnyTimes()
+			searchSvc := svcmocks.NewMockSearchService(ctrl)
+			searchSvc.EXPECT().IndexArticle(gomock.Any(), gomock.Any()).
+				Return(nil).AnyTimes()
+			feedRepo := repomocks.NewMockFeedRepository(ctrl)
+			feedRepo.EXPECT().Invalidate(gomock.Any(), int64(123)).
+				Return(nil).AnyTimes()
+			svc := NewImplArticleService(authorRepo, readerRepo, revRepo, nil,
+				feedRepo, searchSvc, NewImplModerationService(modRepo, filter),
+				nil, logger.NewNopLogger())
+			id, err := svc.Publish(context.Background(), tc.arti)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
+
+func TestImplArticleService_PublishReviewed(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+			repository.ArticleReaderRepository)
+
+		version  int64
+		unlisted bool
+
+		wantErr error
+	}{
+		{
+			name: "published",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+				repository.ArticleReaderRepository) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				arti := domain.Article{
+					Id:      1,
+					Title:   "My title",
+					Content: "Go to the casino",
+					Author:  domain.Author{Id: 123},
+					Status:  domain.ArticleStatusUnpublished,
+					Version: 2,
+				}
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(1)).Return(arti, nil)
+				arti.Status = domain.ArticleStatusPublished
+				authorRepo.EXPECT().Update(gomock.Any(), arti).Return(nil)
+				readerRepo.EXPECT().Save(gomock.Any(), arti).Return(nil)
+				return authorRepo, readerRepo
+			},
+			version: 2,
+		},
+		{
+			name: "published unlisted",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+				repository.ArticleReaderRepository) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				arti := domain.Article{
+					Id:      1,
+					Title:   "My title",
+					Author:  domain.Author{Id: 123},
+					Status:  domain.ArticleStatusUnpublished,
+					Version: 2,
+				}
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(1)).Return(arti, nil)
+				arti.Status = domain.ArticleStatusUnlisted
+				authorRepo.EXPECT().Update(gomock.Any(), arti).Return(nil)
+				readerRepo.EXPECT().Save(gomock.Any(), arti).Return(nil)
+				return authorRepo, readerRepo
+			},
+			version:  2,
+			unlisted: true,
+		},
+		{
+			name: "edited after submitting",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+				repository.ArticleReaderRepository) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Version: 3}, nil)
+				return authorRepo, nil
+			},
+			version: 2,
+			wantErr: ErrArticleVersionConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			authorRepo, readerRepo := tc.mock(ctrl)
+			revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+			revRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+				Return(int64(1), nil).AnyTimes()
+			searchSvc := svcmocks.NewMockSearchService(ctrl)
+			searchSvc.EXPECT().IndexArticle(gomock.Any(), int64(1)).
+				Return(nil).AnyTimes()
+			feedRepo := repomocks.NewMockFeedRepository(ctrl)
+			feedRepo.EXPECT().Invalidate(gomock.Any(), int64(123)).
+				Return(nil).AnyTimes()
+			moderator := NewImplModerationService(nil, moderation.NewFilter(nil))
+			svc := NewImplArticleService(authorRepo, readerRepo, revRepo, nil,
+				feedRepo, searchSvc, moderator, nil, logger.NewNopLogger())
+			err := svc.PublishReviewed(context.Background(), 1, tc.version, tc.unlisted)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestImplArticleService_RestoreRevision(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+			repository.ArticleRevisionRepository, repository.CollaboratorRepository)
+
+		uid int64
+		aid int64
+		rid int64
+
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+				repository.ArticleRevisionRepository, repository.CollaboratorRepository) {
+				repo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+				revRepo.EXPECT().GetById(gomock.Any(), int64(3)).
+					Return(domain.ArticleRevision{
+						Id:        3,
+						ArticleId: 2,
+						Title:     "old title",
+						Content:   "old content",
+						Author:    domain.Author{Id: 1},
+					}, nil)
+				repo.EXPECT().GetById(gomock.Any(), int64(2)).
+					Return(domain.Article{
+						Id:      2,
+						Title:   "new title",
+						Content: "new content",
+						Tags:    []string{"go"},
+						Author:  domain.Author{Id: 1},
+					}, nil)
+				restored := domain.Article{
+					Id:      2,
+					Title:   "old title",
+					Content: "old content",
+					Tags:    []string{"go"},
+					Author:  domain.Author{Id: 1},
+					Status:  domain.ArticleStatusUnpublished,
+				}
+				repo.EXPECT().Update(gomock.Any(), restored).Return(nil)
+				revRepo.EXPECT().Create(gomock.Any(), restored).Return(int64(4), nil)
+				return repo, revRepo, nil
+			},
+			uid:    1,
+			aid:    2,
+			rid:    3,
+			wantId: 2,
+		},
+		{
+			name: "revision of other article",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+				repository.ArticleRevisionRepository, repository.CollaboratorRepository) {
+				repo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(2)).
+					Return(domain.Article{Id: 2, Author: domain.Author{Id: 1}}, nil)
+				revRepo.EXPECT().GetById(gomock.Any(), int64(3)).
+					Return(domain.ArticleRevision{
+						Id:        3,
+						ArticleId: 5,
+						Author:    domain.Author{Id: 1},
+					}, nil)
+				return repo, revRepo, nil
+			},
+			uid:     1,
+			aid:     2,
+			rid:     3,
+			wantErr: ErrArticleRevisionNotFound,
+		},
+		{
+			name: "editor",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+				repository.ArticleRevisionRepository, repository.CollaboratorRepository) {
+				repo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+				collabRepo := repomocks.NewMockCollaboratorRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(2)).
+					Return(domain.Article{
+						Id:      2,
+						Title:   "new title",
+						Content: "new content",
+						Author:  domain.Author{Id: 9},
+					}, nil)
+				collabRepo.EXPECT().GetRole(gomock.Any(), int64(2), int64(1)).
+					Return(domain.CollaboratorRoleEditor, nil)
+				revRepo.EXPECT().GetById(gomock.Any(), int64(3)).
+					Return(domain.ArticleRevision{
+						Id:        3,
+						ArticleId: 2,
+						Title:     "old title",
+						Content:   "old content",
+						Author:    domain.Author{Id: 9},
+					}, nil)
+				// The article stays the owner's
+				restored := domain.Article{
+					Id:      2,
+					Title:   "old title",
+					Content: "old content",
+					Author:  domain.Author{Id: 9},
+					Status:  domain.ArticleStatusUnpublished,
+				}
+				repo.EXPECT().Update(gomock.Any(), restored).Return(nil)
+				revRepo.EXPECT().Create(gomock.Any(), restored).Return(int64(4), nil)
+				return repo, revRepo, collabRepo
+			},
+			uid:    1,
+			aid:    2,
+			rid:    3,
+			wantId: 2,
+		},
+		{
+			name: "viewer",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+				repository.ArticleRevisionRepository, repository.CollaboratorRepository) {
+				repo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				collabRepo := repomocks.NewMockCollaboratorRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(2)).
+					Return(domain.Article{Id: 2, Author: domain.Author{Id: 9}}, nil)
+				collabRepo.EXPECT().GetRole(gomock.Any(), int64(2), int64(1)).
+					Return(domain.CollaboratorRoleViewer, nil)
+				return repo, nil, collabRepo
+			},
+			uid:     1,
+			aid:     2,
+			rid:     3,
+			wantErr: ErrArticlePermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, revRepo, collabRepo := tc.mock(ctrl)
+			svc := NewImplArticleService(repo, nil, revRepo, collabRepo,
+				nil, nil, nil, nil, logger.NewNopLogger())
+			id, err := svc.RestoreRevision(context.Background(), tc.uid, tc.aid, tc.rid)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
+
+func TestImplArticleService_PublishDue(t *testing.T) {
+	now := time.UnixMilli(1000)
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+			repository.ArticleReaderRepository, SearchService)
+
+		wantCnt int
+		wantErr error
+	}{
+		{
+			name: "publish due articles",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+				repository.ArticleReaderRepository, SearchService) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				searchSvc := svcmocks.NewMockSearchService(ctrl)
+				artis := []domain.Article{{Id: 1}, {Id: 2}, {Id: 3}, {Id: 4}}
+				authorRepo.EXPECT().ListScheduled(gomock.Any(), now, int64(10)).
+					Return(artis, nil)
+				pub := domain.Article{Id: 1, Title: "My title",
+					Author: domain.Author{Id: 123},
+					Status: domain.ArticleStatusPublished}
+				authorRepo.EXPECT().PublishScheduled(gomock.Any(), int64(1), now).
+					Return(pub, true, nil)
+				readerRepo.EXPECT().Save(gomock.Any(), pub).Return(nil)
+				searchSvc.EXPECT().IndexArticle(gomock.Any(), int64(1)).Return(nil)
+				// published by another instance or canceled
+				authorRepo.EXPECT().PublishScheduled(gomock.Any(), int64(2), now).
+					Return(domain.Article{}, false, nil)
+				authorRepo.EXPECT().PublishScheduled(gomock.Any(), int64(3), now).
+					Return(domain.Article{}, false, errors.New("db error"))
+				authorRepo.EXPECT().PublishScheduled(gomock.Any(), int64(4), now).
+					Return(domain.Article{Id: 4}, true, nil)
+				readerRepo.EXPECT().Save(gomock.Any(), domain.Article{Id: 4}).
+					Return(errors.New("db error"))
+				return authorRepo, readerRepo, searchSvc
+			},
+			wantCnt: 1,
+		},
+		{
+			name: "list error",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
+				repository.ArticleReaderRepository, SearchService) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				authorRepo.EXPECT().ListScheduled(gomock.Any(), now, int64(10)).
+					Return(nil, errors.New("db error"))
+				return authorRepo, nil, nil
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			authorRepo, readerRepo, searchSvc := tc.mock(ctrl)
+			// Only the published one drops the feeds
+			feedRepo := repomocks.NewMockFeedRepository(ctrl)
+			feedRepo.EXPECT().Invalidate(gomock.Any(), int64(123)).
+				Return(nil).Times(tc.wantCnt)
+			svc := NewImplArticleService(authorRepo, readerRepo, nil, nil,
+				feedRepo, searchSvc, nil, nil, logger.NewNopLogger())
+			cnt, err := svc.PublishDue(context.Background(), now, 10)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCnt, cnt)
+		})
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	testCases := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "trim and dedupe",
+			tags: []string{" go ", "", "redis", "go", "  "},
+			want: []string{"go", "redis"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, normalizeTags(tc.tags))
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+	"webook/webook/pkg/logger"
+)
+
+var ErrArticleNotInTrash = repository.ErrArticleNotInTrash
+
+// ArticleTrashService keeps the deleted articles for a retention window,
+// the author can restore them before they are purged
+type ArticleTrashService interface {
+	Delete(ctx context.Context, uid, id int64) error
+	// Restore return ErrArticleNotInTrash if the article is not deleted
+	// or it's out of the retention window
+	Restore(ctx context.Context, uid, id int64) error
+	List(ctx context.Context, uid, offset, limit int64) ([]domain.Article, error)
+	// PurgeExpired remove a batch of the expired articles with their interactives,
+	// comments, revisions, uploads, chapters, collaborators and share links,
+	// return the size of the batch
+	PurgeExpired(ctx context.Context, now time.Time, limit int64) (int, error)
+}
+
+type ImplArticleTrashService struct {
+	authorRepo  repository.ArticleAuthorRepository
+	readerRepo  repository.ArticleReaderRepository
+	revRepo     repository.ArticleRevisionRepository
+	interRepo   repository.InteractiveRepository
+	commentRepo repository.CommentRepository
+	uploadRepo  repository.UploadRepository
+	seriesRepo  repository.SeriesRepository
+	collabRepo  repository.CollaboratorRepository
+	shareRepo   repository.ArticleShareRepository
+	rankRepo    repository.RankingRepository
+	feedRepo    repository.FeedRepository
+	searchSvc   SearchService
+	l           logger.Logger
+
+	retention time.Duration
+	biz       string
+}
+
+func NewImplArticleTrashService(authorRepo repository.ArticleAuthorRepository,
+	readerRepo repository.ArticleReaderRepository,
+	revRepo repository.ArticleRevisionRepository,
+	interRepo repository.InteractiveRepository,
+	commentRepo repository.CommentRepository,
+	uploadRepo repository.UploadRepository,
+	seriesRepo repository.SeriesRepository,
+	collabRepo repository.CollaboratorRepository,
+	shareRepo repository.ArticleShareRepository,
+	rankRepo repository.RankingRepository,
+	feedRepo repository.FeedRepository,
+	searchSvc SearchService,
+	retention time.Duration,
+	l logger.Logger) ArticleTrashService {
+	return &ImplArticleTrashService{
+		authorRepo:  authorRepo,
+		readerRepo:  readerRepo,
+		revRepo:     revRepo,
+		interRepo:   interRepo,
+		commentRepo: commentRepo,
+		uploadRepo:  uploadRepo,
+		seriesRepo:  seriesRepo,
+		collabRepo:  collabRepo,
+		shareRepo:   shareRepo,
+		rankRepo:    rankRepo,
+		feedRepo:    feedRepo,
+		searchSvc:   searchSvc,
+		l:           l,
+		retention:   retention,
+		biz:         "article",
+	}
+}
+
+func (s *ImplArticleTrashService) Delete(ctx context.Context,
+	uid, id int64) error {
+	now := time.Now()
+
+	// The author side checks the author
+	err := s.authorRepo.Delete(ctx, uid, id, now)
+	if err != nil {
+		return err
+	}
+	err = s.readerRepo.Delete(ctx, id, now)
+	if err != nil {
+		return err
+	}
+
+	err = s.searchSvc.DeleteArticle(ctx, id)
+	if err != nil {
+		s.l.Error("Failed to delete article from search index",
+			logger.Error(err),
+			logger.Int64("id", id))
+	}
+	err = s.rankRepo.RemoveFromTopN(ctx, id)
+	if err != nil {
+		s.l.Error("Failed to remove article from ranking",
+			logger.Error(err),
+			logger.Int64("id", id))
+	}
+	s.invalidateFeeds(ctx, uid)
+	return nil
+}
+
+func (s *ImplArticleTrashService) Restore(ctx context.Context,
+	uid, id int64) error {
+	err := s.authorRepo.Restore(ctx, uid, id, time.Now().Add(-s.retention))
+	if err != nil {
+		return err
+	}
+	err = s.readerRepo.Restore(ctx, id)
+	if err != nil {
+		return err
+	}
+	s.invalidateFeeds(ctx, uid)
+
+	// Put it back to the search index if it's still published,
+	// the ranking picks it up in the next run
+	arti, err := s.authorRepo.GetById(ctx, id)
+	if err != nil {
+		s.l.Error("Failed to get restored article",
+			logger.Error(err),
+			logger.Int64("id", id))
+		return nil
+	}
+	if arti.Status == domain.ArticleStatusPublished {
+		err = s.searchSvc.IndexArticle(ctx, id)
+		if err != nil {
+			s.l.Error("Failed to index article",
+				logger.Error(err),
+				logger.Int64("id", id))
+		}
+	}
+	return nil
+}
+
+func (s *ImplArticleTrashService) List(ctx context.Context,
+	uid, offset, limit int64) ([]domain.Article, error) {
+	return s.authorRepo.ListTrash(ctx, uid, offset, limit)
+}
+
+func (s *ImplArticleTrashService) PurgeExpired(ctx context.Context,
+	now time.Time, limit int64) (int, error) {
+	artis, err := s.authorRepo.ListExpired(ctx, now.Add(-s.retention), limit)
+	if err != nil || len(artis) == 0 {
+		return 0, err
+	}
+	ids := domain.ArticleList(artis).Ids()
+
+	// Remove the articles at last, so a failed batch is listed
+	// and purged again in the next run
+	err = s.interRepo.DeleteByBizIds(ctx, s.biz, ids)
+	if err != nil {
+		return 0, err
+	}
+	err = s.commentRepo.DeleteByArticleIds(ctx, ids)
+	if err != nil {
+		return 0, err
+	}
+	err = s.revRepo.DeleteByArticleIds(ctx, ids)
+	if err != nil {
+		return 0, err
+	}
+	err = s.uploadRepo.DeleteByArticleIds(ctx, ids)
+	if err != nil {
+		return 0, err
+	}
+	err = s.seriesRepo.DeleteArticles(ctx, ids)
+	if err != nil {
+		return 0, err
+	}
+	err = s.collabRepo.DeleteByArticleIds(ctx, ids)
+	if err != nil {
+		return 0, err
+	}
+	err = s.shareRepo.DeleteByArticleIds(ctx, ids)
+	if err != nil {
+		return 0, err
+	}
+	err = s.readerRepo.Purge(ctx, ids)
+	if err != nil {
+		return 0, err
+	}
+	err = s.authorRepo.Purge(ctx, ids)
+	if err != nil {
+		return 0, err
+	}
+	uids := make(map[int64]struct{}, len(artis))
+	for _, arti := range artis {
+		uids[arti.Author.Id] = struct{}{}
+	}
+	for uid := range uids {
+		s.invalidateFeeds(ctx, uid)
+	}
+	return len(artis), nil
+}
+
+// invalidateFeeds drop the cached feeds of the author,
+// they expire soon anyway if it fails
+func (s *ImplArticleTrashService) invalidateFeeds(ctx context.Context, uid int64) {
+	err := s.feedRepo.Invalidate(ctx, uid)
+	if err != nil {
+		s.l.Error("Failed to invalidate feeds",
+			logger.Error(err),
+			logger.Int64("uid", uid))
+	}
+}
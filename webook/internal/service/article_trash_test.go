@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"webook/webook/internal/domain"
+	repomocks "webook/webook/internal/repository/mocks"
+	svcmocks "webook/webook/internal/service/mocks"
+	"webook/webook/pkg/logger"
+)
+
+func TestImplArticleTrashService_PurgeExpired(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	retention := 24 * time.Hour
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) ArticleTrashService
+
+		wantCnt int
+		wantErr error
+	}{
+		{
+			name: "nothing expired",
+			mock: func(ctrl *gomock.Controller) ArticleTrashService {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				authorRepo.EXPECT().ListExpired(gomock.Any(), now.Add(-retention), int64(100)).
+					Return(nil, nil)
+				return NewImplArticleTrashService(authorRepo, nil, nil, nil, nil,
+					nil, nil, nil, nil, nil, nil, nil, retention, logger.NewNopLogger())
+			},
+		},
+		{
+			name: "purge a batch",
+			mock: func(ctrl *gomock.Controller) ArticleTrashService {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+				interRepo := repomocks.NewMockInteractiveRepository(ctrl)
+				commentRepo := repomocks.NewMockCommentRepository(ctrl)
+				uploadRepo := repomocks.NewMockUploadRepository(ctrl)
+				seriesRepo := repomocks.NewMockSeriesRepository(ctrl)
+				collabRepo := repomocks.NewMockCollaboratorRepository(ctrl)
+				shareRepo := repomocks.NewMockArticleShareRepository(ctrl)
+				feedRepo := repomocks.NewMockFeedRepository(ctrl)
+				ids := []int64{1, 2}
+				authorRepo.EXPECT().ListExpired(gomock.Any(), now.Add(-retention), int64(100)).
+					Return([]domain.Article{
+						{Id: 1, Author: domain.Author{Id: 123}},
+						{Id: 2, Author: domain.Author{Id: 123}},
+					}, nil)
+				gomock.InOrder(
+					interRepo.EXPECT().DeleteByBizIds(gomock.Any(), "article", ids).Return(nil),
+					commentRepo.EXPECT().DeleteByArticleIds(gomock.Any(), ids).Return(nil),
+					revRepo.EXPECT().DeleteByArticleIds(gomock.Any(), ids).Return(nil),
+					uploadRepo.EXPECT().DeleteByArticleIds(gomock.Any(), ids).Return(nil),
+					seriesRepo.EXPECT().DeleteArticles(gomock.Any(), ids).Return(nil),
+					collabRepo.EXPECT().DeleteByArticleIds(gomock.Any(), ids).Return(nil),
+					shareRepo.EXPECT().DeleteByArticleIds(gomock.Any(), ids).Return(nil),
+					readerRepo.EXPECT().Purge(gomock.Any(), ids).Return(nil),
+					authorRepo.EXPECT().Purge(gomock.Any(), ids).Return(nil),
+					// Once for each author
+					feedRepo.EXPECT().Invalidate(gomock.Any(), int64(123)).Return(nil),
+				)
+				return NewImplArticleTrashService(authorRepo, readerRepo, revRepo,
+					interRepo, commentRepo, uploadRepo, seriesRepo, collabRepo, shareRepo,
+					nil, feedRepo, nil, retention, logger.NewNopLogger())
+			},
+			wantCnt: 2,
+		},
+		{
+			name: "comments failed",
+			mock: func(ctrl *gomock.Controller) ArticleTrashService {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				interRepo := repomocks.NewMockInteractiveRepository(ctrl)
+				commentRepo := repomocks.NewMockCommentRepository(ctrl)
+				authorRepo.EXPECT().ListExpired(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]domain.Article{{Id: 1}}, nil)
+				interRepo.EXPECT().DeleteByBizIds(gomock.Any(), "article", []int64{1}).
+					Return(nil)
+				commentRepo.EXPECT().DeleteByArticleIds(gomock.Any(), []int64{1}).
+					Return(errors.New("db error"))
+				// The articles are kept for the next run
+				return NewImplArticleTrashService(authorRepo, nil, nil,
+					interRepo, commentRepo, nil, nil, nil, nil, nil, nil, nil, retention, logger.NewNopLogger())
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := tc.mock(ctrl)
+			cnt, err := svc.PurgeExpired(context.Background(), now, 100)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCnt, cnt)
+		})
+	}
+}
+
+func TestImplArticleTrashService_Restore(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) ArticleTrashService
+
+		wantErr error
+	}{
+		{
+			name: "restore published",
+			mock: func(ctrl *gomock.Controller) ArticleTrashService {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				searchSvc := svcmocks.NewMockSearchService(ctrl)
+				feedRepo := repomocks.NewMockFeedRepository(ctrl)
+				authorRepo.EXPECT().Restore(gomock.Any(), int64(123), int64(1), gomock.Any()).
+					Return(nil)
+				readerRepo.EXPECT().Restore(gomock.Any(), int64(1)).Return(nil)
+				feedRepo.EXPECT().Invalidate(gomock.Any(), int64(123)).Return(nil)
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Status: domain.ArticleStatusPublished}, nil)
+				searchSvc.EXPECT().IndexArticle(gomock.Any(), int64(1)).Return(nil)
+				return NewImplArticleTrashService(authorRepo, readerRepo, nil, nil, nil,
+					nil, nil, nil, nil, nil, feedRepo, searchSvc, time.Hour, logger.NewNopLogger())
+			},
+		},
+		{
+			name: "restore draft",
+			mock: func(ctrl *gomock.Controller) ArticleTrashService {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				feedRepo := repomocks.NewMockFeedRepository(ctrl)
+				authorRepo.EXPECT().Restore(gomock.Any(), int64(123), int64(1), gomock.Any()).
+					Return(nil)
+				readerRepo.EXPECT().Restore(gomock.Any(), int64(1)).Return(nil)
+				feedRepo.EXPECT().Invalidate(gomock.Any(), int64(123)).Return(nil)
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Status: domain.ArticleStatusUnpublished}, nil)
+				return NewImplArticleTrashService(authorRepo, readerRepo, nil, nil, nil,
+					nil, nil, nil, nil, nil, feedRepo, nil, time.Hour, logger.NewNopLogger())
+			},
+		},
+		{
+			name: "not in trash",
+			mock: func(ctrl *gomock.Controller) ArticleTrashService {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				authorRepo.EXPECT().Restore(gomock.Any(), int64(123), int64(1), gomock.Any()).
+					Return(ErrArticleNotInTrash)
+				return NewImplArticleTrashService(authorRepo, nil, nil, nil, nil,
+					nil, nil, nil, nil, nil, nil, nil, time.Hour, logger.NewNopLogger())
+			},
+			wantErr: ErrArticleNotInTrash,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := tc.mock(ctrl)
+			err := svc.Restore(context.Background(), 123, 1)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+)
+
+var (
+	ErrCollaboratorInvalidRole = errors.New("invalid collaborator role")
+	ErrInvitationNotFound      = errors.New("invitation not found")
+)
+
+// CollaboratorService manage who can work on an article with the owner
+type CollaboratorService interface {
+	// Invite invite the user as a viewer or an editor, only the owner
+	// can invite. Inviting a collaborator again changes the role
+	Invite(ctx context.Context, uid, aid, invitee int64, role domain.CollaboratorRole) error
+	// Accept accept the invitation to the article for uid
+	Accept(ctx context.Context, uid, aid int64) error
+	// Remove remove the collaborator, the owner removes anyone,
+	// the others can only leave
+	Remove(ctx context.Context, uid, aid, collaborator int64) error
+	// List the collaborators can see each other
+	List(ctx context.Context, uid, aid int64) ([]domain.Collaborator, error)
+}
+
+type ImplCollaboratorService struct {
+	repo repository.CollaboratorRepository
+	perm articlePermission
+}
+
+func NewImplCollaboratorService(repo repository.CollaboratorRepository,
+	authorRepo repository.ArticleAuthorRepository) CollaboratorService {
+	return &ImplCollaboratorService{
+		repo: repo,
+		perm: articlePermission{
+			authorRepo: authorRepo,
+			collabRepo: repo,
+		},
+	}
+}
+
+func (s *ImplCollaboratorService) Invite(ctx context.Context,
+	uid, aid, invitee int64, role domain.CollaboratorRole) error {
+	// The owner is the author, it can't be given away
+	if role != domain.CollaboratorRoleViewer && role != domain.CollaboratorRoleEditor {
+		return ErrCollaboratorInvalidRole
+	}
+	_, err := s.perm.check(ctx, uid, aid, domain.CollaboratorRoleOwner)
+	if err != nil {
+		return err
+	}
+	if invitee == uid {
+		return ErrCollaboratorInvalidRole
+	}
+	return s.repo.Invite(ctx, domain.Collaborator{
+		ArticleId: aid,
+		Uid:       invitee,
+		Role:      role,
+	})
+}
+
+func (s *ImplCollaboratorService) Accept(ctx context.Context, uid, aid int64) error {
+	err := s.repo.Accept(ctx, aid, uid)
+	if errors.Is(err, repository.ErrCollaboratorNotFound) {
+		return ErrInvitationNotFound
+	}
+	return err
+}
+
+func (s *ImplCollaboratorService) Remove(ctx context.Context,
+	uid, aid, collaborator int64) error {
+	if uid != collaborator {
+		_, err := s.perm.check(ctx, uid, aid, domain.CollaboratorRoleOwner)
+		if err != nil {
+			return err
+		}
+	}
+	return s.repo.Remove(ctx, aid, collaborator)
+}
+
+func (s *ImplCollaboratorService) List(ctx context.Context,
+	uid, aid int64) ([]domain.Collaborator, error) {
+	_, err := s.perm.check(ctx, uid, aid, domain.CollaboratorRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx, aid)
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+	repomocks "webook/webook/internal/repository/mocks"
+	"webook/webook/pkg/logger"
+)
+
+func TestImplCollaboratorService_Invite(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (repository.CollaboratorRepository,
+			repository.ArticleAuthorRepository)
+
+		uid     int64
+		invitee int64
+		role    domain.CollaboratorRole
+
+		wantErr error
+	}{
+		{
+			name: "owner invites an editor",
+			mock: func(ctrl *gomock.Controller) (repository.CollaboratorRepository,
+				repository.ArticleAuthorRepository) {
+				repo := repomocks.NewMockCollaboratorRepository(ctrl)
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 123}}, nil)
+				repo.EXPECT().Invite(gomock.Any(), domain.Collaborator{
+					ArticleId: 1,
+					Uid:       234,
+					Role:      domain.CollaboratorRoleEditor,
+				}).Return(nil)
+				return repo, authorRepo
+			},
+			uid:     123,
+			invitee: 234,
+			role:    domain.CollaboratorRoleEditor,
+		},
+		{
+			name: "editor can't invite",
+			mock: func(ctrl *gomock.Controller) (repository.CollaboratorRepository,
+				repository.ArticleAuthorRepository) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 123}}, nil)
+				return nil, authorRepo
+			},
+			uid:     234,
+			invitee: 345,
+			role:    domain.CollaboratorRoleViewer,
+			wantErr: ErrArticlePermissionDenied,
+		},
+		{
+			name: "article not found",
+			mock: func(ctrl *gomock.Controller) (repository.CollaboratorRepository,
+				repository.ArticleAuthorRepository) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{}, repository.ErrArticleNotFound)
+				return nil, authorRepo
+			},
+			uid:     123,
+			invitee: 234,
+			role:    domain.CollaboratorRoleViewer,
+			wantErr: ErrArticlePermissionDenied,
+		},
+		{
+			name: "owner role",
+			mock: func(ctrl *gomock.Controller) (repository.CollaboratorRepository,
+				repository.ArticleAuthorRepository) {
+				return nil, nil
+			},
+			uid:     123,
+			invitee: 234,
+			role:    domain.CollaboratorRoleOwner,
+			wantErr: ErrCollaboratorInvalidRole,
+		},
+		{
+			name: "invite self",
+			mock: func(ctrl *gomock.Controller) (repository.CollaboratorRepository,
+				repository.ArticleAuthorRepository) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 123}}, nil)
+				return nil, authorRepo
+			},
+			uid:     123,
+			invitee: 123,
+			role:    domain.CollaboratorRoleEditor,
+			wantErr: ErrCollaboratorInvalidRole,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, authorRepo := tc.mock(ctrl)
+			svc := NewImplCollaboratorService(repo, authorRepo)
+			err := svc.Invite(context.Background(), tc.uid, 1, tc.invitee, tc.role)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestImplArticleService_SaveByCollaborator(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		role domain.CollaboratorRole
+
+		wantErr error
+	}{
+		{
+			name: "editor",
+			role: domain.CollaboratorRoleEditor,
+		},
+		{
+			name:    "viewer",
+			role:    domain.CollaboratorRoleViewer,
+			wantErr: ErrArticlePermissionDenied,
+		},
+		{
+			name:    "invitation not accepted",
+			role:    domain.CollaboratorRoleUnknown,
+			wantErr: ErrArticlePermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+			revRepo := repomocks.NewMockArticleRevisionRepository(ctrl)
+			collabRepo := repomocks.NewMockCollaboratorRepository(ctrl)
+			authorRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+				Return(domain.Article{Id: 1, Author: domain.Author{Id: 123}}, nil)
+			collabRepo.EXPECT().GetRole(gomock.Any(), int64(1), int64(234)).
+				Return(tc.role, nil)
+			if tc.wantErr == nil {
+				// Saved as the owner's draft
+				draft := domain.Article{
+					Id:      1,
+					Title:   "My title",
+					Content: "My content",
+					Author:  domain.Author{Id: 123},
+					Status:  domain.ArticleStatusUnpublished,
+				}
+				authorRepo.EXPECT().Update(gomock.Any(), draft).Return(nil)
+				revRepo.EXPECT().Create(gomock.Any(), draft).Return(int64(1), nil)
+			}
+			svc := NewImplArticleService(authorRepo, nil, revRepo, collabRepo,
+				nil, nil, nil, nil, logger.NewNopLogger())
+			_, err := svc.Save(context.Background(), domain.Article{
+				Id:      1,
+				Title:   "My title",
+				Content: "My content",
+				Author:  domain.Author{Id: 234},
+			})
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
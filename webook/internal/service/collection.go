@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+)
+
+// collectionScanBatch is the number of the items read at once, a page
+// may take more batches since the hidden articles are skipped
+const collectionScanBatch = 100
+
+var (
+	ErrCollectionNotFound     = errors.New("collection is not found or not visible")
+	ErrCollectionItemNotFound = errors.New("item is not in the collection")
+)
+
+// CollectionService manage the folders of the collected articles.
+// The others only see the public collections of a user
+type CollectionService interface {
+	Create(ctx context.Context, c domain.Collection) (int64, error)
+	// Update change the name and the privacy, c.Uid is the owner
+	Update(ctx context.Context, c domain.Collection) error
+	// Delete remove the collection with the articles in it
+	Delete(ctx context.Context, uid, id int64) error
+	// List the collections of owner seen by viewer
+	List(ctx context.Context, viewer, owner int64) ([]domain.Collection, error)
+	// ListArticles list the articles in the collection of owner, the latest
+	// collected first. It returns ErrCollectionNotFound if it's not visible
+	// to viewer, the default one is only visible to the owner
+	ListArticles(ctx context.Context, viewer, owner, cid, offset, limit int64) ([]domain.Article, error)
+	// MoveArticle move the collected article to another collection of the user
+	MoveArticle(ctx context.Context, uid, aid, from, to int64) error
+}
+
+type ImplCollectionService struct {
+	repo       repository.CollectionRepository
+	readerRepo repository.ArticleReaderRepository
+	biz        string
+}
+
+func NewImplCollectionService(repo repository.CollectionRepository,
+	readerRepo repository.ArticleReaderRepository) CollectionService {
+	return &ImplCollectionService{
+		repo:       repo,
+		readerRepo: readerRepo,
+		biz:        "article",
+	}
+}
+
+func (s *ImplCollectionService) Create(ctx context.Context, c domain.Collection) (int64, error) {
+	return s.repo.Create(ctx, c)
+}
+
+func (s *ImplCollectionService) Update(ctx context.Context, c domain.Collection) error {
+	err := s.repo.Update(ctx, c)
+	if errors.Is(err, repository.ErrCollectionNotFound) {
+		return ErrCollectionNotFound
+	}
+	return err
+}
+
+func (s *ImplCollectionService) Delete(ctx context.Context, uid, id int64) error {
+	err := s.repo.Delete(ctx, uid, id)
+	if errors.Is(err, repository.ErrCollectionNotFound) {
+		return ErrCollectionNotFound
+	}
+	return err
+}
+
+func (s *ImplCollectionService) List(ctx context.Context,
+	viewer, owner int64) ([]domain.Collection, error) {
+	return s.repo.List(ctx, owner, viewer != owner)
+}
+
+func (s *ImplCollectionService) ListArticles(ctx context.Context,
+	viewer, owner, cid, offset, limit int64) ([]domain.Article, error) {
+	if cid > 0 {
+		c, err := s.repo.GetById(ctx, cid)
+		if errors.Is(err, repository.ErrCollectionNotFound) {
+			return nil, ErrCollectionNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		if c.Uid != owner || (!c.Public && viewer != owner) {
+			return nil, ErrCollectionNotFound
+		}
+	} else if viewer != owner {
+		return nil, ErrCollectionNotFound
+	}
+
+	// The unlisted ones are collected from the share links,
+	// they are not shown to the others
+	statuses := []domain.ArticleStatus{domain.ArticleStatusPublished}
+	if viewer == owner {
+		statuses = append(statuses, domain.ArticleStatusUnlisted)
+	}
+
+	// The articles deleted or withdrawn after collecting are not counted
+	// by offset either, so the items are read from the start
+	var skipped int64
+	res := make([]domain.Article, 0, limit)
+	for start := int64(0); int64(len(res)) < limit; start += collectionScanBatch {
+		items, err := s.repo.ListItems(ctx, owner, cid, s.biz, start, collectionScanBatch)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]int64, 0, len(items))
+		for _, item := range items {
+			ids = append(ids, item.BizId)
+		}
+		artis, err := s.readerRepo.ListPubByIds(ctx, ids, statuses)
+		if err != nil {
+			return nil, err
+		}
+		visible := make(map[int64]domain.Article, len(artis))
+		for _, arti := range artis {
+			visible[arti.Id] = arti
+		}
+		for _, item := range items {
+			arti, ok := visible[item.BizId]
+			if !ok {
+				continue
+			}
+			if skipped < offset {
+				skipped++
+				continue
+			}
+			res = append(res, arti)
+			if int64(len(res)) == limit {
+				break
+			}
+		}
+		if len(items) < collectionScanBatch {
+			break
+		}
+	}
+	return res, nil
+}
+
+func (s *ImplCollectionService) MoveArticle(ctx context.Context,
+	uid, aid, from, to int64) error {
+	if from == to {
+		return nil
+	}
+	err := checkCollectionOwner(ctx, s.repo, uid, to)
+	if err != nil {
+		return err
+	}
+	err = s.repo.MoveItem(ctx, uid, s.biz, aid, from, to)
+	if errors.Is(err, repository.ErrCollectionNotFound) {
+		return ErrCollectionItemNotFound
+	}
+	return err
+}
+
+// checkCollectionOwner return ErrCollectionNotFound if cid is not
+// a collection of uid, 0 is the default one of everyone
+func checkCollectionOwner(ctx context.Context,
+	repo repository.CollectionRepository, uid, cid int64) error {
+	if cid == 0 {
+		return nil
+	}
+	c, err := repo.GetById(ctx, cid)
+	if errors.Is(err, repository.ErrCollectionNotFound) {
+		return ErrCollectionNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if c.Uid != uid {
+		return ErrCollectionNotFound
+	}
+	return nil
+}
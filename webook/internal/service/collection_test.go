@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+	repomocks "webook/webook/internal/repository/mocks"
+)
+
+func TestImplCollectionService_ListArticles(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (repository.CollectionRepository,
+			repository.ArticleReaderRepository)
+
+		viewer int64
+		cid    int64
+		offset int64
+		// limit is 10 by default
+		limit int64
+
+		wantIds []int64
+		wantErr error
+	}{
+		{
+			name: "owner",
+			mock: func(ctrl *gomock.Controller) (repository.CollectionRepository,
+				repository.ArticleReaderRepository) {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Collection{Id: 1, Uid: 123}, nil)
+				repo.EXPECT().ListItems(gomock.Any(), int64(123), int64(1), "article",
+					int64(0), int64(collectionScanBatch)).
+					Return([]domain.CollectionItem{{BizId: 11}, {BizId: 12}, {BizId: 13}, {BizId: 14}}, nil)
+				// 13 is withdrawn and 14 is deleted
+				readerRepo.EXPECT().ListPubByIds(gomock.Any(), []int64{11, 12, 13, 14},
+					[]domain.ArticleStatus{domain.ArticleStatusPublished, domain.ArticleStatusUnlisted}).
+					Return([]domain.Article{{Id: 12}, {Id: 11}}, nil)
+				return repo, readerRepo
+			},
+			viewer:  123,
+			cid:     1,
+			wantIds: []int64{11, 12},
+		},
+		{
+			name: "public to others",
+			mock: func(ctrl *gomock.Controller) (repository.CollectionRepository,
+				repository.ArticleReaderRepository) {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Collection{Id: 1, Uid: 123, Public: true}, nil)
+				repo.EXPECT().ListItems(gomock.Any(), int64(123), int64(1), "article",
+					int64(0), int64(collectionScanBatch)).
+					Return([]domain.CollectionItem{{BizId: 11}, {BizId: 12}}, nil)
+				readerRepo.EXPECT().ListPubByIds(gomock.Any(), []int64{11, 12},
+					[]domain.ArticleStatus{domain.ArticleStatusPublished}).
+					Return([]domain.Article{{Id: 11}}, nil)
+				return repo, readerRepo
+			},
+			viewer:  234,
+			cid:     1,
+			wantIds: []int64{11},
+		},
+		{
+			name: "hidden ones not counted by offset",
+			mock: func(ctrl *gomock.Controller) (repository.CollectionRepository,
+				repository.ArticleReaderRepository) {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				repo.EXPECT().ListItems(gomock.Any(), int64(123), int64(0), "article",
+					int64(0), int64(collectionScanBatch)).
+					Return([]domain.CollectionItem{{BizId: 11}, {BizId: 12}, {BizId: 13}}, nil)
+				readerRepo.EXPECT().ListPubByIds(gomock.Any(), []int64{11, 12, 13}, gomock.Any()).
+					Return([]domain.Article{{Id: 11}, {Id: 13}}, nil)
+				return repo, readerRepo
+			},
+			viewer:  123,
+			offset:  1,
+			limit:   1,
+			wantIds: []int64{13},
+		},
+		{
+			name: "page across batches",
+			mock: func(ctrl *gomock.Controller) (repository.CollectionRepository,
+				repository.ArticleReaderRepository) {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				items := make([]domain.CollectionItem, collectionScanBatch)
+				for i := range items {
+					items[i].BizId = int64(i + 1)
+				}
+				// Only the first one of the batch is visible
+				repo.EXPECT().ListItems(gomock.Any(), int64(123), int64(0), "article",
+					int64(0), int64(collectionScanBatch)).Return(items, nil)
+				readerRepo.EXPECT().ListPubByIds(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]domain.Article{{Id: 1}}, nil)
+				repo.EXPECT().ListItems(gomock.Any(), int64(123), int64(0), "article",
+					int64(collectionScanBatch), int64(collectionScanBatch)).
+					Return([]domain.CollectionItem{{BizId: 201}}, nil)
+				readerRepo.EXPECT().ListPubByIds(gomock.Any(), []int64{201}, gomock.Any()).
+					Return([]domain.Article{{Id: 201}}, nil)
+				return repo, readerRepo
+			},
+			viewer:  123,
+			limit:   2,
+			wantIds: []int64{1, 201},
+		},
+		{
+			name: "private to others",
+			mock: func(ctrl *gomock.Controller) (repository.CollectionRepository,
+				repository.ArticleReaderRepository) {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Collection{Id: 1, Uid: 123}, nil)
+				return repo, nil
+			},
+			viewer:  234,
+			cid:     1,
+			wantErr: ErrCollectionNotFound,
+		},
+		{
+			name: "not the owner's",
+			mock: func(ctrl *gomock.Controller) (repository.CollectionRepository,
+				repository.ArticleReaderRepository) {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Collection{Id: 1, Uid: 234, Public: true}, nil)
+				return repo, nil
+			},
+			viewer:  123,
+			cid:     1,
+			wantErr: ErrCollectionNotFound,
+		},
+		{
+			name: "default to others",
+			mock: func(ctrl *gomock.Controller) (repository.CollectionRepository,
+				repository.ArticleReaderRepository) {
+				return nil, nil
+			},
+			viewer:  234,
+			wantErr: ErrCollectionNotFound,
+		},
+		{
+			name: "db error",
+			mock: func(ctrl *gomock.Controller) (repository.CollectionRepository,
+				repository.ArticleReaderRepository) {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				repo.EXPECT().ListItems(gomock.Any(), int64(123), int64(0), "article",
+					int64(0), int64(collectionScanBatch)).
+					Return(nil, errors.New("db error"))
+				return repo, nil
+			},
+			viewer:  123,
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, readerRepo := tc.mock(ctrl)
+			svc := NewImplCollectionService(repo, readerRepo)
+			limit := tc.limit
+			if limit == 0 {
+				limit = 10
+			}
+			artis, err := svc.ListArticles(context.Background(), tc.viewer, 123, tc.cid, tc.offset, limit)
+			assert.Equal(t, tc.wantErr, err)
+			if err == nil {
+				assert.Equal(t, tc.wantIds, domain.ArticleList(artis).Ids())
+			}
+		})
+	}
+}
+
+func TestImplCollectionService_MoveArticle(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) repository.CollectionRepository
+
+		to int64
+
+		wantErr error
+	}{
+		{
+			name: "moved",
+			mock: func(ctrl *gomock.Controller) repository.CollectionRepository {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(2)).
+					Return(domain.Collection{Id: 2, Uid: 123}, nil)
+				repo.EXPECT().MoveItem(gomock.Any(), int64(123), "article",
+					int64(11), int64(1), int64(2)).Return(nil)
+				return repo
+			},
+			to: 2,
+		},
+		{
+			name: "to the default",
+			mock: func(ctrl *gomock.Controller) repository.CollectionRepository {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				repo.EXPECT().MoveItem(gomock.Any(), int64(123), "article",
+					int64(11), int64(1), int64(0)).Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "to another user's",
+			mock: func(ctrl *gomock.Controller) repository.CollectionRepository {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(2)).
+					Return(domain.Collection{Id: 2, Uid: 234}, nil)
+				return repo
+			},
+			to:      2,
+			wantErr: ErrCollectionNotFound,
+		},
+		{
+			name: "not in from",
+			mock: func(ctrl *gomock.Controller) repository.CollectionRepository {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(2)).
+					Return(domain.Collection{Id: 2, Uid: 123}, nil)
+				repo.EXPECT().MoveItem(gomock.Any(), int64(123), "article",
+					int64(11), int64(1), int64(2)).Return(repository.ErrCollectionNotFound)
+				return repo
+			},
+			to:      2,
+			wantErr: ErrCollectionItemNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewImplCollectionService(tc.mock(ctrl), nil)
+			err := svc.MoveArticle(context.Background(), 123, 11, 1, tc.to)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -4,27 +4,64 @@ import (
 	"context"
 	"webook/webook/internal/domain"
 	"webook/webook/internal/repository"
+	"webook/webook/pkg/moderation"
 )
 
 type CommentService interface {
+	// Create moderate the content and create the comment, it returns
+	// ErrContentRejected or ErrContentUnderReview if it's not created
 	Create(ctx context.Context, comment domain.Comment) (int64, error)
+	// CreateReviewed create the comment approved by the manual review
+	CreateReviewed(ctx context.Context, comment domain.Comment) (int64, error)
 	GetByArticleId(ctx context.Context, articleId int64, offset int64, limit int64) ([]domain.Comment, error)
+	GetByArticleIdAfter(ctx context.Context, articleId int64, cursor domain.Cursor, limit int64) ([]domain.Comment, error)
 	DeleteById(ctx context.Context, id int64, userId int64) error
 }
 
 type CommentServiceImpl struct {
-	repo     repository.CommentRepository
-	userRepo repository.UserRepository
+	repo      repository.CommentRepository
+	userRepo  repository.UserRepository
+	moderator ModerationService
 }
 
-func NewCommentServiceImpl(repo repository.CommentRepository, userRepo repository.UserRepository) CommentService {
+func NewCommentServiceImpl(repo repository.CommentRepository, userRepo repository.UserRepository,
+	moderator ModerationService) CommentService {
 	return &CommentServiceImpl{
-		repo:     repo,
-		userRepo: userRepo,
+		repo:      repo,
+		userRepo:  userRepo,
+		moderator: moderator,
 	}
 }
 
 func (s *CommentServiceImpl) Create(ctx context.Context, comment domain.Comment) (int64, error) {
+	res := s.moderator.Check(ctx, comment.Content)
+	switch res.Action {
+	case moderation.ActionReject:
+		return 0, ErrContentRejected
+	case moderation.ActionReview:
+		err := s.moderator.Submit(ctx, domain.ModerationItem{
+			Biz:     "comment",
+			BizId:   comment.ArticleId,
+			Uid:     comment.User.Id,
+			Content: comment.Content,
+			Words:   res.Words,
+		})
+		if err != nil {
+			return 0, err
+		}
+		return 0, ErrContentUnderReview
+	}
+	comment.Content = res.Texts[0]
+	return s.create(ctx, comment)
+}
+
+func (s *CommentServiceImpl) CreateReviewed(ctx context.Context, comment domain.Comment) (int64, error) {
+	// The words may be changed since submitting
+	comment.Content = s.moderator.Check(ctx, comment.Content).Texts[0]
+	return s.create(ctx, comment)
+}
+
+func (s *CommentServiceImpl) create(ctx context.Context, comment domain.Comment) (int64, error) {
 	user, err := s.userRepo.FindByID(ctx, comment.User.Id)
 	if err != nil {
 		return 0, err
@@ -37,6 +74,10 @@ func (s *CommentServiceImpl) GetByArticleId(ctx context.Context, articleId int64
 	return s.repo.GetByArticleId(ctx, articleId, offset, limit)
 }
 
+func (s *CommentServiceImpl) GetByArticleIdAfter(ctx context.Context, articleId int64, cursor domain.Cursor, limit int64) ([]domain.Comment, error) {
+	return s.repo.GetByArticleIdAfter(ctx, articleId, cursor, limit)
+}
+
 func (s *CommentServiceImpl) DeleteById(ctx context.Context, id int64, userId int64) error {
 	return s.repo.DeleteById(ctx, id, userId)
 }
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+	"webook/webook/pkg/feed"
+	"webook/webook/pkg/logger"
+)
+
+var ErrFeedAuthorNotFound = errors.New("author of the feed not found")
+
+// FeedService build the RSS and Atom feeds of the latest published articles
+type FeedService interface {
+	// Feed return the feed of the author, uid 0 means the whole site.
+	// The feeds are cached for a while and dropped on publishing and withdrawing
+	Feed(ctx context.Context, format domain.FeedFormat, uid int64) (domain.Feed, error)
+}
+
+type ImplFeedService struct {
+	svc      ArticleService
+	repo     repository.FeedRepository
+	userRepo repository.UserRepository
+	l        logger.Logger
+
+	// title and link are the site's, the links of the
+	// feeds and the articles are under link
+	title string
+	link  string
+	size  int64
+}
+
+func NewImplFeedService(svc ArticleService,
+	repo repository.FeedRepository,
+	userRepo repository.UserRepository,
+	title string, link string, size int64,
+	l logger.Logger) FeedService {
+	return &ImplFeedService{
+		svc:      svc,
+		repo:     repo,
+		userRepo: userRepo,
+		l:        l,
+		title:    title,
+		link:     link,
+		size:     size,
+	}
+}
+
+func (s *ImplFeedService) Feed(ctx context.Context,
+	format domain.FeedFormat, uid int64) (domain.Feed, error) {
+	res, err := s.repo.Get(ctx, format, uid)
+	if err == nil {
+		return res, nil
+	}
+
+	res, err = s.build(ctx, format, uid)
+	if err != nil {
+		return domain.Feed{}, err
+	}
+	err = s.repo.Set(ctx, uid, res)
+	if err != nil {
+		s.l.Error("Failed to cache feed",
+			logger.Error(err),
+			logger.String("format", string(format)),
+			logger.Int64("uid", uid))
+	}
+	return res, nil
+}
+
+func (s *ImplFeedService) build(ctx context.Context,
+	format domain.FeedFormat, uid int64) (domain.Feed, error) {
+	f := feed.Feed{
+		Title:       s.title,
+		Link:        s.link,
+		Description: "The latest articles on " + s.title,
+		Self:        fmt.Sprintf("%s/feed/%s.xml", s.link, format),
+	}
+	var (
+		artis []domain.Article
+		err   error
+	)
+	if uid == 0 {
+		artis, err = s.svc.ListPub(ctx, time.Now(), 0, s.size)
+	} else {
+		var author domain.User
+		author, err = s.userRepo.FindByID(ctx, uid)
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return domain.Feed{}, ErrFeedAuthorNotFound
+		}
+		if err != nil {
+			return domain.Feed{}, err
+		}
+		f.Title = author.NickName + " - " + s.title
+		f.Description = fmt.Sprintf("The latest articles of %s on %s", author.NickName, s.title)
+		f.Self = fmt.Sprintf("%s/feed/author/%d/%s.xml", s.link, uid, format)
+		artis, err = s.svc.ListPubByAuthor(ctx, uid, time.Now(), 0, s.size)
+	}
+	if err != nil {
+		return domain.Feed{}, err
+	}
+
+	res := domain.Feed{
+		Format: format,
+		Utime:  time.UnixMilli(0),
+	}
+	for _, arti := range artis {
+		link := fmt.Sprintf("%s/articles/%d", s.link, arti.Id)
+		f.Items = append(f.Items, feed.Item{
+			Id:        link,
+			Title:     arti.Title,
+			Link:      link,
+			Author:    arti.Author.Name,
+			Summary:   arti.Abstract(),
+			Published: arti.Ctime,
+			Updated:   arti.Utime,
+		})
+		if arti.Utime.After(res.Utime) {
+			// HTTP dates are in seconds
+			res.Utime = arti.Utime.Truncate(time.Second)
+		}
+	}
+	f.Updated = res.Utime
+
+	switch format {
+	case domain.FeedFormatRSS:
+		res.Content, err = feed.RSS(f)
+	case domain.FeedFormatAtom:
+		res.Content, err = feed.Atom(f)
+	default:
+		return domain.Feed{}, fmt.Errorf("unknown feed format %q", format)
+	}
+	if err != nil {
+		return domain.Feed{}, err
+	}
+	sum := sha256.Sum256(res.Content)
+	res.ETag = fmt.Sprintf(`"%x"`, sum[:16])
+	return res, nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+	repomocks "webook/webook/internal/repository/mocks"
+	svcmocks "webook/webook/internal/service/mocks"
+	"webook/webook/pkg/logger"
+)
+
+func TestImplFeedService_Feed(t *testing.T) {
+	utime := time.UnixMilli(1700000000123)
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (ArticleService,
+			repository.FeedRepository, repository.UserRepository)
+
+		format domain.FeedFormat
+		uid    int64
+
+		wantUtime   time.Time
+		wantContent []string
+		wantErr     error
+	}{
+		{
+			name: "cached",
+			mock: func(ctrl *gomock.Controller) (ArticleService,
+				repository.FeedRepository, repository.UserRepository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				repo.EXPECT().Get(gomock.Any(), domain.FeedFormatRSS, int64(0)).
+					Return(domain.Feed{
+						Format:  domain.FeedFormatRSS,
+						Content: []byte("<rss></rss>"),
+						Utime:   utime,
+					}, nil)
+				return nil, repo, nil
+			},
+			format:      domain.FeedFormatRSS,
+			wantUtime:   utime,
+			wantContent: []string{"<rss></rss>"},
+		},
+		{
+			name: "site feed",
+			mock: func(ctrl *gomock.Controller) (ArticleService,
+				repository.FeedRepository, repository.UserRepository) {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				repo.EXPECT().Get(gomock.Any(), domain.FeedFormatRSS, int64(0)).
+					Return(domain.Feed{}, errors.New("cache miss"))
+				svc.EXPECT().ListPub(gomock.Any(), gomock.Any(), int64(0), int64(20)).
+					Return([]domain.Article{
+						{Id: 2, Title: "Second", Content: "world",
+							Author: domain.Author{Id: 123, Name: "Tom"},
+							Ctime:  utime, Utime: utime},
+						{Id: 1, Title: "First", Content: "hello",
+							Author: domain.Author{Id: 234, Name: "Jerry"},
+							Ctime:  time.UnixMilli(1000), Utime: time.UnixMilli(1000)},
+					}, nil)
+				repo.EXPECT().Set(gomock.Any(), int64(0), gomock.Any()).Return(nil)
+				return svc, repo, nil
+			},
+			format:    domain.FeedFormatRSS,
+			wantUtime: utime.Truncate(time.Second),
+			wantContent: []string{
+				"<link>https://webook.example.com/articles/2</link>",
+				"<dc:creator>Jerry</dc:creator>",
+				"<description>hello</description>",
+				`<atom:link href="https://webook.example.com/feed/rss.xml" rel="self"`,
+			},
+		},
+		{
+			name: "author feed",
+			mock: func(ctrl *gomock.Controller) (ArticleService,
+				repository.FeedRepository, repository.UserRepository) {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().Get(gomock.Any(), domain.FeedFormatAtom, int64(123)).
+					Return(domain.Feed{}, errors.New("cache miss"))
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(123)).
+					Return(domain.User{Id: 123, NickName: "Tom"}, nil)
+				svc.EXPECT().ListPubByAuthor(gomock.Any(), int64(123), gomock.Any(),
+					int64(0), int64(20)).Return(nil, nil)
+				// Cache failure doesn't fail the request
+				repo.EXPECT().Set(gomock.Any(), int64(123), gomock.Any()).
+					Return(errors.New("redis error"))
+				return svc, repo, userRepo
+			},
+			format:    domain.FeedFormatAtom,
+			uid:       123,
+			wantUtime: time.UnixMilli(0),
+			wantContent: []string{
+				"<title>Tom - webook</title>",
+				`<link href="https://webook.example.com/feed/author/123/atom.xml" rel="self"`,
+			},
+		},
+		{
+			name: "author not found",
+			mock: func(ctrl *gomock.Controller) (ArticleService,
+				repository.FeedRepository, repository.UserRepository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().Get(gomock.Any(), domain.FeedFormatRSS, int64(123)).
+					Return(domain.Feed{}, errors.New("cache miss"))
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(123)).
+					Return(domain.User{}, repository.ErrUserNotFound)
+				return nil, repo, userRepo
+			},
+			format:  domain.FeedFormatRSS,
+			uid:     123,
+			wantErr: ErrFeedAuthorNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc, repo, userRepo := tc.mock(ctrl)
+			feedSvc := NewImplFeedService(svc, repo, userRepo, "webook",
+				"https://webook.example.com", 20, logger.NewNopLogger())
+			f, err := feedSvc.Feed(context.Background(), tc.format, tc.uid)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.format, f.Format)
+			assert.True(t, tc.wantUtime.Equal(f.Utime))
+			for _, c := range tc.wantContent {
+				assert.Contains(t, string(f.Content), c)
+			}
+		})
+	}
+}
@@ -2,6 +2,10 @@ package service
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
 	"webook/webook/internal/domain"
 	"webook/webook/internal/repository"
 
@@ -10,21 +14,60 @@ import (
 
 type InteractiveService interface {
 	IncreaseViewCount(ctx context.Context, biz string, bizId int64) error
-	Like(ctx context.Context, biz string, id int64, uid int64) error
-	CancelLike(ctx context.Context, biz string, id int64, uid int64) error
-	Collect(ctx context.Context, biz string, id int64, cid int64, uid int64) error
-	CancelCollect(ctx context.Context, biz string, id int64, uid int64) error
+	// Like set the reaction of the user, "" is ReactionLike. It returns
+	// ErrInvalidReaction if the reaction is not one of the biz.
+	// Like, CancelLike, Collect and CancelCollect are idempotent, they return
+	// the counts after the call with the state of the user it sets,
+	// Liked and Reaction or Collected
+	Like(ctx context.Context, biz string, id int64, uid int64, reaction string) (domain.InteractiveCount, error)
+	CancelLike(ctx context.Context, biz string, id int64, uid int64) (domain.InteractiveCount, error)
+	// Collect return ErrCollectionNotFound if cid is not a collection of uid,
+	// 0 is the default collection
+	Collect(ctx context.Context, biz string, id int64, cid int64, uid int64) (domain.InteractiveCount, error)
+	// CancelCollect remove the resource from all the collections of the user
+	CancelCollect(ctx context.Context, biz string, id int64, uid int64) (domain.InteractiveCount, error)
 	Get(ctx context.Context, biz string, id int64, uid int64) (domain.InteractiveCount, error)
-	GetByIds(ctx context.Context, biz string, ids []int64) (map[int64]domain.InteractiveCount, error)
+	// GetByIds get the counts of a page, Liked, Reaction and Collected
+	// are filled as well if uid is not 0
+	GetByIds(ctx context.Context, biz string, ids []int64, uid int64) (map[int64]domain.InteractiveCount, error)
+	// RecordUniqueView count the visitor, a user or an anonymous fingerprint,
+	// once a day. The raw page views are counted by IncreaseViewCount
+	RecordUniqueView(ctx context.Context, biz string, id int64, visitor string) error
+	// FoldUniqueViews add the unique views of the day to the counts,
+	// return the number of the resources changed
+	FoldUniqueViews(ctx context.Context, biz string, day time.Time) (int, error)
+	// ReconcileCounts check all the like, reaction and collect counts of
+	// the biz against the likes and the collections, batch by batch.
+	// The diffs are repaired unless dryRun
+	ReconcileCounts(ctx context.Context, biz string, dryRun bool) (domain.ReconcileReport, error)
 }
 
+var ErrInvalidReaction = errors.New("invalid reaction")
+
 type ImplInteractiveService struct {
-	repo repository.InteractiveRepository
+	repo        repository.InteractiveRepository
+	collectRepo repository.CollectionRepository
+	// reactions is the reactions of each biz,
+	// a biz not in it only has ReactionLike
+	reactions map[string][]string
+	// foldBatchSize is how many resources are folded in a transaction
+	foldBatchSize int64
+	// reconcileBatchSize is how many counts are checked in a transaction,
+	// maxReportDiffs is how many diffs are kept in the report
+	reconcileBatchSize int
+	maxReportDiffs     int
 }
 
-func NewInteractiveService(repo repository.InteractiveRepository) InteractiveService {
+func NewInteractiveService(repo repository.InteractiveRepository,
+	collectRepo repository.CollectionRepository,
+	reactions map[string][]string) InteractiveService {
 	return &ImplInteractiveService{
-		repo: repo,
+		repo:               repo,
+		collectRepo:        collectRepo,
+		reactions:          reactions,
+		foldBatchSize:      100,
+		reconcileBatchSize: 200,
+		maxReportDiffs:     100,
 	}
 }
 
@@ -33,23 +76,61 @@ func (s *ImplInteractiveService) IncreaseViewCount(ctx context.Context, biz stri
 }
 
 func (s *ImplInteractiveService) Like(ctx context.Context,
-	biz string, id int64, uid int64) error {
-	return s.repo.IncreaseLike(ctx, biz, id, uid)
+	biz string, id int64, uid int64, reaction string) (domain.InteractiveCount, error) {
+	if reaction == "" {
+		reaction = domain.ReactionLike
+	}
+	if !s.validReaction(biz, reaction) {
+		return domain.InteractiveCount{}, ErrInvalidReaction
+	}
+	err := s.repo.IncreaseLike(ctx, biz, id, uid, reaction)
+	if err != nil {
+		return domain.InteractiveCount{}, err
+	}
+	res, err := s.repo.Get(ctx, biz, id)
+	res.Liked, res.Reaction = true, reaction
+	return res, err
+}
+
+func (s *ImplInteractiveService) validReaction(biz string, reaction string) bool {
+	reactions, ok := s.reactions[biz]
+	if !ok {
+		return reaction == domain.ReactionLike
+	}
+	return slices.Contains(reactions, reaction)
 }
 
 func (s *ImplInteractiveService) CancelLike(ctx context.Context,
-	biz string, id int64, uid int64) error {
-	return s.repo.DecreaseLike(ctx, biz, id, uid)
+	biz string, id int64, uid int64) (domain.InteractiveCount, error) {
+	err := s.repo.DecreaseLike(ctx, biz, id, uid)
+	if err != nil {
+		return domain.InteractiveCount{}, err
+	}
+	return s.repo.Get(ctx, biz, id)
 }
 
 func (s *ImplInteractiveService) Collect(ctx context.Context,
-	biz string, id int64, cid int64, uid int64) error {
-	return s.repo.AddCollectionItem(ctx, biz, id, cid, uid)
+	biz string, id int64, cid int64, uid int64) (domain.InteractiveCount, error) {
+	err := checkCollectionOwner(ctx, s.collectRepo, uid, cid)
+	if err != nil {
+		return domain.InteractiveCount{}, err
+	}
+	err = s.repo.AddCollectionItem(ctx, biz, id, cid, uid)
+	if err != nil {
+		return domain.InteractiveCount{}, err
+	}
+	res, err := s.repo.Get(ctx, biz, id)
+	res.Collected = true
+	return res, err
 }
 
 func (s *ImplInteractiveService) CancelCollect(ctx context.Context,
-	biz string, id int64, uid int64) error {
-	return s.repo.DeleteCollectionItem(ctx, biz, id, uid)
+	biz string, id int64, uid int64) (domain.InteractiveCount, error) {
+	err := s.repo.DeleteCollectionItem(ctx, biz, id, uid)
+	if err != nil {
+		return domain.InteractiveCount{}, err
+	}
+	return s.repo.Get(ctx, biz, id)
 }
 
 func (s *ImplInteractiveService) Get(ctx context.Context,
@@ -59,11 +140,16 @@ func (s *ImplInteractiveService) Get(ctx context.Context,
 	if err != nil {
 		return domain.InteractiveCount{}, err
 	}
+	// The anonymous reader hasn't liked or collected it
+	if uid <= 0 {
+		return inter, nil
+	}
 
 	var eg errgroup.Group
 	eg.Go(func() error {
 		var er error
-		inter.Liked, er = s.repo.Liked(ctx, biz, id, uid)
+		inter.Reaction, er = s.repo.Reaction(ctx, biz, id, uid)
+		inter.Liked = inter.Reaction != ""
 		return er
 	})
 
@@ -77,18 +163,82 @@ func (s *ImplInteractiveService) Get(ctx context.Context,
 }
 
 func (s *ImplInteractiveService) GetByIds(ctx context.Context,
-	biz string, ids []int64) (map[int64]domain.InteractiveCount, error) {
+	biz string, ids []int64, uid int64) (map[int64]domain.InteractiveCount, error) {
 
-	inters, err := s.repo.GetByIds(ctx, biz, ids)
-	if err != nil {
+	var (
+		eg     errgroup.Group
+		inters []domain.InteractiveCount
+		states map[int64]domain.InteractiveCount
+	)
+	eg.Go(func() error {
+		var er error
+		inters, er = s.repo.GetByIds(ctx, biz, ids)
+		return er
+	})
+	if uid > 0 {
+		eg.Go(func() error {
+			var er error
+			states, er = s.repo.GetUserStates(ctx, biz, uid, ids)
+			return er
+		})
+	}
+	if err := eg.Wait(); err != nil {
 		return nil, err
 	}
 
 	// map[articleId]InteractiveCount
 	res := make(map[int64]domain.InteractiveCount, len(inters))
 	for _, inter := range inters {
+		state := states[inter.BizId]
+		inter.Liked = state.Liked
+		inter.Reaction = state.Reaction
+		inter.Collected = state.Collected
 		res[inter.BizId] = inter
 	}
 
 	return res, nil
 }
+
+func (s *ImplInteractiveService) RecordUniqueView(ctx context.Context,
+	biz string, id int64, visitor string) error {
+	return s.repo.AddUniqueView(ctx, biz, id, visitor, time.Now())
+}
+
+func (s *ImplInteractiveService) FoldUniqueViews(ctx context.Context,
+	biz string, day time.Time) (int, error) {
+	return s.repo.FoldUniqueViews(ctx, biz, day, s.foldBatchSize)
+}
+
+func (s *ImplInteractiveService) ReconcileCounts(ctx context.Context,
+	biz string, dryRun bool) (domain.ReconcileReport, error) {
+	res := domain.ReconcileReport{
+		DryRun:   dryRun,
+		DiffCnts: make(map[string]int),
+	}
+	var afterId int64
+	for {
+		batch, err := s.repo.ReconcileCounts(ctx, biz, afterId, s.reconcileBatchSize, dryRun)
+		if err != nil {
+			return res, err
+		}
+		res.Scanned += batch.Scanned
+		for _, diff := range batch.Diffs {
+			res.DiffCnts[counterKind(diff.Counter)]++
+			if len(res.Diffs) < s.maxReportDiffs {
+				res.Diffs = append(res.Diffs, diff)
+			}
+		}
+		if batch.Scanned < s.reconcileBatchSize {
+			return res, nil
+		}
+		afterId = batch.LastId
+	}
+}
+
+// counterKind put the reactions together
+func counterKind(counter string) string {
+	if strings.HasPrefix(counter, domain.CounterReactionPrefix) {
+		return "reaction"
+	}
+	return counter
+}
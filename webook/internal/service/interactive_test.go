@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"webook/webook/internal/domain"
+	repomocks "webook/webook/internal/repository/mocks"
+)
+
+func TestImplInteractiveService_Like(t *testing.T) {
+	reactions := map[string][]string{
+		"article": {domain.ReactionLike, "funny"},
+	}
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) *repomocks.MockInteractiveRepository
+		biz      string
+		reaction string
+		wantRes  domain.InteractiveCount
+		wantErr  error
+	}{
+		{
+			name: "like by default",
+			mock: func(ctrl *gomock.Controller) *repomocks.MockInteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().IncreaseLike(gomock.Any(), "article", int64(1), int64(123),
+					domain.ReactionLike).Return(nil)
+				repo.EXPECT().Get(gomock.Any(), "article", int64(1)).
+					Return(domain.InteractiveCount{LikeCnt: 1}, nil)
+				return repo
+			},
+			biz: "article",
+			wantRes: domain.InteractiveCount{
+				LikeCnt:  1,
+				Liked:    true,
+				Reaction: domain.ReactionLike,
+			},
+		},
+		{
+			name: "configured reaction",
+			mock: func(ctrl *gomock.Controller) *repomocks.MockInteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().IncreaseLike(gomock.Any(), "article", int64(1), int64(123),
+					"funny").Return(nil)
+				repo.EXPECT().Get(gomock.Any(), "article", int64(1)).
+					Return(domain.InteractiveCount{LikeCnt: 2}, nil)
+				return repo
+			},
+			biz:      "article",
+			reaction: "funny",
+			wantRes: domain.InteractiveCount{
+				LikeCnt:  2,
+				Liked:    true,
+				Reaction: "funny",
+			},
+		},
+		{
+			name: "unknown reaction",
+			mock: func(ctrl *gomock.Controller) *repomocks.MockInteractiveRepository {
+				return repomocks.NewMockInteractiveRepository(ctrl)
+			},
+			biz:      "article",
+			reaction: "angry",
+			wantErr:  ErrInvalidReaction,
+		},
+		{
+			name: "biz without reactions",
+			mock: func(ctrl *gomock.Controller) *repomocks.MockInteractiveRepository {
+				return repomocks.NewMockInteractiveRepository(ctrl)
+			},
+			biz:      "comment",
+			reaction: "funny",
+			wantErr:  ErrInvalidReaction,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewInteractiveService(tc.mock(ctrl), nil, reactions)
+			res, err := svc.Like(context.Background(), tc.biz, 1, 123, tc.reaction)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
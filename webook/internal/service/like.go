@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+)
+
+// LikeService list the likes of the articles, both sides of them
+type LikeService interface {
+	// ListLikers list the users who like the published article, the latest
+	// first. It returns ErrArticleNotFound if the article is not published
+	ListLikers(ctx context.Context, aid int64, cursor domain.Cursor, limit int64) ([]domain.Like, error)
+	// ListLikedArticles list the articles the user likes, the latest first.
+	// The articles no longer published are skipped, so a page may be short,
+	// the next page starts from the returned cursor, it's zero at the end
+	ListLikedArticles(ctx context.Context, uid int64, cursor domain.Cursor, limit int64) ([]domain.LikedArticle, domain.Cursor, error)
+}
+
+type ImplLikeService struct {
+	interRepo  repository.InteractiveRepository
+	readerRepo repository.ArticleReaderRepository
+	userRepo   repository.UserRepository
+	biz        string
+}
+
+func NewImplLikeService(interRepo repository.InteractiveRepository,
+	readerRepo repository.ArticleReaderRepository,
+	userRepo repository.UserRepository) LikeService {
+	return &ImplLikeService{
+		interRepo:  interRepo,
+		readerRepo: readerRepo,
+		userRepo:   userRepo,
+		biz:        "article",
+	}
+}
+
+func (s *ImplLikeService) ListLikers(ctx context.Context,
+	aid int64, cursor domain.Cursor, limit int64) ([]domain.Like, error) {
+	arti, err := s.readerRepo.GetPubById(ctx, aid)
+	if err != nil {
+		return nil, err
+	}
+	if arti.Status != domain.ArticleStatusPublished {
+		return nil, ErrArticleNotFound
+	}
+
+	likes, err := s.interRepo.ListLikers(ctx, s.biz, aid, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range likes {
+		u, er := s.userRepo.FindByID(ctx, likes[i].Uid)
+		if errors.Is(er, repository.ErrUserNotFound) {
+			// Shown without the nickname
+			continue
+		}
+		if er != nil {
+			return nil, er
+		}
+		likes[i].Nickname = u.NickName
+	}
+	return likes, nil
+}
+
+func (s *ImplLikeService) ListLikedArticles(ctx context.Context,
+	uid int64, cursor domain.Cursor, limit int64) ([]domain.LikedArticle, domain.Cursor, error) {
+	likes, err := s.interRepo.ListLiked(ctx, s.biz, uid, cursor, limit)
+	if err != nil {
+		return nil, domain.Cursor{}, err
+	}
+
+	res := make([]domain.LikedArticle, 0, len(likes))
+	for _, like := range likes {
+		arti, er := s.readerRepo.GetPubById(ctx, like.BizId)
+		if errors.Is(er, repository.ErrArticleNotFound) {
+			// Deleted after it's liked
+			continue
+		}
+		if er != nil {
+			return nil, domain.Cursor{}, er
+		}
+		// The unlisted ones are liked from the share links
+		if arti.Status == domain.ArticleStatusPublished ||
+			arti.Status == domain.ArticleStatusUnlisted {
+			res = append(res, domain.LikedArticle{Like: like, Article: arti})
+		}
+	}
+
+	var next domain.Cursor
+	if len(likes) > 0 && int64(len(likes)) == limit {
+		last := likes[len(likes)-1]
+		next = domain.Cursor{Utime: last.Utime, Id: last.Id}
+	}
+	return res, next, nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+	repomocks "webook/webook/internal/repository/mocks"
+)
+
+func TestImplLikeService_ListLikers(t *testing.T) {
+	utime := time.UnixMilli(1700000000000)
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (repository.InteractiveRepository,
+			repository.ArticleReaderRepository, repository.UserRepository)
+
+		want    []domain.Like
+		wantErr error
+	}{
+		{
+			name: "nicknames",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository,
+				repository.ArticleReaderRepository, repository.UserRepository) {
+				interRepo := repomocks.NewMockInteractiveRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				readerRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Status: domain.ArticleStatusPublished}, nil)
+				interRepo.EXPECT().ListLikers(gomock.Any(), "article", int64(1),
+					domain.Cursor{}, int64(2)).
+					Return([]domain.Like{
+						{Id: 5, Uid: 123, Reaction: "like", Utime: utime},
+						{Id: 4, Uid: 456, Reaction: "funny", Utime: utime},
+					}, nil)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(123)).
+					Return(domain.User{Id: 123, NickName: "Tom"}, nil)
+				// The user is gone
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(456)).
+					Return(domain.User{}, repository.ErrUserNotFound)
+				return interRepo, readerRepo, userRepo
+			},
+			want: []domain.Like{
+				{Id: 5, Uid: 123, Reaction: "like", Utime: utime, Nickname: "Tom"},
+				{Id: 4, Uid: 456, Reaction: "funny", Utime: utime},
+			},
+		},
+		{
+			name: "not published",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository,
+				repository.ArticleReaderRepository, repository.UserRepository) {
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				readerRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Status: domain.ArticleStatusUnlisted}, nil)
+				return repomocks.NewMockInteractiveRepository(ctrl), readerRepo,
+					repomocks.NewMockUserRepository(ctrl)
+			},
+			wantErr: ErrArticleNotFound,
+		},
+		{
+			name: "user error",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository,
+				repository.ArticleReaderRepository, repository.UserRepository) {
+				interRepo := repomocks.NewMockInteractiveRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				readerRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Status: domain.ArticleStatusPublished}, nil)
+				interRepo.EXPECT().ListLikers(gomock.Any(), "article", int64(1),
+					domain.Cursor{}, int64(2)).
+					Return([]domain.Like{{Id: 5, Uid: 123}}, nil)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(123)).
+					Return(domain.User{}, errors.New("db error"))
+				return interRepo, readerRepo, userRepo
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewImplLikeService(tc.mock(ctrl))
+			likes, err := svc.ListLikers(context.Background(), 1, domain.Cursor{}, 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, likes)
+		})
+	}
+}
+
+func TestImplLikeService_ListLikedArticles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	utime := time.UnixMilli(1700000000000)
+	cursor := domain.Cursor{Utime: utime.Add(time.Hour), Id: 9}
+	interRepo := repomocks.NewMockInteractiveRepository(ctrl)
+	readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+	likes := []domain.Like{
+		{Id: 8, BizId: 11, Reaction: "like", Utime: utime},
+		{Id: 7, BizId: 12, Reaction: "funny", Utime: utime},
+		{Id: 6, BizId: 13, Reaction: "like", Utime: utime},
+	}
+	interRepo.EXPECT().ListLiked(gomock.Any(), "article", int64(123), cursor, int64(3)).
+		Return(likes, nil)
+	readerRepo.EXPECT().GetPubById(gomock.Any(), int64(11)).
+		Return(domain.Article{Id: 11, Status: domain.ArticleStatusPublished}, nil)
+	readerRepo.EXPECT().GetPubById(gomock.Any(), int64(12)).
+		Return(domain.Article{}, repository.ErrArticleNotFound)
+	readerRepo.EXPECT().GetPubById(gomock.Any(), int64(13)).
+		Return(domain.Article{Id: 13, Status: domain.ArticleStatusPrivate}, nil)
+
+	svc := NewImplLikeService(interRepo, readerRepo, nil)
+	res, next, err := svc.ListLikedArticles(context.Background(), 123, cursor, 3)
+	require.NoError(t, err)
+	// The page is short, but the next one goes on after the skipped
+	assert.Equal(t, []domain.LikedArticle{{
+		Like:    likes[0],
+		Article: domain.Article{Id: 11, Status: domain.ArticleStatusPublished},
+	}}, res)
+	assert.Equal(t, domain.Cursor{Utime: utime, Id: 6}, next)
+
+	interRepo.EXPECT().ListLiked(gomock.Any(), "article", int64(123), next, int64(3)).
+		Return(likes[:0], nil)
+	res, next, err = svc.ListLikedArticles(context.Background(), 123, next, 3)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+	assert.True(t, next.IsZero())
+}
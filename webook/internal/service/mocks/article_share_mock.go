@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/service/article_share.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/service/article_share.go -package=svcmocks -destination=./webook/internal/service/mocks/article_share_mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	domain "webook/webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleShareService is a mock of ArticleShareService interface.
+type MockArticleShareService struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleShareServiceMockRecorder
+}
+
+// MockArticleShareServiceMockRecorder is the mock recorder for MockArticleShareService.
+type MockArticleShareServiceMockRecorder struct {
+	mock *MockArticleShareService
+}
+
+// NewMockArticleShareService creates a new mock instance.
+func NewMockArticleShareService(ctrl *gomock.Controller) *MockArticleShareService {
+	mock := &MockArticleShareService{ctrl: ctrl}
+	mock.recorder = &MockArticleShareServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleShareService) EXPECT() *MockArticleShareServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockArticleShareService) Create(ctx context.Context, uid, aid int64, draft bool, expireAt time.Time) (domain.ArticleShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, uid, aid, draft, expireAt)
+	ret0, _ := ret[0].(domain.ArticleShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockArticleShareServiceMockRecorder) Create(ctx, uid, aid, draft, expireAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockArticleShareService)(nil).Create), ctx, uid, aid, draft, expireAt)
+}
+
+// GetByToken mocks base method.
+func (m *MockArticleShareService) GetByToken(ctx context.Context, token string) (domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByToken", ctx, token)
+	ret0, _ := ret[0].(domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByToken indicates an expected call of GetByToken.
+func (mr *MockArticleShareServiceMockRecorder) GetByToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByToken", reflect.TypeOf((*MockArticleShareService)(nil).GetByToken), ctx, token)
+}
+
+// List mocks base method.
+func (m *MockArticleShareService) List(ctx context.Context, uid, aid int64) ([]domain.ArticleShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, uid, aid)
+	ret0, _ := ret[0].([]domain.ArticleShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockArticleShareServiceMockRecorder) List(ctx, uid, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockArticleShareService)(nil).List), ctx, uid, aid)
+}
+
+// Revoke mocks base method.
+func (m *MockArticleShareService) Revoke(ctx context.Context, uid, aid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, uid, aid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockArticleShareServiceMockRecorder) Revoke(ctx, uid, aid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockArticleShareService)(nil).Revoke), ctx, uid, aid, id)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/service/article_trash.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/service/article_trash.go -package=svcmocks -destination=./webook/internal/service/mocks/article_trash_mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	domain "webook/webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleTrashService is a mock of ArticleTrashService interface.
+type MockArticleTrashService struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleTrashServiceMockRecorder
+}
+
+// MockArticleTrashServiceMockRecorder is the mock recorder for MockArticleTrashService.
+type MockArticleTrashServiceMockRecorder struct {
+	mock *MockArticleTrashService
+}
+
+// NewMockArticleTrashService creates a new mock instance.
+func NewMockArticleTrashService(ctrl *gomock.Controller) *MockArticleTrashService {
+	mock := &MockArticleTrashService{ctrl: ctrl}
+	mock.recorder = &MockArticleTrashServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleTrashService) EXPECT() *MockArticleTrashServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockArticleTrashService) Delete(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArticleTrashServiceMockRecorder) Delete(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleTrashService)(nil).Delete), ctx, uid, id)
+}
+
+// List mocks base method.
+func (m *MockArticleTrashService) List(ctx context.Context, uid, offset, limit int64) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockArticleTrashServiceMockRecorder) List(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockArticleTrashService)(nil).List), ctx, uid, offset, limit)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockArticleTrashService) PurgeExpired(ctx context.Context, now time.Time, limit int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired", ctx, now, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockArticleTrashServiceMockRecorder) PurgeExpired(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockArticleTrashService)(nil).PurgeExpired), ctx, now, limit)
+}
+
+// Restore mocks base method.
+func (m *MockArticleTrashService) Restore(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockArticleTrashServiceMockRecorder) Restore(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockArticleTrashService)(nil).Restore), ctx, uid, id)
+}
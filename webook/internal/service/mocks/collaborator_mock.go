@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/service/collaborator.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/service/collaborator.go -package=svcmocks -destination=./webook/internal/service/mocks/collaborator_mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "webook/webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCollaboratorService is a mock of CollaboratorService interface.
+type MockCollaboratorService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCollaboratorServiceMockRecorder
+}
+
+// MockCollaboratorServiceMockRecorder is the mock recorder for MockCollaboratorService.
+type MockCollaboratorServiceMockRecorder struct {
+	mock *MockCollaboratorService
+}
+
+// NewMockCollaboratorService creates a new mock instance.
+func NewMockCollaboratorService(ctrl *gomock.Controller) *MockCollaboratorService {
+	mock := &MockCollaboratorService{ctrl: ctrl}
+	mock.recorder = &MockCollaboratorServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCollaboratorService) EXPECT() *MockCollaboratorServiceMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockCollaboratorService) Accept(ctx context.Context, uid, aid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", ctx, uid, aid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockCollaboratorServiceMockRecorder) Accept(ctx, uid, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockCollaboratorService)(nil).Accept), ctx, uid, aid)
+}
+
+// Invite mocks base method.
+func (m *MockCollaboratorService) Invite(ctx context.Context, uid, aid, invitee int64, role domain.CollaboratorRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", ctx, uid, aid, invitee, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockCollaboratorServiceMockRecorder) Invite(ctx, uid, aid, invitee, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockCollaboratorService)(nil).Invite), ctx, uid, aid, invitee, role)
+}
+
+// List mocks base method.
+func (m *MockCollaboratorService) List(ctx context.Context, uid, aid int64) ([]domain.Collaborator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, uid, aid)
+	ret0, _ := ret[0].([]domain.Collaborator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockCollaboratorServiceMockRecorder) List(ctx, uid, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCollaboratorService)(nil).List), ctx, uid, aid)
+}
+
+// Remove mocks base method.
+func (m *MockCollaboratorService) Remove(ctx context.Context, uid, aid, collaborator int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, uid, aid, collaborator)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockCollaboratorServiceMockRecorder) Remove(ctx, uid, aid, collaborator any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockCollaboratorService)(nil).Remove), ctx, uid, aid, collaborator)
+}
@@ -12,6 +12,7 @@ package svcmocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 	domain "webook/webook/internal/domain"
 
 	gomock "go.uber.org/mock/gomock"
@@ -40,12 +41,28 @@ func (m *MockInteractiveService) EXPECT() *MockInteractiveServiceMockRecorder {
 	return m.recorder
 }
 
+// CancelCollect mocks base method.
+func (m *MockInteractiveService) CancelCollect(ctx context.Context, biz string, id, uid int64) (domain.InteractiveCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelCollect", ctx, biz, id, uid)
+	ret0, _ := ret[0].(domain.InteractiveCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelCollect indicates an expected call of CancelCollect.
+func (mr *MockInteractiveServiceMockRecorder) CancelCollect(ctx, biz, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelCollect", reflect.TypeOf((*MockInteractiveService)(nil).CancelCollect), ctx, biz, id, uid)
+}
+
 // CancelLike mocks base method.
-func (m *MockInteractiveService) CancelLike(ctx context.Context, biz string, id, uid int64) error {
+func (m *MockInteractiveService) CancelLike(ctx context.Context, biz string, id, uid int64) (domain.InteractiveCount, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CancelLike", ctx, biz, id, uid)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(domain.InteractiveCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // CancelLike indicates an expected call of CancelLike.
@@ -55,11 +72,12 @@ func (mr *MockInteractiveServiceMockRecorder) CancelLike(ctx, biz, id, uid any)
 }
 
 // Collect mocks base method.
-func (m *MockInteractiveService) Collect(ctx context.Context, biz string, id, cid, uid int64) error {
+func (m *MockInteractiveService) Collect(ctx context.Context, biz string, id, cid, uid int64) (domain.InteractiveCount, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Collect", ctx, biz, id, cid, uid)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(domain.InteractiveCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Collect indicates an expected call of Collect.
@@ -68,6 +86,21 @@ func (mr *MockInteractiveServiceMockRecorder) Collect(ctx, biz, id, cid, uid any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collect", reflect.TypeOf((*MockInteractiveService)(nil).Collect), ctx, biz, id, cid, uid)
 }
 
+// FoldUniqueViews mocks base method.
+func (m *MockInteractiveService) FoldUniqueViews(ctx context.Context, biz string, day time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FoldUniqueViews", ctx, biz, day)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FoldUniqueViews indicates an expected call of FoldUniqueViews.
+func (mr *MockInteractiveServiceMockRecorder) FoldUniqueViews(ctx, biz, day any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FoldUniqueViews", reflect.TypeOf((*MockInteractiveService)(nil).FoldUniqueViews), ctx, biz, day)
+}
+
 // Get mocks base method.
 func (m *MockInteractiveService) Get(ctx context.Context, biz string, id, uid int64) (domain.InteractiveCount, error) {
 	m.ctrl.T.Helper()
@@ -84,18 +117,18 @@ func (mr *MockInteractiveServiceMockRecorder) Get(ctx, biz, id, uid any) *gomock
 }
 
 // GetByIds mocks base method.
-func (m *MockInteractiveService) GetByIds(ctx context.Context, biz string, ids []int64) (map[int64]domain.InteractiveCount, error) {
+func (m *MockInteractiveService) GetByIds(ctx context.Context, biz string, ids []int64, uid int64) (map[int64]domain.InteractiveCount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByIds", ctx, biz, ids)
+	ret := m.ctrl.Call(m, "GetByIds", ctx, biz, ids, uid)
 	ret0, _ := ret[0].(map[int64]domain.InteractiveCount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByIds indicates an expected call of GetByIds.
-func (mr *MockInteractiveServiceMockRecorder) GetByIds(ctx, biz, ids any) *gomock.Call {
+func (mr *MockInteractiveServiceMockRecorder) GetByIds(ctx, biz, ids, uid any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractiveService)(nil).GetByIds), ctx, biz, ids)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractiveService)(nil).GetByIds), ctx, biz, ids, uid)
 }
 
 // IncreaseViewCount mocks base method.
@@ -113,15 +146,45 @@ func (mr *MockInteractiveServiceMockRecorder) IncreaseViewCount(ctx, biz, bizId
 }
 
 // Like mocks base method.
-func (m *MockInteractiveService) Like(ctx context.Context, biz string, id, uid int64) error {
+func (m *MockInteractiveService) Like(ctx context.Context, biz string, id, uid int64, reaction string) (domain.InteractiveCount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Like", ctx, biz, id, uid)
+	ret := m.ctrl.Call(m, "Like", ctx, biz, id, uid, reaction)
+	ret0, _ := ret[0].(domain.InteractiveCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Like indicates an expected call of Like.
+func (mr *MockInteractiveServiceMockRecorder) Like(ctx, biz, id, uid, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockInteractiveService)(nil).Like), ctx, biz, id, uid, reaction)
+}
+
+// ReconcileCounts mocks base method.
+func (m *MockInteractiveService) ReconcileCounts(ctx context.Context, biz string, dryRun bool) (domain.ReconcileReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileCounts", ctx, biz, dryRun)
+	ret0, _ := ret[0].(domain.ReconcileReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileCounts indicates an expected call of ReconcileCounts.
+func (mr *MockInteractiveServiceMockRecorder) ReconcileCounts(ctx, biz, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileCounts", reflect.TypeOf((*MockInteractiveService)(nil).ReconcileCounts), ctx, biz, dryRun)
+}
+
+// RecordUniqueView mocks base method.
+func (m *MockInteractiveService) RecordUniqueView(ctx context.Context, biz string, id int64, visitor string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordUniqueView", ctx, biz, id, visitor)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Like indicates an expected call of Like.
-func (mr *MockInteractiveServiceMockRecorder) Like(ctx, biz, id, uid any) *gomock.Call {
+// RecordUniqueView indicates an expected call of RecordUniqueView.
+func (mr *MockInteractiveServiceMockRecorder) RecordUniqueView(ctx, biz, id, visitor any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockInteractiveService)(nil).Like), ctx, biz, id, uid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordUniqueView", reflect.TypeOf((*MockInteractiveService)(nil).RecordUniqueView), ctx, biz, id, visitor)
 }
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/service/like.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webook/internal/service/like.go -package=svcmocks -destination=./webook/internal/service/mocks/like_mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "webook/webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLikeService is a mock of LikeService interface.
+type MockLikeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLikeServiceMockRecorder
+}
+
+// MockLikeServiceMockRecorder is the mock recorder for MockLikeService.
+type MockLikeServiceMockRecorder struct {
+	mock *MockLikeService
+}
+
+// NewMockLikeService creates a new mock instance.
+func NewMockLikeService(ctrl *gomock.Controller) *MockLikeService {
+	mock := &MockLikeService{ctrl: ctrl}
+	mock.recorder = &MockLikeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLikeService) EXPECT() *MockLikeServiceMockRecorder {
+	return m.recorder
+}
+
+// ListLikedArticles mocks base method.
+func (m *MockLikeService) ListLikedArticles(ctx context.Context, uid int64, cursor domain.Cursor, limit int64) ([]domain.LikedArticle, domain.Cursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLikedArticles", ctx, uid, cursor, limit)
+	ret0, _ := ret[0].([]domain.LikedArticle)
+	ret1, _ := ret[1].(domain.Cursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListLikedArticles indicates an expected call of ListLikedArticles.
+func (mr *MockLikeServiceMockRecorder) ListLikedArticles(ctx, uid, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikedArticles", reflect.TypeOf((*MockLikeService)(nil).ListLikedArticles), ctx, uid, cursor, limit)
+}
+
+// ListLikers mocks base method.
+func (m *MockLikeService) ListLikers(ctx context.Context, aid int64, cursor domain.Cursor, limit int64) ([]domain.Like, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLikers", ctx, aid, cursor, limit)
+	ret0, _ := ret[0].([]domain.Like)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLikers indicates an expected call of ListLikers.
+func (mr *MockLikeServiceMockRecorder) ListLikers(ctx, aid, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikers", reflect.TypeOf((*MockLikeService)(nil).ListLikers), ctx, aid, cursor, limit)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+	"webook/webook/pkg/moderation"
+)
+
+var (
+	ErrContentRejected        = errors.New("content is rejected by moderation")
+	ErrContentUnderReview     = errors.New("content is sent to the manual review")
+	ErrModerationItemNotFound = repository.ErrModerationItemNotFound
+)
+
+// ModerationService check the texts against the sensitive words, and keep
+// the queue of the texts waiting for the manual review
+type ModerationService interface {
+	// Check check the texts together, the strictest action of them wins
+	Check(ctx context.Context, texts ...string) domain.ModerationResult
+	// Submit queue the item for the manual review
+	Submit(ctx context.Context, item domain.ModerationItem) error
+	ListPending(ctx context.Context, offset, limit int64) ([]domain.ModerationItem, error)
+	// Review approve or reject the pending item and return it,
+	// ErrModerationItemNotFound if it's not pending
+	Review(ctx context.Context, id, reviewer int64, approve bool) (domain.ModerationItem, error)
+	// Reopen put the approved item back to the queue,
+	// it's for the item failed to be published
+	Reopen(ctx context.Context, id int64) error
+}
+
+type ImplModerationService struct {
+	repo   repository.ModerationRepository
+	filter *moderation.Filter
+}
+
+func NewImplModerationService(repo repository.ModerationRepository,
+	filter *moderation.Filter) ModerationService {
+	return &ImplModerationService{
+		repo:   repo,
+		filter: filter,
+	}
+}
+
+func (s *ImplModerationService) Check(ctx context.Context, texts ...string) domain.ModerationResult {
+	res := domain.ModerationResult{
+		Texts: make([]string, 0, len(texts)),
+	}
+	seen := make(map[string]struct{})
+	for _, text := range texts {
+		r := s.filter.Check(text)
+		if r.Action > res.Action {
+			res.Action = r.Action
+		}
+		res.Texts = append(res.Texts, r.Text)
+		for _, w := range r.Words {
+			if _, ok := seen[w]; !ok {
+				seen[w] = struct{}{}
+				res.Words = append(res.Words, w)
+			}
+		}
+	}
+	return res
+}
+
+func (s *ImplModerationService) Submit(ctx context.Context, item domain.ModerationItem) error {
+	_, err := s.repo.Create(ctx, item)
+	return err
+}
+
+func (s *ImplModerationService) ListPending(ctx context.Context,
+	offset, limit int64) ([]domain.ModerationItem, error) {
+	return s.repo.ListPending(ctx, offset, limit)
+}
+
+func (s *ImplModerationService) Review(ctx context.Context,
+	id, reviewer int64, approve bool) (domain.ModerationItem, error) {
+	status := domain.ModerationStatusRejected
+	if approve {
+		status = domain.ModerationStatusApproved
+	}
+	// Only one reviewer wins, so the approved item is published once
+	err := s.repo.Review(ctx, id, reviewer, status)
+	if err != nil {
+		return domain.ModerationItem{}, err
+	}
+	return s.repo.GetById(ctx, id)
+}
+
+func (s *ImplModerationService) Reopen(ctx context.Context, id int64) error {
+	return s.repo.Reopen(ctx, id)
+}
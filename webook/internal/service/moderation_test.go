@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+	repomocks "webook/webook/internal/repository/mocks"
+	"webook/webook/pkg/moderation"
+)
+
+func TestImplModerationService_Check(t *testing.T) {
+	svc := NewImplModerationService(nil, moderation.NewFilter(map[string]moderation.Category{
+		"ads":    {Action: moderation.ActionMask, Words: []string{"buy now"}},
+		"gamble": {Action: moderation.ActionReview, Words: []string{"casino"}},
+	}))
+	res := svc.Check(context.Background(), "Buy now", "casino, buy now")
+	assert.Equal(t, domain.ModerationResult{
+		Action: moderation.ActionReview,
+		Texts:  []string{"*******", "casino, *******"},
+		Words:  []string{"buy now", "casino"},
+	}, res)
+}
+
+func TestImplModerationService_Review(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) repository.ModerationRepository
+
+		approve bool
+
+		wantItem domain.ModerationItem
+		wantErr  error
+	}{
+		{
+			name: "approved",
+			mock: func(ctrl *gomock.Controller) repository.ModerationRepository {
+				repo := repomocks.NewMockModerationRepository(ctrl)
+				repo.EXPECT().Review(gomock.Any(), int64(1), int64(9),
+					domain.ModerationStatusApproved).Return(nil)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.ModerationItem{Id: 1, Biz: "comment"}, nil)
+				return repo
+			},
+			approve:  true,
+			wantItem: domain.ModerationItem{Id: 1, Biz: "comment"},
+		},
+		{
+			name: "reviewed already",
+			mock: func(ctrl *gomock.Controller) repository.ModerationRepository {
+				repo := repomocks.NewMockModerationRepository(ctrl)
+				repo.EXPECT().Review(gomock.Any(), int64(1), int64(9),
+					domain.ModerationStatusRejected).Return(ErrModerationItemNotFound)
+				return repo
+			},
+			wantErr: ErrModerationItemNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewImplModerationService(tc.mock(ctrl), moderation.NewFilter(nil))
+			item, err := svc.Review(context.Background(), 1, 9, tc.approve)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantItem, item)
+		})
+	}
+}
@@ -82,7 +82,7 @@ func (s *BatchRankingService) topN(ctx context.Context, n int64) ([]domain.Artic
 		}
 		// Get Interactive Count From Batch
 		ids := getids(artis)
-		interMap, err := s.interSvc.GetByIds(ctx, "article", ids)
+		interMap, err := s.interSvc.GetByIds(ctx, "article", ids, 0)
 		if err != nil {
 			return nil, err
 		}
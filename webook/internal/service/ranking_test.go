@@ -11,6 +11,8 @@ import (
 )
 
 func TestBatchRankingService_TopN(t *testing.T) {
+	// The articles are in the last 7 days, so all the batches are read
+	now := time.Now()
 	testCases := []struct {
 		name string
 
@@ -29,38 +31,38 @@ func TestBatchRankingService_TopN(t *testing.T) {
 				// Mock ListPub()
 				artiSvc.EXPECT().ListPub(gomock.Any(), gomock.Any(), int64(0), int64(2)).
 					Return([]domain.Article{
-						{Id: 1, Title: "title1"},
-						{Id: 2, Title: "title2"},
+						{Id: 1, Title: "title1", Utime: now},
+						{Id: 2, Title: "title2", Utime: now},
 					}, nil)
 				artiSvc.EXPECT().ListPub(gomock.Any(), gomock.Any(), int64(2), int64(2)).
 					Return([]domain.Article{
-						{Id: 3, Title: "title3"},
-						{Id: 4, Title: "title4"},
+						{Id: 3, Title: "title3", Utime: now},
+						{Id: 4, Title: "title4", Utime: now},
 					}, nil)
 				artiSvc.EXPECT().ListPub(gomock.Any(), gomock.Any(), int64(4), int64(2)).
 					Return([]domain.Article{}, nil)
 
 				// Mock GetByIds()
-				interSvc.EXPECT().GetByIds(gomock.Any(), gomock.Any(), []int64{1, 2}).
+				interSvc.EXPECT().GetByIds(gomock.Any(), gomock.Any(), []int64{1, 2}, int64(0)).
 					Return(map[int64]domain.InteractiveCount{
 						1: {LikeCnt: 1},
 						2: {LikeCnt: 2},
 					}, nil)
-				interSvc.EXPECT().GetByIds(gomock.Any(), gomock.Any(), []int64{3, 4}).
+				interSvc.EXPECT().GetByIds(gomock.Any(), gomock.Any(), []int64{3, 4}, int64(0)).
 					Return(map[int64]domain.InteractiveCount{
 						3: {LikeCnt: 3},
 						4: {LikeCnt: 4},
 					}, nil)
-				interSvc.EXPECT().GetByIds(gomock.Any(), gomock.Any(), []int64{}).
+				interSvc.EXPECT().GetByIds(gomock.Any(), gomock.Any(), []int64{}, int64(0)).
 					Return(map[int64]domain.InteractiveCount{}, nil)
 
 				return interSvc, artiSvc
 			},
 			wantErr: nil,
 			wantArtis: []domain.Article{
-				{Id: 4, Title: "title4"},
-				{Id: 3, Title: "title3"},
-				{Id: 2, Title: "title2"},
+				{Id: 4, Title: "title4", Utime: now},
+				{Id: 3, Title: "title3", Utime: now},
+				{Id: 2, Title: "title2", Utime: now},
 			},
 		},
 	}
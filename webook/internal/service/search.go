@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+	"webook/webook/pkg/logger"
+)
+
+type SearchService interface {
+	SearchArticle(ctx context.Context, query string, offset, limit int) (domain.ArticleSearchResult, error)
+	// IndexArticle put the published article into the index
+	IndexArticle(ctx context.Context, id int64) error
+	DeleteArticle(ctx context.Context, id int64) error
+	// Rebuild rebuild the index from all the published articles
+	Rebuild(ctx context.Context) error
+}
+
+type IndexSearchService struct {
+	repo     repository.SearchRepository
+	artiRepo repository.ArticleReaderRepository
+	l        logger.Logger
+
+	batchSize int64
+}
+
+func NewIndexSearchService(repo repository.SearchRepository,
+	artiRepo repository.ArticleReaderRepository, l logger.Logger) SearchService {
+	return &IndexSearchService{
+		repo:      repo,
+		artiRepo:  artiRepo,
+		l:         l,
+		batchSize: 100,
+	}
+}
+
+func (s *IndexSearchService) SearchArticle(ctx context.Context,
+	query string, offset, limit int) (domain.ArticleSearchResult, error) {
+	return s.repo.SearchArticle(ctx, query, offset, limit)
+}
+
+func (s *IndexSearchService) IndexArticle(ctx context.Context, id int64) error {
+	arti, err := s.artiRepo.GetPubById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if arti.Status != domain.ArticleStatusPublished {
+		return s.repo.DeleteArticle(ctx, id)
+	}
+	return s.repo.InputArticle(ctx, arti)
+}
+
+func (s *IndexSearchService) DeleteArticle(ctx context.Context, id int64) error {
+	return s.repo.DeleteArticle(ctx, id)
+}
+
+func (s *IndexSearchService) Rebuild(ctx context.Context) error {
+	var (
+		start  = time.Now()
+		offset int64
+		all    []domain.Article
+	)
+	for {
+		artis, err := s.artiRepo.ListPub(ctx, start, offset, s.batchSize)
+		if err != nil {
+			return err
+		}
+		all = append(all, artis...)
+		if int64(len(artis)) < s.batchSize {
+			break
+		}
+		offset += int64(len(artis))
+	}
+
+	s.l.Info("Rebuild search index", logger.Int64("size", int64(len(all))))
+	return s.repo.ReplaceArticles(ctx, all)
+}
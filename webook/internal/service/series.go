@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+	"webook/webook/pkg/logger"
+)
+
+var (
+	ErrSeriesNotFound = errors.New("series is not found or not owned by the user")
+	// ErrSeriesInvalidArticles means the articles can't be put into the series,
+	// such as an article of others, or in another series
+	ErrSeriesInvalidArticles = errors.New("articles are invalid for the series")
+)
+
+// SeriesService keeps the articles of an author in order. The readers
+// navigate between the published chapters, the others are skipped
+type SeriesService interface {
+	Create(ctx context.Context, uid int64, title string) (int64, error)
+	List(ctx context.Context, uid int64, offset, limit int64) ([]domain.Series, error)
+	// AddArticle append the article to the end of the series
+	AddArticle(ctx context.Context, uid, id, aid int64) error
+	RemoveArticle(ctx context.Context, uid, id, aid int64) error
+	// Reorder set the order of the chapters, aids must be
+	// the same articles as the series has
+	Reorder(ctx context.Context, uid, id int64, aids []int64) error
+	// Nav return ErrSeriesNotFound if the article is in no series
+	Nav(ctx context.Context, aid int64) (domain.SeriesNav, error)
+}
+
+type ImplSeriesService struct {
+	repo       repository.SeriesRepository
+	authorRepo repository.ArticleAuthorRepository
+	readerRepo repository.ArticleReaderRepository
+	l          logger.Logger
+}
+
+func NewImplSeriesService(repo repository.SeriesRepository,
+	authorRepo repository.ArticleAuthorRepository,
+	readerRepo repository.ArticleReaderRepository,
+	l logger.Logger) SeriesService {
+	return &ImplSeriesService{
+		repo:       repo,
+		authorRepo: authorRepo,
+		readerRepo: readerRepo,
+		l:          l,
+	}
+}
+
+func (s *ImplSeriesService) Create(ctx context.Context,
+	uid int64, title string) (int64, error) {
+	return s.repo.Create(ctx, domain.Series{
+		Title:    title,
+		AuthorId: uid,
+	})
+}
+
+func (s *ImplSeriesService) List(ctx context.Context,
+	uid int64, offset, limit int64) ([]domain.Series, error) {
+	return s.repo.ListByAuthor(ctx, uid, offset, limit)
+}
+
+func (s *ImplSeriesService) AddArticle(ctx context.Context,
+	uid, id, aid int64) error {
+	series, err := s.owned(ctx, uid, id)
+	if err != nil {
+		return err
+	}
+
+	arti, err := s.authorRepo.GetById(ctx, aid)
+	if errors.Is(err, repository.ErrArticleNotFound) {
+		return ErrSeriesInvalidArticles
+	}
+	if err != nil {
+		return err
+	}
+	if arti.Author.Id != uid {
+		return ErrSeriesInvalidArticles
+	}
+	for _, cur := range series.ArticleIds {
+		if cur == aid {
+			return nil
+		}
+	}
+	// An article in another series is rejected by the repository
+	err = s.repo.SetArticleIds(ctx, id, append(series.ArticleIds, aid))
+	if errors.Is(err, repository.ErrSeriesArticleDuplicate) {
+		return ErrSeriesInvalidArticles
+	}
+	return err
+}
+
+func (s *ImplSeriesService) RemoveArticle(ctx context.Context,
+	uid, id, aid int64) error {
+	series, err := s.owned(ctx, uid, id)
+	if err != nil {
+		return err
+	}
+	aids := make([]int64, 0, len(series.ArticleIds))
+	for _, cur := range series.ArticleIds {
+		if cur != aid {
+			aids = append(aids, cur)
+		}
+	}
+	if len(aids) == len(series.ArticleIds) {
+		return nil
+	}
+	return s.repo.SetArticleIds(ctx, id, aids)
+}
+
+func (s *ImplSeriesService) Reorder(ctx context.Context,
+	uid, id int64, aids []int64) error {
+	series, err := s.owned(ctx, uid, id)
+	if err != nil {
+		return err
+	}
+	if len(aids) != len(series.ArticleIds) {
+		return ErrSeriesInvalidArticles
+	}
+	cur := make(map[int64]bool, len(series.ArticleIds))
+	for _, aid := range series.ArticleIds {
+		cur[aid] = true
+	}
+	for _, aid := range aids {
+		if !cur[aid] {
+			return ErrSeriesInvalidArticles
+		}
+		// Each one is used once
+		delete(cur, aid)
+	}
+	return s.repo.SetArticleIds(ctx, id, aids)
+}
+
+func (s *ImplSeriesService) Nav(ctx context.Context,
+	aid int64) (domain.SeriesNav, error) {
+	series, err := s.repo.GetByArticleId(ctx, aid)
+	if errors.Is(err, repository.ErrSeriesNotFound) {
+		return domain.SeriesNav{}, ErrSeriesNotFound
+	}
+	if err != nil {
+		return domain.SeriesNav{}, err
+	}
+
+	nav := domain.SeriesNav{
+		SeriesId: series.Id,
+		Title:    series.Title,
+	}
+	for i, cur := range series.ArticleIds {
+		if cur != aid {
+			continue
+		}
+		nav.Prev = s.nearestPub(ctx, series.ArticleIds[:i], -1)
+		nav.Next = s.nearestPub(ctx, series.ArticleIds[i+1:], 1)
+		break
+	}
+	return nav, nil
+}
+
+// nearestPub return the first published article of aids walking in
+// the direction, the zero Article if there is none
+func (s *ImplSeriesService) nearestPub(ctx context.Context,
+	aids []int64, direction int) domain.Article {
+	i, end := 0, len(aids)
+	if direction < 0 {
+		i, end = len(aids)-1, -1
+	}
+	for ; i != end; i += direction {
+		arti, err := s.readerRepo.GetPubById(ctx, aids[i])
+		if err != nil {
+			if !errors.Is(err, repository.ErrArticleNotFound) {
+				s.l.Error("Failed to get chapter of series",
+					logger.Error(err),
+					logger.Int64("aid", aids[i]))
+			}
+			continue
+		}
+		// Skip the withdrawn ones
+		if arti.Status != domain.ArticleStatusPublished {
+			continue
+		}
+		return domain.Article{
+			Id:    arti.Id,
+			Title: arti.Title,
+		}
+	}
+	return domain.Article{}
+}
+
+func (s *ImplSeriesService) owned(ctx context.Context,
+	uid, id int64) (domain.Series, error) {
+	series, err := s.repo.GetById(ctx, id)
+	if errors.Is(err, repository.ErrSeriesNotFound) {
+		return domain.Series{}, ErrSeriesNotFound
+	}
+	if err != nil {
+		return domain.Series{}, err
+	}
+	if series.AuthorId != uid {
+		return domain.Series{}, ErrSeriesNotFound
+	}
+	return series, nil
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+	repomocks "webook/webook/internal/repository/mocks"
+	"webook/webook/pkg/logger"
+)
+
+func TestImplSeriesService_Nav(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (repository.SeriesRepository,
+			repository.ArticleReaderRepository)
+
+		aid int64
+
+		wantNav domain.SeriesNav
+		wantErr error
+	}{
+		{
+			name: "skip withdrawn and deleted",
+			mock: func(ctrl *gomock.Controller) (repository.SeriesRepository,
+				repository.ArticleReaderRepository) {
+				repo := repomocks.NewMockSeriesRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				repo.EXPECT().GetByArticleId(gomock.Any(), int64(3)).
+					Return(domain.Series{Id: 10, Title: "Go", ArticleIds: []int64{1, 2, 3, 4, 5}}, nil)
+				readerRepo.EXPECT().GetPubById(gomock.Any(), int64(2)).
+					Return(domain.Article{Id: 2, Status: domain.ArticleStatusPrivate}, nil)
+				readerRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Title: "Part 1", Status: domain.ArticleStatusPublished}, nil)
+				readerRepo.EXPECT().GetPubById(gomock.Any(), int64(4)).
+					Return(domain.Article{}, repository.ErrArticleNotFound)
+				readerRepo.EXPECT().GetPubById(gomock.Any(), int64(5)).
+					Return(domain.Article{Id: 5, Title: "Part 5", Status: domain.ArticleStatusPublished}, nil)
+				return repo, readerRepo
+			},
+			aid: 3,
+			wantNav: domain.SeriesNav{
+				SeriesId: 10,
+				Title:    "Go",
+				Prev:     domain.Article{Id: 1, Title: "Part 1"},
+				Next:     domain.Article{Id: 5, Title: "Part 5"},
+			},
+		},
+		{
+			name: "first chapter",
+			mock: func(ctrl *gomock.Controller) (repository.SeriesRepository,
+				repository.ArticleReaderRepository) {
+				repo := repomocks.NewMockSeriesRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+				repo.EXPECT().GetByArticleId(gomock.Any(), int64(1)).
+					Return(domain.Series{Id: 10, Title: "Go", ArticleIds: []int64{1, 2}}, nil)
+				readerRepo.EXPECT().GetPubById(gomock.Any(), int64(2)).
+					Return(domain.Article{Id: 2, Status: domain.ArticleStatusPrivate}, nil)
+				return repo, readerRepo
+			},
+			aid:     1,
+			wantNav: domain.SeriesNav{SeriesId: 10, Title: "Go"},
+		},
+		{
+			name: "not in series",
+			mock: func(ctrl *gomock.Controller) (repository.SeriesRepository,
+				repository.ArticleReaderRepository) {
+				repo := repomocks.NewMockSeriesRepository(ctrl)
+				repo.EXPECT().GetByArticleId(gomock.Any(), int64(1)).
+					Return(domain.Series{}, repository.ErrSeriesNotFound)
+				return repo, nil
+			},
+			aid:     1,
+			wantErr: ErrSeriesNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, readerRepo := tc.mock(ctrl)
+			svc := NewImplSeriesService(repo, nil, readerRepo, logger.NewNopLogger())
+			nav, err := svc.Nav(context.Background(), tc.aid)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantNav, nav)
+		})
+	}
+}
+
+func TestImplSeriesService_Reorder(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) repository.SeriesRepository
+
+		aids []int64
+
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(ctrl *gomock.Controller) repository.SeriesRepository {
+				repo := repomocks.NewMockSeriesRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(10)).
+					Return(domain.Series{Id: 10, AuthorId: 123, ArticleIds: []int64{1, 2, 3}}, nil)
+				repo.EXPECT().SetArticleIds(gomock.Any(), int64(10), []int64{3, 1, 2}).
+					Return(nil)
+				return repo
+			},
+			aids: []int64{3, 1, 2},
+		},
+		{
+			name: "duplicated article",
+			mock: func(ctrl *gomock.Controller) repository.SeriesRepository {
+				repo := repomocks.NewMockSeriesRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(10)).
+					Return(domain.Series{Id: 10, AuthorId: 123, ArticleIds: []int64{1, 2, 3}}, nil)
+				return repo
+			},
+			aids:    []int64{1, 1, 2},
+			wantErr: ErrSeriesInvalidArticles,
+		},
+		{
+			name: "missing article",
+			mock: func(ctrl *gomock.Controller) repository.SeriesRepository {
+				repo := repomocks.NewMockSeriesRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(10)).
+					Return(domain.Series{Id: 10, AuthorId: 123, ArticleIds: []int64{1, 2, 3}}, nil)
+				return repo
+			},
+			aids:    []int64{1, 2},
+			wantErr: ErrSeriesInvalidArticles,
+		},
+		{
+			name: "not the owner",
+			mock: func(ctrl *gomock.Controller) repository.SeriesRepository {
+				repo := repomocks.NewMockSeriesRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(10)).
+					Return(domain.Series{Id: 10, AuthorId: 234, ArticleIds: []int64{1}}, nil)
+				return repo
+			},
+			aids:    []int64{1},
+			wantErr: ErrSeriesNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewImplSeriesService(tc.mock(ctrl), nil, nil, logger.NewNopLogger())
+			err := svc.Reorder(context.Background(), 123, 10, tc.aids)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestImplSeriesService_AddArticle(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (repository.SeriesRepository,
+			repository.ArticleAuthorRepository)
+
+		wantErr error
+	}{
+		{
+			name: "append",
+			mock: func(ctrl *gomock.Controller) (repository.SeriesRepository,
+				repository.ArticleAuthorRepository) {
+				repo := repomocks.NewMockSeriesRepository(ctrl)
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(10)).
+					Return(domain.Series{Id: 10, AuthorId: 123, ArticleIds: []int64{1}}, nil)
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(2)).
+					Return(domain.Article{Id: 2, Author: domain.Author{Id: 123}}, nil)
+				repo.EXPECT().SetArticleIds(gomock.Any(), int64(10), []int64{1, 2}).
+					Return(nil)
+				return repo, authorRepo
+			},
+		},
+		{
+			name: "article of others",
+			mock: func(ctrl *gomock.Controller) (repository.SeriesRepository,
+				repository.ArticleAuthorRepository) {
+				repo := repomocks.NewMockSeriesRepository(ctrl)
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(10)).
+					Return(domain.Series{Id: 10, AuthorId: 123, ArticleIds: []int64{1}}, nil)
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(2)).
+					Return(domain.Article{Id: 2, Author: domain.Author{Id: 234}}, nil)
+				return repo, authorRepo
+			},
+			wantErr: ErrSeriesInvalidArticles,
+		},
+		{
+			name: "in another series",
+			mock: func(ctrl *gomock.Controller) (repository.SeriesRepository,
+				repository.ArticleAuthorRepository) {
+				repo := repomocks.NewMockSeriesRepository(ctrl)
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(10)).
+					Return(domain.Series{Id: 10, AuthorId: 123, ArticleIds: []int64{1}}, nil)
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(2)).
+					Return(domain.Article{Id: 2, Author: domain.Author{Id: 123}}, nil)
+				repo.EXPECT().SetArticleIds(gomock.Any(), int64(10), []int64{1, 2}).
+					Return(repository.ErrSeriesArticleDuplicate)
+				return repo, authorRepo
+			},
+			wantErr: ErrSeriesInvalidArticles,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, authorRepo := tc.mock(ctrl)
+			svc := NewImplSeriesService(repo, authorRepo, nil, logger.NewNopLogger())
+			err := svc.AddArticle(context.Background(), 123, 10, 2)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
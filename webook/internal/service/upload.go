@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+	"webook/webook/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUploadTooLarge       = errors.New("upload is too large")
+	ErrUploadTypeNotAllowed = errors.New("upload type is not allowed")
+	ErrUploadForbidden      = errors.New("article is not found or not editable by the user")
+)
+
+// uploadTypes are the content types allowed, mapped to the file extension
+var uploadTypes = map[string]string{
+	"image/png":       ".png",
+	"image/jpeg":      ".jpg",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+type UploadService interface {
+	// Upload save the file for the article editable by uid, the content type
+	// is sniffed from the content instead of trusting the client
+	Upload(ctx context.Context, uid, aid int64, size int64, r io.ReadSeeker) (domain.Upload, error)
+	// GC remove the uploads created before the given time which are referred
+	// by neither the draft nor the published copy, return how many are removed.
+	// The uploads of the articles in the trash are kept, they are removed
+	// when the articles are purged
+	GC(ctx context.Context, before time.Time) (int, error)
+}
+
+type ImplUploadService struct {
+	repo       repository.UploadRepository
+	authorRepo repository.ArticleAuthorRepository
+	readerRepo repository.ArticleReaderRepository
+	perm       articlePermission
+	l          logger.Logger
+
+	maxSize   int64
+	batchSize int64
+}
+
+func NewImplUploadService(repo repository.UploadRepository,
+	authorRepo repository.ArticleAuthorRepository,
+	readerRepo repository.ArticleReaderRepository,
+	collabRepo repository.CollaboratorRepository,
+	maxSize int64,
+	l logger.Logger) UploadService {
+	return &ImplUploadService{
+		repo:       repo,
+		authorRepo: authorRepo,
+		readerRepo: readerRepo,
+		perm: articlePermission{
+			authorRepo: authorRepo,
+			collabRepo: collabRepo,
+		},
+		l:         l,
+		maxSize:   maxSize,
+		batchSize: 100,
+	}
+}
+
+func (s *ImplUploadService) Upload(ctx context.Context,
+	uid, aid int64, size int64, r io.ReadSeeker) (domain.Upload, error) {
+	if size > s.maxSize {
+		return domain.Upload{}, ErrUploadTooLarge
+	}
+
+	// Sniff the content type from the first 512 bytes
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return domain.Upload{}, ErrUploadTypeNotAllowed
+	}
+	contentType := http.DetectContentType(head[:n])
+	ext, ok := uploadTypes[contentType]
+	if !ok {
+		return domain.Upload{}, ErrUploadTypeNotAllowed
+	}
+	_, err = r.Seek(0, io.SeekStart)
+	if err != nil {
+		return domain.Upload{}, err
+	}
+
+	_, err = s.perm.check(ctx, uid, aid, domain.CollaboratorRoleEditor)
+	if errors.Is(err, ErrArticlePermissionDenied) {
+		return domain.Upload{}, ErrUploadForbidden
+	}
+	if err != nil {
+		return domain.Upload{}, err
+	}
+
+	return s.repo.Create(ctx, domain.Upload{
+		Key:         fmt.Sprintf("article/%d/%s%s", aid, uuid.NewString(), ext),
+		ArticleId:   aid,
+		Uid:         uid,
+		Size:        size,
+		ContentType: contentType,
+	}, r)
+}
+
+func (s *ImplUploadService) GC(ctx context.Context, before time.Time) (int, error) {
+	var (
+		startId int64
+		cnt     int
+	)
+	for {
+		uploads, err := s.repo.ListBefore(ctx, before, startId, s.batchSize)
+		if err != nil {
+			return cnt, err
+		}
+		if len(uploads) == 0 {
+			return cnt, nil
+		}
+		startId = uploads[len(uploads)-1].Id
+
+		garbage := s.unreferenced(ctx, uploads)
+		if len(garbage) > 0 {
+			err = s.repo.Delete(ctx, garbage)
+			if err != nil {
+				return cnt, err
+			}
+			cnt += len(garbage)
+		}
+		if int64(len(uploads)) < s.batchSize {
+			return cnt, nil
+		}
+	}
+}
+
+// unreferenced return the uploads which are not in the content of their
+// articles, the articles failed to get are skipped
+func (s *ImplUploadService) unreferenced(ctx context.Context,
+	uploads []domain.Upload) []domain.Upload {
+	// nil content means the article is skipped
+	contents := make(map[int64][]string)
+	var res []domain.Upload
+	for _, u := range uploads {
+		content, ok := contents[u.ArticleId]
+		if !ok {
+			var err error
+			content, err = s.articleContents(ctx, u.ArticleId)
+			if err != nil && !errors.Is(err, repository.ErrArticleNotFound) {
+				s.l.Error("Failed to get article of uploads",
+					logger.Error(err),
+					logger.Int64("aid", u.ArticleId))
+			}
+			contents[u.ArticleId] = content
+		}
+		if content == nil {
+			continue
+		}
+		referenced := false
+		for _, c := range content {
+			if strings.Contains(c, u.URL) {
+				referenced = true
+				break
+			}
+		}
+		if !referenced {
+			res = append(res, u)
+		}
+	}
+	return res
+}
+
+// articleContents return the content of the draft and the published copy,
+// ErrArticleNotFound if the article is in the trash or purged
+func (s *ImplUploadService) articleContents(ctx context.Context,
+	aid int64) ([]string, error) {
+	arti, err := s.authorRepo.GetById(ctx, aid)
+	if err != nil {
+		return nil, err
+	}
+	res := []string{arti.Content}
+	pub, err := s.readerRepo.GetPubById(ctx, aid)
+	switch {
+	case err == nil:
+		res = append(res, pub.Content)
+	case errors.Is(err, repository.ErrArticleNotFound):
+	default:
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"testing"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+	repomocks "webook/webook/internal/repository/mocks"
+	"webook/webook/pkg/logger"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n0000")
+
+func TestImplUploadService_Upload(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (repository.UploadRepository,
+			repository.ArticleAuthorRepository, repository.CollaboratorRepository)
+
+		content []byte
+		size    int64
+
+		wantUpload domain.Upload
+		wantErr    error
+	}{
+		{
+			name: "success",
+			mock: func(ctrl *gomock.Controller) (repository.UploadRepository,
+				repository.ArticleAuthorRepository, repository.CollaboratorRepository) {
+				repo := repomocks.NewMockUploadRepository(ctrl)
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 123}}, nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, u domain.Upload,
+						r io.ReadSeeker) (domain.Upload, error) {
+						// The reader is rewound after sniffing
+						data, err := io.ReadAll(r)
+						assert.NoError(t, err)
+						assert.Equal(t, pngHeader, data)
+						assert.Regexp(t, `^article/1/[0-9a-f-]{36}\.png$`, u.Key)
+						u.URL = "/uploads/" + u.Key
+						return u, nil
+					})
+				return repo, authorRepo, nil
+			},
+			content: pngHeader,
+			size:    int64(len(pngHeader)),
+			wantUpload: domain.Upload{
+				ArticleId:   1,
+				Uid:         123,
+				Size:        int64(len(pngHeader)),
+				ContentType: "image/png",
+			},
+		},
+		{
+			name: "too large",
+			mock: func(ctrl *gomock.Controller) (repository.UploadRepository,
+				repository.ArticleAuthorRepository, repository.CollaboratorRepository) {
+				return nil, nil, nil
+			},
+			content: pngHeader,
+			size:    1025,
+			wantErr: ErrUploadTooLarge,
+		},
+		{
+			name: "type not allowed",
+			mock: func(ctrl *gomock.Controller) (repository.UploadRepository,
+				repository.ArticleAuthorRepository, repository.CollaboratorRepository) {
+				return nil, nil, nil
+			},
+			content: []byte("<html><script>alert(1)</script></html>"),
+			size:    38,
+			wantErr: ErrUploadTypeNotAllowed,
+		},
+		{
+			name: "editor",
+			mock: func(ctrl *gomock.Controller) (repository.UploadRepository,
+				repository.ArticleAuthorRepository, repository.CollaboratorRepository) {
+				repo := repomocks.NewMockUploadRepository(ctrl)
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				collabRepo := repomocks.NewMockCollaboratorRepository(ctrl)
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 234}}, nil)
+				collabRepo.EXPECT().GetRole(gomock.Any(), int64(1), int64(123)).
+					Return(domain.CollaboratorRoleEditor, nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, u domain.Upload,
+						r io.ReadSeeker) (domain.Upload, error) {
+						return u, nil
+					})
+				return repo, authorRepo, collabRepo
+			},
+			content: pngHeader,
+			size:    int64(len(pngHeader)),
+			wantUpload: domain.Upload{
+				ArticleId:   1,
+				Uid:         123,
+				Size:        int64(len(pngHeader)),
+				ContentType: "image/png",
+			},
+		},
+		{
+			name: "viewer",
+			mock: func(ctrl *gomock.Controller) (repository.UploadRepository,
+				repository.ArticleAuthorRepository, repository.CollaboratorRepository) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				collabRepo := repomocks.NewMockCollaboratorRepository(ctrl)
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 234}}, nil)
+				collabRepo.EXPECT().GetRole(gomock.Any(), int64(1), int64(123)).
+					Return(domain.CollaboratorRoleViewer, nil)
+				return nil, authorRepo, collabRepo
+			},
+			content: pngHeader,
+			size:    int64(len(pngHeader)),
+			wantErr: ErrUploadForbidden,
+		},
+		{
+			name: "article not found",
+			mock: func(ctrl *gomock.Controller) (repository.UploadRepository,
+				repository.ArticleAuthorRepository, repository.CollaboratorRepository) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				authorRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{}, repository.ErrArticleNotFound)
+				return nil, authorRepo, nil
+			},
+			content: pngHeader,
+			size:    int64(len(pngHeader)),
+			wantErr: ErrUploadForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, authorRepo, collabRepo := tc.mock(ctrl)
+			svc := NewImplUploadService(repo, authorRepo, nil, collabRepo,
+				1024, logger.NewNopLogger())
+			u, err := svc.Upload(context.Background(), 123, 1, tc.size,
+				bytes.NewReader(tc.content))
+			assert.Equal(t, tc.wantErr, err)
+			// The key is random
+			u.Key, u.URL = "", ""
+			assert.Equal(t, tc.wantUpload, u)
+		})
+	}
+}
+
+func TestImplUploadService_GC(t *testing.T) {
+	before := time.UnixMilli(1700000000000)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockUploadRepository(ctrl)
+	authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+	readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
+
+	uploads := []domain.Upload{
+		// Referred by the draft
+		{Id: 1, ArticleId: 1, URL: "/uploads/a.png"},
+		// Referred by the published copy
+		{Id: 2, ArticleId: 1, URL: "/uploads/b.png"},
+		// Referred by none
+		{Id: 3, ArticleId: 1, URL: "/uploads/c.png"},
+		// The article is in the trash
+		{Id: 4, ArticleId: 2, URL: "/uploads/d.png"},
+	}
+	repo.EXPECT().ListBefore(gomock.Any(), before, int64(0), int64(100)).
+		Return(uploads, nil)
+	authorRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+		Return(domain.Article{Id: 1, Content: "![](/uploads/a.png)"}, nil)
+	readerRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).
+		Return(domain.Article{Id: 1, Content: "![](/uploads/b.png)"}, nil)
+	authorRepo.EXPECT().GetById(gomock.Any(), int64(2)).
+		Return(domain.Article{}, repository.ErrArticleNotFound)
+	repo.EXPECT().Delete(gomock.Any(), []domain.Upload{uploads[2]}).Return(nil)
+
+	svc := NewImplUploadService(repo, authorRepo, readerRepo, nil,
+		1024, logger.NewNopLogger())
+	cnt, err := svc.GC(context.Background(), before)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cnt)
+}
@@ -1,10 +1,15 @@
 package web
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 	"webook/webook/internal/domain"
 	"webook/webook/internal/errs"
 	"webook/webook/internal/service"
@@ -21,14 +26,27 @@ type ArticleHandler struct {
 	interSvc   service.InteractiveService
 	rankSvc    service.RankingService
 	commentSvc service.CommentService
+	searchSvc  service.SearchService
+	trashSvc   service.ArticleTrashService
+	seriesSvc  service.SeriesService
 
 	l   logger.Logger
 	biz string
 }
 
+const (
+	maxTagCnt = 10
+	maxTagLen = 64
+
+	maxSearchLimit = 50
+)
+
 type Page struct {
 	Limit  int64
 	Offset int64
+	// Cursor switch to the cursor mode if it's present, "" is the first page.
+	// The response is ArticleListVo in the cursor mode
+	Cursor *string `json:"cursor"`
 }
 
 type CreateCommentReq struct {
@@ -39,12 +57,18 @@ func NewArticleHandler(l logger.Logger,
 	svc service.ArticleService,
 	intersvc service.InteractiveService,
 	ranksvc service.RankingService,
-	commentSvc service.CommentService) *ArticleHandler {
+	commentSvc service.CommentService,
+	searchSvc service.SearchService,
+	trashSvc service.ArticleTrashService,
+	seriesSvc service.SeriesService) *ArticleHandler {
 	return &ArticleHandler{
 		svc:        svc,
 		interSvc:   intersvc,
 		rankSvc:    ranksvc,
 		commentSvc: commentSvc,
+		searchSvc:  searchSvc,
+		trashSvc:   trashSvc,
+		seriesSvc:  seriesSvc,
 		l:          l,
 		biz:        "article",
 	}
@@ -56,6 +80,11 @@ func (h *ArticleHandler) RegisterRoutes(server *gin.Engine) {
 	g.POST("/edit", ginx.WrapBodyAndClaims(h.Edit))
 	g.POST("/publish", ginx.WrapBodyAndClaims(h.Publish))
 	g.POST("/withdraw", h.Withdraw)
+	g.POST("/schedule/cancel", ginx.WrapBodyAndClaims(h.CancelSchedule))
+	g.POST("/delete", ginx.WrapBodyAndClaims(h.Delete))
+
+	g.POST("/trash/list", ginx.WrapBodyAndClaims(h.TrashList))
+	g.POST("/trash/restore", ginx.WrapBodyAndClaims(h.Restore))
 
 	g.GET("/detail/:id", h.Detail)
 	g.POST("/list", h.List)
@@ -64,9 +93,17 @@ func (h *ArticleHandler) RegisterRoutes(server *gin.Engine) {
 	g.POST("/pub/like", h.Like)
 	g.POST("/pub/collect", h.Collect)
 	g.POST("/pub/list", h.PubList)
+	g.POST("/pub/tag/list", ginx.WrapBody(h.PubListByTag))
+	g.GET("/pub/tags/popular", h.PopularTags)
+	g.GET("/pub/search", h.Search)
 
 	g.GET("/pub/top", h.Top)
 
+	// 历史版本相关路由
+	g.GET("/:id/revisions", ginx.WrapClaims(h.ListRevisions))
+	g.GET("/:id/revisions/diff", ginx.WrapClaims(h.DiffRevisions))
+	g.POST("/:id/revisions/:rid/restore", ginx.WrapClaims(h.RestoreRevision))
+
 	// 评论相关路由
 	g.POST("/:id/comment", h.CreateComment)
 	g.GET("/:id/comments", h.ListComments)
@@ -74,15 +111,32 @@ func (h *ArticleHandler) RegisterRoutes(server *gin.Engine) {
 }
 
 func (h *ArticleHandler) Edit(ctx *gin.Context, req EditReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	if !validTags(req.Tags) {
+		return ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Invalid tags",
+		}, nil
+	}
 
 	id, err := h.svc.Save(ctx, domain.Article{
 		Id:      req.Id,
 		Title:   req.Title,
 		Content: req.Content,
+		Tags:    req.Tags,
+		Version: req.Version,
 		Author: domain.Author{
 			Id: uc.Uid,
 		},
 	})
+	if errors.Is(err, service.ErrArticleVersionConflict) {
+		return h.versionConflict(ctx, req.Id, uc.Uid)
+	}
+	if errors.Is(err, service.ErrArticlePermissionDenied) {
+		return ginx.Result{
+			Code: errs.ArticlePermissionDenied,
+			Msg:  "Permission denied",
+		}, nil
+	}
 	if err != nil {
 		return ginx.Result{
 			Code: errs.ArticleInternalServerError,
@@ -97,14 +151,53 @@ func (h *ArticleHandler) Edit(ctx *gin.Context, req EditReq, uc ijwt.UserClaims)
 }
 
 func (h *ArticleHandler) Publish(ctx *gin.Context, req PublishReq, uc ijwt.UserClaims) (ginx.Result, error) {
-	id, err := h.svc.Publish(ctx, domain.Article{
+	if !validTags(req.Tags) {
+		return ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Invalid tags",
+		}, nil
+	}
+
+	arti := domain.Article{
 		Id:      req.Id,
 		Title:   req.Title,
 		Content: req.Content,
+		Tags:    req.Tags,
+		Version: req.Version,
 		Author: domain.Author{
 			Id: uc.Uid,
 		},
-	})
+	}
+	if req.PublishAt > 0 {
+		arti.PublishAt = time.UnixMilli(req.PublishAt)
+	}
+	if req.Unlisted {
+		arti.Status = domain.ArticleStatusUnlisted
+	}
+	id, err := h.svc.Publish(ctx, arti)
+	if errors.Is(err, service.ErrArticleVersionConflict) {
+		return h.versionConflict(ctx, req.Id, uc.Uid)
+	}
+	if errors.Is(err, service.ErrArticlePermissionDenied) {
+		return ginx.Result{
+			Code: errs.ArticlePermissionDenied,
+			Msg:  "Permission denied",
+		}, nil
+	}
+	if errors.Is(err, service.ErrContentRejected) {
+		return ginx.Result{
+			Code: errs.ArticleContentRejected,
+			Msg:  "Content rejected",
+		}, nil
+	}
+	if errors.Is(err, service.ErrContentUnderReview) {
+		// Saved as a draft, it's published after the review
+		return ginx.Result{
+			Code: errs.ArticleContentUnderReview,
+			Msg:  "Content under review",
+			Data: id,
+		}, nil
+	}
 	if err != nil {
 		return ginx.Result{
 			Code: errs.ArticleInternalServerError,
@@ -117,6 +210,95 @@ func (h *ArticleHandler) Publish(ctx *gin.Context, req PublishReq, uc ijwt.UserC
 	}, nil
 }
 
+// versionConflict return the current server copy,
+// so the editor can merge it with the local one
+func (h *ArticleHandler) versionConflict(ctx *gin.Context,
+	id int64, uid int64) (ginx.Result, error) {
+	arti, err := h.svc.GetById(ctx, uid, id)
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("failed to get article on version conflict: %w", err)
+	}
+	return ginx.Result{
+		Code: errs.ArticleVersionConflict,
+		Msg:  "Article has been modified",
+		Data: toContentVo(arti, domain.InteractiveCount{}),
+	}, nil
+}
+
+func (h *ArticleHandler) CancelSchedule(ctx *gin.Context, req CancelScheduleReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	err := h.svc.CancelSchedule(ctx, uc.Uid, req.Id)
+	switch err {
+	case nil:
+		return ginx.Result{
+			Msg: "OK",
+		}, nil
+	case service.ErrArticleNotScheduled:
+		return ginx.Result{
+			Code: errs.ArticleNotScheduled,
+			Msg:  "Article is not scheduled",
+		}, nil
+	case service.ErrArticlePermissionDenied:
+		return ginx.Result{
+			Code: errs.ArticlePermissionDenied,
+			Msg:  "Permission denied",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("failed to cancel scheduled article: %w", err)
+	}
+}
+
+func (h *ArticleHandler) Delete(ctx *gin.Context, req DeleteReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	err := h.trashSvc.Delete(ctx, uc.Uid, req.Id)
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("failed to delete article: %w", err)
+	}
+	return ginx.Result{
+		Msg: "OK",
+	}, nil
+}
+
+func (h *ArticleHandler) TrashList(ctx *gin.Context, page Page, uc ijwt.UserClaims) (ginx.Result, error) {
+	artis, err := h.trashSvc.List(ctx, uc.Uid, page.Offset, page.Limit)
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("failed to list trash: %w", err)
+	}
+	return ginx.Result{
+		Data: toAbstractVos(artis, make(map[int64]domain.InteractiveCount)),
+	}, nil
+}
+
+func (h *ArticleHandler) Restore(ctx *gin.Context, req RestoreReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	err := h.trashSvc.Restore(ctx, uc.Uid, req.Id)
+	switch err {
+	case nil:
+		return ginx.Result{
+			Msg: "OK",
+		}, nil
+	case service.ErrArticleNotInTrash:
+		return ginx.Result{
+			Code: errs.ArticleNotInTrash,
+			Msg:  "Article is not in the trash or has expired",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("failed to restore article: %w", err)
+	}
+}
+
 func (h *ArticleHandler) Withdraw(ctx *gin.Context) {
 	type Req struct {
 		Id int64 `json:"id"`
@@ -129,6 +311,13 @@ func (h *ArticleHandler) Withdraw(ctx *gin.Context) {
 	uc := ctx.MustGet("userclaim").(ijwt.UserClaims)
 
 	err := h.svc.Withdraw(ctx, uc.Uid, req.Id)
+	if errors.Is(err, service.ErrArticlePermissionDenied) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticlePermissionDenied,
+			Msg:  "Permission denied",
+		})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, ginx.Result{
 			Code: 0,
@@ -150,6 +339,11 @@ func (h *ArticleHandler) List(ctx *gin.Context) {
 	}
 	uc := ctx.MustGet("userclaim").(ijwt.UserClaims)
 
+	if page.Cursor != nil {
+		h.listAfter(ctx, uc.Uid, *page.Cursor, page.Limit)
+		return
+	}
+
 	artis, err := h.svc.GetByAuthor(ctx, uc.Uid, page.Offset, page.Limit)
 	if err != nil {
 		ctx.JSON(http.StatusOK, ginx.Result{
@@ -166,42 +360,69 @@ func (h *ArticleHandler) List(ctx *gin.Context) {
 	})
 }
 
-func (h *ArticleHandler) Detail(ctx *gin.Context) {
-	// Get id from path
-	idstr := ctx.Param("id")
-	id, err := strconv.ParseInt(idstr, 10, 64)
+func (h *ArticleHandler) listAfter(ctx *gin.Context, uid int64,
+	cursorStr string, limit int64) {
+	cursor, err := decodeCursorPage(cursorStr, limit)
 	if err != nil {
 		ctx.JSON(http.StatusOK, ginx.Result{
 			Code: 4,
-			Msg:  "Invalid parameter: id",
+			Msg:  "Invalid parameter: " + err.Error(),
 		})
 		return
 	}
 
-	// Get article by id
-	arti, err := h.svc.GetById(ctx, id)
+	artis, err := h.svc.GetByAuthorAfter(ctx, uid, cursor, limit)
 	if err != nil {
 		ctx.JSON(http.StatusOK, ginx.Result{
 			Code: 5,
 			Msg:  "System Error",
-			Data: nil,
 		})
-		h.l.Error("Failed to get article", logger.Error(err))
+		h.l.Error("Failed to get article list", logger.Error(err))
 		return
 	}
 
-	// Check if the id is right
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: ArticleListVo{
+			Items:      toAbstractVos(artis, make(map[int64]domain.InteractiveCount)),
+			NextCursor: nextArticleCursor(artis, limit),
+		},
+	})
+}
+
+func (h *ArticleHandler) Detail(ctx *gin.Context) {
+	// Get id from path
+	idstr := ctx.Param("id")
+	id, err := strconv.ParseInt(idstr, 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "Invalid parameter: id",
+		})
+		return
+	}
+
+	// Get article by id, the owner and the collaborators can read the draft
 	uc := ctx.MustGet("userclaim").(ijwt.UserClaims)
-	if arti.Author.Id != uc.Uid {
+	arti, err := h.svc.GetById(ctx, uc.Uid, id)
+	if errors.Is(err, service.ErrArticlePermissionDenied) {
 		ctx.JSON(http.StatusOK, ginx.Result{
-			Msg:  "System Error",
-			Code: 5,
+			Code: errs.ArticlePermissionDenied,
+			Msg:  "Permission denied",
 		})
-		h.l.Error("invalid article id",
+		h.l.Warn("no permission on article",
 			logger.Int64("id", id),
 			logger.Int64("uid", uc.Uid))
 		return
 	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "System Error",
+			Data: nil,
+		})
+		h.l.Error("Failed to get article", logger.Error(err))
+		return
+	}
 
 	ctx.JSON(http.StatusOK, ginx.Result{
 		Data: toContentVo(arti, domain.InteractiveCount{}),
@@ -224,23 +445,50 @@ func (h *ArticleHandler) PubDetail(ctx *gin.Context) {
 		eg    errgroup.Group
 		arti  domain.Article
 		inter domain.InteractiveCount
+		nav   *SeriesNavVo
 	)
 
-	uc := ctx.MustGet("userclaim").(ijwt.UserClaims)
+	// The detail is public, uid 0 is the anonymous reader
+	var uid int64
+	if uc, ok := ctx.Get("userclaim"); ok {
+		uid = uc.(ijwt.UserClaims).Uid
+	}
 
 	eg.Go(func() error {
 		var er error
-		arti, er = h.svc.GetPubById(ctx, uc.Uid, id)
+		arti, er = h.svc.GetPubById(ctx, uid, id)
 		return er
 	})
 
 	eg.Go(func() error {
 		var er error
-		inter, er = h.interSvc.Get(ctx, h.biz, id, uc.Uid)
+		inter, er = h.interSvc.Get(ctx, h.biz, id, uid)
 		return er
 	})
 
+	eg.Go(func() error {
+		// The series is optional, the detail is returned without it
+		res, er := h.seriesSvc.Nav(ctx, id)
+		switch {
+		case er == nil:
+			nav = toSeriesNavVo(res)
+		case errors.Is(er, service.ErrSeriesNotFound):
+		default:
+			h.l.Error("Failed to get series of article",
+				logger.Error(er),
+				logger.Int64("id", id))
+		}
+		return nil
+	})
+
 	err = eg.Wait()
+	if errors.Is(err, service.ErrArticleNotFound) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleNotFound,
+			Msg:  "Article not found",
+		})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, ginx.Result{
 			Code: 5,
@@ -251,12 +499,19 @@ func (h *ArticleHandler) PubDetail(ctx *gin.Context) {
 		return
 	}
 
-	// Increase view count
-	err = h.interSvc.IncreaseViewCount(ctx, h.biz, id)
+	// The raw page view is counted by the consumer of the read event
+	err = h.interSvc.RecordUniqueView(ctx, h.biz, id, visitor(ctx, uid))
+	if err != nil {
+		h.l.Error("Failed to record unique view",
+			logger.Error(err),
+			logger.Int64("id", id))
+	}
 
 	// Return article
+	vo := toContentVo(arti, inter)
+	vo.Series = nav
 	ctx.JSON(http.StatusOK, ginx.Result{
-		Data: toContentVo(arti, inter),
+		Data: vo,
 		Msg:  "OK",
 	})
 }
@@ -269,11 +524,27 @@ func (h *ArticleHandler) PubList(ctx *gin.Context) {
 	}
 
 	var (
-		artis    []domain.Article
-		interMap map[int64]domain.InteractiveCount
+		artis      []domain.Article
+		interMap   map[int64]domain.InteractiveCount
+		nextCursor string
+		err        error
 	)
 
-	artis, err := h.svc.ListPub(ctx, time.Now(), page.Offset, page.Limit)
+	if page.Cursor != nil {
+		var cursor domain.Cursor
+		cursor, err = decodeCursorPage(*page.Cursor, page.Limit)
+		if err != nil {
+			ctx.JSON(http.StatusOK, ginx.Result{
+				Code: 4,
+				Msg:  "Invalid parameter: " + err.Error(),
+			})
+			return
+		}
+		artis, err = h.svc.ListPubAfter(ctx, cursor, page.Limit)
+		nextCursor = nextArticleCursor(artis, page.Limit)
+	} else {
+		artis, err = h.svc.ListPub(ctx, time.Now(), page.Offset, page.Limit)
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, ginx.Result{
 			Code: 5,
@@ -284,7 +555,8 @@ func (h *ArticleHandler) PubList(ctx *gin.Context) {
 		return
 	}
 
-	interMap, err = h.interSvc.GetByIds(ctx, h.biz, domain.ArticleList(artis).Ids())
+	uc := ctx.MustGet("userclaim").(ijwt.UserClaims)
+	interMap, err = h.interSvc.GetByIds(ctx, h.biz, domain.ArticleList(artis).Ids(), uc.Uid)
 
 	if err != nil {
 		ctx.JSON(http.StatusOK, ginx.Result{
@@ -297,16 +569,130 @@ func (h *ArticleHandler) PubList(ctx *gin.Context) {
 	}
 
 	// Return article
+	if page.Cursor != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Data: ArticleListVo{
+				Items:      toAbstractVos(artis, interMap),
+				NextCursor: nextCursor,
+			},
+			Msg: "OK",
+		})
+		return
+	}
 	ctx.JSON(http.StatusOK, ginx.Result{
 		Data: toAbstractVos(artis, interMap),
 		Msg:  "OK",
 	})
 }
 
+func (h *ArticleHandler) PubListByTag(ctx *gin.Context, req TagPage) (ginx.Result, error) {
+	artis, err := h.svc.ListPubByTag(ctx, req.Tag, time.Now(), req.Offset, req.Limit)
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("failed to list article by tag: %w", err)
+	}
+
+	uc := ctx.MustGet("userclaim").(ijwt.UserClaims)
+	interMap, err := h.interSvc.GetByIds(ctx, h.biz, domain.ArticleList(artis).Ids(), uc.Uid)
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("failed to get interactive data: %w", err)
+	}
+
+	return ginx.Result{
+		Data: toAbstractVos(artis, interMap),
+		Msg:  "OK",
+	}, nil
+}
+
+func (h *ArticleHandler) PopularTags(ctx *gin.Context) {
+	limit, err := strconv.ParseInt(ctx.DefaultQuery("limit", "20"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Invalid parameter: limit",
+		})
+		return
+	}
+
+	tags, err := h.svc.ListPopularTags(ctx, limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		})
+		h.l.Error("Failed to get popular tags", logger.Error(err))
+		return
+	}
+
+	vos := make([]TagVo, 0, len(tags))
+	for _, tag := range tags {
+		vos = append(vos, TagVo{
+			Name:       tag.Name,
+			ArticleCnt: tag.ArticleCnt,
+		})
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: vos,
+		Msg:  "OK",
+	})
+}
+
+func (h *ArticleHandler) Search(ctx *gin.Context) {
+	query := strings.TrimSpace(ctx.Query("q"))
+	offset, err1 := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	limit, err2 := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if query == "" || err1 != nil || err2 != nil ||
+		offset < 0 || limit <= 0 || limit > maxSearchLimit {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Invalid parameter",
+		})
+		return
+	}
+
+	res, err := h.searchSvc.SearchArticle(ctx, query, offset, limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		})
+		h.l.Error("Failed to search article",
+			logger.Error(err),
+			logger.String("query", query))
+		return
+	}
+
+	vos := make([]ArticleSearchHitVo, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		vos = append(vos, ArticleSearchHitVo{
+			Id:       hit.Id,
+			Title:    hit.Title,
+			Snippet:  hit.Snippet,
+			Score:    hit.Score,
+			AuthorId: hit.Author.Id,
+		})
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: ArticleSearchVo{
+			Total: res.Total,
+			Items: vos,
+		},
+		Msg: "OK",
+	})
+}
+
 func (h *ArticleHandler) Like(ctx *gin.Context) {
 	type Req struct {
 		Id   int64 `json:"id"`
 		Like bool  `json:"like"`
+		// Reaction is "like" if it's empty, a different one replaces
+		// the reaction before
+		Reaction string `json:"reaction"`
 	}
 	var req Req
 	var err error
@@ -315,12 +701,22 @@ func (h *ArticleHandler) Like(ctx *gin.Context) {
 	}
 	uc := ctx.MustGet("userclaim").(ijwt.UserClaims)
 
+	// Liking again or canceling a like not there is OK,
+	// the counts only change once
+	var inter domain.InteractiveCount
 	if req.Like {
-		err = h.interSvc.Like(ctx, h.biz, req.Id, uc.Uid)
+		inter, err = h.interSvc.Like(ctx, h.biz, req.Id, uc.Uid, req.Reaction)
 	} else {
-		err = h.interSvc.CancelLike(ctx, h.biz, req.Id, uc.Uid)
+		inter, err = h.interSvc.CancelLike(ctx, h.biz, req.Id, uc.Uid)
 	}
 
+	if errors.Is(err, service.ErrInvalidReaction) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Invalid reaction",
+		})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, ginx.Result{
 			Code: 5,
@@ -331,7 +727,8 @@ func (h *ArticleHandler) Like(ctx *gin.Context) {
 	}
 
 	ctx.JSON(http.StatusOK, ginx.Result{
-		Msg: "OK",
+		Msg:  "OK",
+		Data: h.toInteractiveStateVo(inter),
 	})
 }
 
@@ -350,13 +747,21 @@ func (h *ArticleHandler) Collect(ctx *gin.Context) {
 
 	uc := ctx.MustGet("userclaim").(ijwt.UserClaims)
 
+	var inter domain.InteractiveCount
 	var err error
 	if req.Collect {
-		err = h.interSvc.Collect(ctx, h.biz, req.Id, req.Cid, uc.Uid)
+		inter, err = h.interSvc.Collect(ctx, h.biz, req.Id, req.Cid, uc.Uid)
 	} else {
-		err = h.interSvc.CancelCollect(ctx, h.biz, req.Id, uc.Uid)
+		inter, err = h.interSvc.CancelCollect(ctx, h.biz, req.Id, uc.Uid)
 	}
 
+	if errors.Is(err, service.ErrCollectionNotFound) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleCollectionNotFound,
+			Msg:  "Collection not found",
+		})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, ginx.Result{
 			Code: 5,
@@ -366,10 +771,22 @@ func (h *ArticleHandler) Collect(ctx *gin.Context) {
 		return
 	}
 	ctx.JSON(http.StatusOK, ginx.Result{
-		Msg: "OK",
+		Msg:  "OK",
+		Data: h.toInteractiveStateVo(inter),
 	})
 }
 
+func (h *ArticleHandler) toInteractiveStateVo(inter domain.InteractiveCount) InteractiveStateVo {
+	return InteractiveStateVo{
+		LikeCnt:    inter.LikeCnt,
+		Reactions:  inter.Reactions,
+		CollectCnt: inter.CollectCnt,
+		Liked:      inter.Liked,
+		Reaction:   inter.Reaction,
+		Collected:  inter.Collected,
+	}
+}
+
 func (h *ArticleHandler) Top(ctx *gin.Context) {
 	// 获取排行榜文章
 	articles, err := h.rankSvc.GetTopN(ctx)
@@ -398,7 +815,8 @@ func (h *ArticleHandler) Top(ctx *gin.Context) {
 	}
 
 	// 获取互动数据
-	interMap, err := h.interSvc.GetByIds(ctx, h.biz, articleIds)
+	uc := ctx.MustGet("userclaim").(ijwt.UserClaims)
+	interMap, err := h.interSvc.GetByIds(ctx, h.biz, articleIds, uc.Uid)
 	if err != nil {
 		ctx.JSON(http.StatusOK, ginx.Result{
 			Code: 5,
@@ -454,22 +872,34 @@ func _tovo(article domain.Article, inter domain.InteractiveCount, isAbstract boo
 		AuthorId:   article.Author.Id,
 		AuthorName: article.Author.Name,
 		Status:     uint8(article.Status),
+		Tags:       article.Tags,
+		Version:    article.Version,
 
 		// interactive field
-		ViewCnt:    inter.ViewCnt,
-		LikeCnt:    inter.LikeCnt,
-		CollectCnt: inter.CollectCnt,
-		Liked:      inter.Liked,
-		Collected:  inter.Collected,
+		ViewCnt:       inter.ViewCnt,
+		UniqueViewCnt: inter.UniqueViewCnt,
+		LikeCnt:       inter.LikeCnt,
+		Reactions:     inter.Reactions,
+		CollectCnt:    inter.CollectCnt,
+		Liked:         inter.Liked,
+		Reaction:      inter.Reaction,
+		Collected:     inter.Collected,
 
 		// Format time to string
 		Ctime: article.Ctime.Format(time.DateTime),
 		Utime: article.Utime.Format(time.DateTime),
 	}
+	if !article.PublishAt.IsZero() {
+		vo.PublishAt = article.PublishAt.Format(time.DateTime)
+	}
+	if !article.DeletedAt.IsZero() {
+		vo.DeletedAt = article.DeletedAt.Format(time.DateTime)
+	}
 	if isAbstract {
 		vo.Abstract = article.Abstract()
 	} else {
 		vo.Content = article.Content
+		vo.HTML = article.HTML
 	}
 	return vo
 }
@@ -498,7 +928,20 @@ func (h *ArticleHandler) CreateComment(ctx *gin.Context) {
 			Id: uc.Uid,
 		},
 	})
-	if err != nil {
+	switch {
+	case errors.Is(err, service.ErrContentRejected):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleContentRejected,
+			Msg:  "评论包含违规内容",
+		})
+		return
+	case errors.Is(err, service.ErrContentUnderReview):
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleContentUnderReview,
+			Msg:  "评论审核中",
+		})
+		return
+	case err != nil:
 		ctx.JSON(http.StatusOK, ginx.Result{
 			Code: 5,
 			Msg:  "系统错误",
@@ -523,6 +966,12 @@ func (h *ArticleHandler) ListComments(ctx *gin.Context) {
 		return
 	}
 
+	// Cursor mode, the response is CommentListVo
+	if cursorStr, ok := ctx.GetQuery("cursor"); ok {
+		h.listCommentsAfter(ctx, articleId, cursorStr)
+		return
+	}
+
 	offsetStr := ctx.Query("offset")
 	offset, err := strconv.ParseInt(offsetStr, 10, 64)
 	if err != nil {
@@ -556,6 +1005,43 @@ func (h *ArticleHandler) ListComments(ctx *gin.Context) {
 	})
 }
 
+func (h *ArticleHandler) listCommentsAfter(ctx *gin.Context,
+	articleId int64, cursorStr string) {
+	limit, err := strconv.ParseInt(ctx.Query("limit"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "参数错误: " + err.Error(),
+		})
+		return
+	}
+	cursor, err := decodeCursorPage(cursorStr, limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "参数错误: " + err.Error(),
+		})
+		return
+	}
+
+	comments, err := h.commentSvc.GetByArticleIdAfter(ctx, articleId, cursor, limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("获取评论列表失败", logger.Error(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: CommentListVo{
+			Items:      comments,
+			NextCursor: nextCommentCursor(comments, limit),
+		},
+	})
+}
+
 func (h *ArticleHandler) DeleteComment(ctx *gin.Context) {
 	commentIdStr := ctx.Param("commentId")
 	commentId, err := strconv.ParseInt(commentIdStr, 10, 64)
@@ -582,3 +1068,174 @@ func (h *ArticleHandler) DeleteComment(ctx *gin.Context) {
 		Msg: "OK",
 	})
 }
+
+func (h *ArticleHandler) ListRevisions(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	aid, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Invalid parameter: id",
+		}, nil
+	}
+	offset, _ := strconv.ParseInt(ctx.DefaultQuery("offset", "0"), 10, 64)
+	limit, _ := strconv.ParseInt(ctx.DefaultQuery("limit", "20"), 10, 64)
+
+	revs, err := h.svc.ListRevisions(ctx, uc.Uid, aid, offset, limit)
+	if errors.Is(err, service.ErrArticlePermissionDenied) {
+		return ginx.Result{
+			Code: errs.ArticlePermissionDenied,
+			Msg:  "Permission denied",
+		}, nil
+	}
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("failed to list article revisions: %w", err)
+	}
+
+	vos := make([]ArticleRevisionVo, 0, len(revs))
+	for _, rev := range revs {
+		vos = append(vos, toRevisionVo(rev))
+	}
+	return ginx.Result{
+		Data: vos,
+	}, nil
+}
+
+func (h *ArticleHandler) DiffRevisions(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	aid, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Invalid parameter: id",
+		}, nil
+	}
+	from, err := strconv.ParseInt(ctx.Query("from"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Invalid parameter: from",
+		}, nil
+	}
+	to, err := strconv.ParseInt(ctx.Query("to"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Invalid parameter: to",
+		}, nil
+	}
+
+	diff, err := h.svc.DiffRevisions(ctx, uc.Uid, aid, from, to)
+	switch err {
+	case nil:
+	case service.ErrArticleRevisionNotFound:
+		return ginx.Result{
+			Code: errs.ArticleRevisionNotFound,
+			Msg:  "Revision not found",
+		}, nil
+	case service.ErrArticleDiffTooLarge:
+		return ginx.Result{
+			Code: errs.ArticleDiffTooLarge,
+			Msg:  "Too large to diff",
+		}, nil
+	case service.ErrArticlePermissionDenied:
+		return ginx.Result{
+			Code: errs.ArticlePermissionDenied,
+			Msg:  "Permission denied",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("failed to diff article revisions: %w", err)
+	}
+
+	vo := ArticleRevisionDiffVo{
+		From:  toRevisionVo(diff.From),
+		To:    toRevisionVo(diff.To),
+		Lines: make([]DiffLineVo, 0, len(diff.Lines)),
+	}
+	for _, line := range diff.Lines {
+		vo.Lines = append(vo.Lines, DiffLineVo{
+			Op:   line.Op,
+			Text: line.Text,
+		})
+	}
+	return ginx.Result{
+		Data: vo,
+	}, nil
+}
+
+func (h *ArticleHandler) RestoreRevision(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	aid, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Invalid parameter: id",
+		}, nil
+	}
+	rid, err := strconv.ParseInt(ctx.Param("rid"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Invalid parameter: rid",
+		}, nil
+	}
+
+	id, err := h.svc.RestoreRevision(ctx, uc.Uid, aid, rid)
+	switch err {
+	case nil:
+		return ginx.Result{
+			Data: id,
+		}, nil
+	case service.ErrArticleRevisionNotFound:
+		return ginx.Result{
+			Code: errs.ArticleRevisionNotFound,
+			Msg:  "Revision not found",
+		}, nil
+	case service.ErrArticlePermissionDenied:
+		return ginx.Result{
+			Code: errs.ArticlePermissionDenied,
+			Msg:  "Permission denied",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("failed to restore article revision: %w", err)
+	}
+}
+
+// visitor identify the reader for the unique views, the user if logged in,
+// otherwise a fingerprint of the client
+func visitor(ctx *gin.Context, uid int64) string {
+	if uid > 0 {
+		return "u:" + strconv.FormatInt(uid, 10)
+	}
+	sum := sha256.Sum256([]byte(ctx.ClientIP() + "|" + ctx.GetHeader("User-Agent")))
+	return "a:" + hex.EncodeToString(sum[:8])
+}
+
+func validTags(tags []string) bool {
+	if len(tags) > maxTagCnt {
+		return false
+	}
+	for _, tag := range tags {
+		if utf8.RuneCountInString(tag) > maxTagLen {
+			return false
+		}
+	}
+	return true
+}
+
+func toRevisionVo(rev domain.ArticleRevision) ArticleRevisionVo {
+	return ArticleRevisionVo{
+		Id:        rev.Id,
+		ArticleId: rev.ArticleId,
+		Title:     rev.Title,
+		Abstract:  domain.Article{Content: rev.Content}.Abstract(),
+		Status:    uint8(rev.Status),
+		Ctime:     rev.Ctime.Format(time.DateTime),
+	}
+}
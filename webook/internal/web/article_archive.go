@@ -0,0 +1,129 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"webook/webook/internal/errs"
+	"webook/webook/internal/service"
+	ijwt "webook/webook/internal/web/jwt"
+	"webook/webook/pkg/ginx"
+	"webook/webook/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportBody is the hard limit of the imported archive
+const maxImportBody = 32 << 20
+
+type ArticleArchiveHandler struct {
+	svc service.ArticleArchiveService
+	l   logger.Logger
+}
+
+func NewArticleArchiveHandler(l logger.Logger, svc service.ArticleArchiveService) *ArticleArchiveHandler {
+	return &ArticleArchiveHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *ArticleArchiveHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/article")
+	g.GET("/export", h.Export)
+	g.POST("/import", ginx.WrapClaims(h.Import))
+}
+
+// Export stream the zip of the articles, the response can't be changed
+// once the first file is written, so a later failure cuts the zip short
+func (h *ArticleArchiveHandler) Export(ctx *gin.Context) {
+	uc := ctx.MustGet("userclaim").(ijwt.UserClaims)
+	name := fmt.Sprintf("webook-articles-%s.zip", time.Now().Format("20060102"))
+	ctx.Header("Content-Type", "application/zip")
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+
+	err := h.svc.Export(ctx, uc.Uid, ctx.Writer)
+	if err == nil {
+		return
+	}
+	h.l.Error("Failed to export articles",
+		logger.Error(err),
+		logger.Int64("uid", uc.Uid))
+	if ctx.Writer.Written() {
+		ctx.Abort()
+		return
+	}
+	ctx.Writer.Header().Del("Content-Disposition")
+	ctx.Writer.Header().Del("Content-Type")
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Code: errs.ArticleInternalServerError,
+		Msg:  "System Error",
+	})
+}
+
+// Import create drafts from the multipart field "file", a zip of
+// Markdown files
+func (h *ArticleArchiveHandler) Import(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportBody)
+	fh, err := ctx.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return ginx.Result{
+				Code: errs.ArticleUploadTooLarge,
+				Msg:  "File is too large",
+			}, nil
+		}
+		return ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Invalid parameter: file",
+		}, nil
+	}
+	file, err := fh.Open()
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("failed to open import: %w", err)
+	}
+	defer file.Close()
+
+	res, err := h.svc.Import(ctx, uc.Uid, file, fh.Size)
+	if errors.Is(err, service.ErrArticleArchiveInvalid) {
+		return ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Invalid archive",
+		}, nil
+	}
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("failed to import articles: %w", err)
+	}
+
+	report := ArticleImportReportVo{
+		Files: make([]ArticleImportFileVo, 0, len(res)),
+	}
+	for _, r := range res {
+		vo := ArticleImportFileVo{
+			File: r.File,
+			Id:   r.Id,
+		}
+		switch {
+		case r.Err == nil:
+			report.Succeeded++
+		case errors.Is(r.Err, service.ErrArticleImportInvalid):
+			report.Failed++
+			vo.Error = r.Err.Error()
+		default:
+			report.Failed++
+			vo.Error = "System Error"
+		}
+		report.Files = append(report.Files, vo)
+	}
+	return ginx.Result{
+		Data: report,
+	}, nil
+}
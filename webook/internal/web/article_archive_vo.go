@@ -0,0 +1,14 @@
+package web
+
+type ArticleImportReportVo struct {
+	Succeeded int                   `json:"succeeded"`
+	Failed    int                   `json:"failed"`
+	Files     []ArticleImportFileVo `json:"files"`
+}
+
+type ArticleImportFileVo struct {
+	File string `json:"file"`
+	// Id is the draft created, Error is empty if it succeeded
+	Id    int64  `json:"id"`
+	Error string `json:"error,omitempty"`
+}
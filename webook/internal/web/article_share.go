@@ -0,0 +1,146 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/errs"
+	"webook/webook/internal/service"
+	ijwt "webook/webook/internal/web/jwt"
+	"webook/webook/pkg/ginx"
+	"webook/webook/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ArticleShareHandler struct {
+	svc service.ArticleShareService
+	l   logger.Logger
+}
+
+func NewArticleShareHandler(l logger.Logger, svc service.ArticleShareService) *ArticleShareHandler {
+	return &ArticleShareHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *ArticleShareHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/article/share")
+	g.POST("/create", ginx.WrapBodyAndClaims(h.Create))
+	g.POST("/list", ginx.WrapBodyAndClaims(h.List))
+	g.POST("/revoke", ginx.WrapBodyAndClaims(h.Revoke))
+	// It's public, the login is not checked for /share/
+	server.GET("/share/:token", h.Read)
+}
+
+func (h *ArticleShareHandler) Create(ctx *gin.Context, req CreateShareReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	var expireAt time.Time
+	if req.ExpireAt > 0 {
+		expireAt = time.UnixMilli(req.ExpireAt)
+		if !expireAt.After(time.Now()) {
+			return ginx.Result{
+				Code: errs.ArticleInvalidInput,
+				Msg:  "Invalid expire_at",
+			}, nil
+		}
+	}
+	share, err := h.svc.Create(ctx, uc.Uid, req.Aid, req.Draft, expireAt)
+	if err != nil {
+		return h.result(err, "failed to create share")
+	}
+	return ginx.Result{
+		Data: toArticleShareVo(share),
+	}, nil
+}
+
+func (h *ArticleShareHandler) List(ctx *gin.Context, req ShareReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	shares, err := h.svc.List(ctx, uc.Uid, req.Aid)
+	if err != nil {
+		return h.result(err, "failed to list shares")
+	}
+	vos := make([]ArticleShareVo, 0, len(shares))
+	for _, s := range shares {
+		vos = append(vos, toArticleShareVo(s))
+	}
+	return ginx.Result{
+		Data: vos,
+	}, nil
+}
+
+func (h *ArticleShareHandler) Revoke(ctx *gin.Context, req ShareReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	err := h.svc.Revoke(ctx, uc.Uid, req.Aid, req.Id)
+	return h.result(err, "failed to revoke share")
+}
+
+// Read is the page of the link, the interactives are not shown
+// since the reader may not log in
+func (h *ArticleShareHandler) Read(ctx *gin.Context) {
+	arti, err := h.svc.GetByToken(ctx, ctx.Param("token"))
+	if errors.Is(err, service.ErrArticleShareNotFound) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleShareNotFound,
+			Msg:  "Share not found",
+		})
+		return
+	}
+	if errors.Is(err, service.ErrContentRejected) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleContentRejected,
+			Msg:  "Content rejected",
+		})
+		return
+	}
+	if errors.Is(err, service.ErrContentUnderReview) {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleContentUnderReview,
+			Msg:  "Content needs review",
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		})
+		h.l.Error("Failed to read shared article", logger.Error(err))
+		return
+	}
+	vo := toContentVo(arti, domain.InteractiveCount{})
+	// The draft version is only for the editors
+	vo.Version = 0
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: vo,
+	})
+}
+
+func (h *ArticleShareHandler) result(err error, msg string) (ginx.Result, error) {
+	switch {
+	case err == nil:
+		return ginx.Result{
+			Msg: "OK",
+		}, nil
+	case errors.Is(err, service.ErrArticlePermissionDenied):
+		return ginx.Result{
+			Code: errs.ArticlePermissionDenied,
+			Msg:  "Permission denied",
+		}, nil
+	case errors.Is(err, service.ErrArticleNotShareable):
+		return ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Private or unpublished article can't be shared",
+		}, nil
+	case errors.Is(err, service.ErrArticleShareNotFound):
+		return ginx.Result{
+			Code: errs.ArticleShareNotFound,
+			Msg:  "Share not found",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("%s: %w", msg, err)
+	}
+}
@@ -0,0 +1,43 @@
+package web
+
+import (
+	"time"
+	"webook/webook/internal/domain"
+)
+
+type ArticleShareVo struct {
+	Id int64 `json:"id"`
+	// Token is the path of the link, /share/:token
+	Token string `json:"token"`
+	Draft bool   `json:"draft"`
+	// ExpireAt is empty if it never expires
+	ExpireAt string `json:"expire_at,omitempty"`
+	Ctime    string `json:"ctime"`
+}
+
+type CreateShareReq struct {
+	Aid int64 `json:"aid"`
+	// Draft links show the latest version, even if it's not published
+	Draft bool `json:"draft"`
+	// ExpireAt unix milliseconds, 0 for never
+	ExpireAt int64 `json:"expire_at"`
+}
+
+type ShareReq struct {
+	Aid int64 `json:"aid"`
+	// Id is the share to revoke, it's ignored by list
+	Id int64 `json:"id"`
+}
+
+func toArticleShareVo(s domain.ArticleShare) ArticleShareVo {
+	vo := ArticleShareVo{
+		Id:    s.Id,
+		Token: s.Token,
+		Draft: s.Draft,
+		Ctime: s.Ctime.Format(time.DateTime),
+	}
+	if !s.ExpireAt.IsZero() {
+		vo.ExpireAt = s.ExpireAt.Format(time.DateTime)
+	}
+	return vo
+}
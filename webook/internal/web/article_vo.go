@@ -1,30 +1,144 @@
 package web
 
+import "webook/webook/internal/domain"
+
 type ArticleVo struct {
-	Id         int64  `json:"id,omitempty"`
-	Title      string `json:"title,omitempty"`
-	Abstract   string `json:"abstract,omitempty"`
-	Content    string `json:"content,omitempty"`
-	AuthorId   int64  `json:"author_id,omitempty"`
-	AuthorName string `json:"author_name,omitempty"`
-	Status     uint8  `json:"status,omitempty"`
-	Ctime      string `json:"ctime,omitempty"`
-	Utime      string `json:"utime,omitempty"`
-
-	ViewCnt    int64 `json:"view_cnt,omitempty"`
-	LikeCnt    int64 `json:"like_cnt,omitempty"`
-	CollectCnt int64 `json:"collect_cnt,omitempty"`
-	Liked      bool  `json:"liked,omitempty"`
-	Collected  bool  `json:"collected,omitempty"`
+	Id         int64    `json:"id,omitempty"`
+	Title      string   `json:"title,omitempty"`
+	Abstract   string   `json:"abstract,omitempty"`
+	Content    string   `json:"content,omitempty"`
+	HTML       string   `json:"html,omitempty"`
+	AuthorId   int64    `json:"author_id,omitempty"`
+	AuthorName string   `json:"author_name,omitempty"`
+	Status     uint8    `json:"status,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	PublishAt  string   `json:"publish_at,omitempty"`
+	Version    int64    `json:"version,omitempty"`
+	DeletedAt  string   `json:"deleted_at,omitempty"`
+	Ctime      string   `json:"ctime,omitempty"`
+	Utime      string   `json:"utime,omitempty"`
+
+	// ViewCnt is the raw page views, UniqueViewCnt is the daily unique
+	// visitors summed up, it's updated every few minutes
+	ViewCnt       int64 `json:"view_cnt,omitempty"`
+	UniqueViewCnt int64 `json:"unique_view_cnt,omitempty"`
+	// LikeCnt is the sum of Reactions, Reaction is the one of the user
+	LikeCnt    int64            `json:"like_cnt,omitempty"`
+	Reactions  map[string]int64 `json:"reactions,omitempty"`
+	CollectCnt int64            `json:"collect_cnt,omitempty"`
+	Liked      bool             `json:"liked,omitempty"`
+	Reaction   string           `json:"reaction,omitempty"`
+	Collected  bool             `json:"collected,omitempty"`
+
+	// Series is only in the detail of a published article in a series
+	Series *SeriesNavVo `json:"series,omitempty"`
+}
+
+// InteractiveStateVo is the response of the likes and the collections,
+// the counts after the request and the state of the user
+type InteractiveStateVo struct {
+	LikeCnt    int64            `json:"like_cnt"`
+	Reactions  map[string]int64 `json:"reactions,omitempty"`
+	CollectCnt int64            `json:"collect_cnt"`
+	Liked      bool             `json:"liked"`
+	Reaction   string           `json:"reaction,omitempty"`
+	Collected  bool             `json:"collected"`
+}
+
+// ArticleListVo is a page of the cursor mode,
+// NextCursor is empty on the last page
+type ArticleListVo struct {
+	Items      []ArticleVo `json:"items"`
+	NextCursor string      `json:"next_cursor"`
+}
+
+type CommentListVo struct {
+	Items      []domain.Comment `json:"items"`
+	NextCursor string           `json:"next_cursor"`
+}
+
+type ArticleRevisionVo struct {
+	Id        int64  `json:"id"`
+	ArticleId int64  `json:"article_id"`
+	Title     string `json:"title"`
+	Abstract  string `json:"abstract"`
+	Status    uint8  `json:"status"`
+	Ctime     string `json:"ctime"`
+}
+
+type ArticleRevisionDiffVo struct {
+	From  ArticleRevisionVo `json:"from"`
+	To    ArticleRevisionVo `json:"to"`
+	Lines []DiffLineVo      `json:"lines"`
+}
+
+type DiffLineVo struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+type TagVo struct {
+	Name       string `json:"name"`
+	ArticleCnt int64  `json:"article_cnt"`
+}
+
+// ArticleSearchHitVo Title and Snippet are html escaped
+// with the matched terms wrapped in <em>
+type ArticleSearchHitVo struct {
+	Id       int64   `json:"id"`
+	Title    string  `json:"title"`
+	Snippet  string  `json:"snippet"`
+	Score    float64 `json:"score"`
+	AuthorId int64   `json:"author_id"`
+}
+
+type ArticleSearchVo struct {
+	Total int                  `json:"total"`
+	Items []ArticleSearchHitVo `json:"items"`
+}
+
+type TagPage struct {
+	Tag    string `json:"tag"`
+	Offset int64  `json:"offset"`
+	Limit  int64  `json:"limit"`
 }
 
 type PublishReq struct {
-	Id      int64  `json:"id"`
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	Id      int64    `json:"id"`
+	Title   string   `json:"title"`
+	Content string   `json:"content"`
+	Tags    []string `json:"tags"`
+	// PublishAt unix milliseconds, publish right now if it is not in the future
+	PublishAt int64 `json:"publish_at"`
+	// Version is the version got from /detail, it increases by 1 on every
+	// successful save. It's ignored for a new article
+	Version int64 `json:"version"`
+	// Unlisted publish it right now, only the share links can read it
+	Unlisted bool `json:"unlisted"`
+}
+
+type CancelScheduleReq struct {
+	Id int64 `json:"id"`
+}
+
+type DeleteReq struct {
+	Id int64 `json:"id"`
+}
+
+type RestoreReq struct {
+	Id int64 `json:"id"`
 }
 type EditReq struct {
-	Id      int64  `json:"id"`
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	Id      int64    `json:"id"`
+	Title   string   `json:"title"`
+	Content string   `json:"content"`
+	Tags    []string `json:"tags"`
+	// Version same as PublishReq.Version
+	Version int64 `json:"version"`
+}
+
+type UploadVo struct {
+	URL         string `json:"url"`
+	Size        int64  `json:"size"`
+	ContentType string `json:"content_type"`
 }
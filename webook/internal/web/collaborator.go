@@ -0,0 +1,92 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/errs"
+	"webook/webook/internal/service"
+	ijwt "webook/webook/internal/web/jwt"
+	"webook/webook/pkg/ginx"
+	"webook/webook/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CollaboratorHandler struct {
+	svc service.CollaboratorService
+	l   logger.Logger
+}
+
+func NewCollaboratorHandler(l logger.Logger, svc service.CollaboratorService) *CollaboratorHandler {
+	return &CollaboratorHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *CollaboratorHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/article/collaborator")
+	g.POST("/invite", ginx.WrapBodyAndClaims(h.Invite))
+	g.POST("/accept", ginx.WrapBodyAndClaims(h.Accept))
+	g.POST("/remove", ginx.WrapBodyAndClaims(h.Remove))
+	g.POST("/list", ginx.WrapBodyAndClaims(h.List))
+}
+
+func (h *CollaboratorHandler) Invite(ctx *gin.Context, req InviteCollaboratorReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	err := h.svc.Invite(ctx, uc.Uid, req.Aid, req.Uid, domain.CollaboratorRole(req.Role))
+	return h.result(err, "failed to invite collaborator")
+}
+
+func (h *CollaboratorHandler) Accept(ctx *gin.Context, req CollaboratorReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	err := h.svc.Accept(ctx, uc.Uid, req.Aid)
+	return h.result(err, "failed to accept invitation")
+}
+
+func (h *CollaboratorHandler) Remove(ctx *gin.Context, req CollaboratorReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	err := h.svc.Remove(ctx, uc.Uid, req.Aid, req.Uid)
+	return h.result(err, "failed to remove collaborator")
+}
+
+func (h *CollaboratorHandler) List(ctx *gin.Context, req CollaboratorReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	collabs, err := h.svc.List(ctx, uc.Uid, req.Aid)
+	if err != nil {
+		return h.result(err, "failed to list collaborators")
+	}
+	vos := make([]CollaboratorVo, 0, len(collabs))
+	for _, c := range collabs {
+		vos = append(vos, toCollaboratorVo(c))
+	}
+	return ginx.Result{
+		Data: vos,
+	}, nil
+}
+
+func (h *CollaboratorHandler) result(err error, msg string) (ginx.Result, error) {
+	switch {
+	case err == nil:
+		return ginx.Result{
+			Msg: "OK",
+		}, nil
+	case errors.Is(err, service.ErrArticlePermissionDenied):
+		return ginx.Result{
+			Code: errs.ArticlePermissionDenied,
+			Msg:  "Permission denied",
+		}, nil
+	case errors.Is(err, service.ErrCollaboratorInvalidRole):
+		return ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Invalid collaborator",
+		}, nil
+	case errors.Is(err, service.ErrInvitationNotFound):
+		return ginx.Result{
+			Code: errs.ArticleInvitationNotFound,
+			Msg:  "Invitation not found",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("%s: %w", msg, err)
+	}
+}
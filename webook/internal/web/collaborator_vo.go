@@ -0,0 +1,35 @@
+package web
+
+import (
+	"time"
+	"webook/webook/internal/domain"
+)
+
+type CollaboratorVo struct {
+	Uid int64 `json:"uid"`
+	// Role is 1 for viewer, 2 for editor
+	Role     uint8  `json:"role"`
+	Accepted bool   `json:"accepted"`
+	Ctime    string `json:"ctime"`
+}
+
+type InviteCollaboratorReq struct {
+	Aid  int64 `json:"aid"`
+	Uid  int64 `json:"uid"`
+	Role uint8 `json:"role"`
+}
+
+type CollaboratorReq struct {
+	Aid int64 `json:"aid"`
+	// Uid is the collaborator to remove, it's ignored by accept and list
+	Uid int64 `json:"uid"`
+}
+
+func toCollaboratorVo(c domain.Collaborator) CollaboratorVo {
+	return CollaboratorVo{
+		Uid:      c.Uid,
+		Role:     uint8(c.Role),
+		Accepted: c.Accepted,
+		Ctime:    c.Ctime.Format(time.DateTime),
+	}
+}
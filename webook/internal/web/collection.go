@@ -0,0 +1,163 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/errs"
+	"webook/webook/internal/service"
+	ijwt "webook/webook/internal/web/jwt"
+	"webook/webook/pkg/ginx"
+	"webook/webook/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxCollectionNameLen = 64
+
+type CollectionHandler struct {
+	svc      service.CollectionService
+	interSvc service.InteractiveService
+	l        logger.Logger
+	biz      string
+}
+
+func NewCollectionHandler(l logger.Logger,
+	svc service.CollectionService,
+	interSvc service.InteractiveService) *CollectionHandler {
+	return &CollectionHandler{
+		svc:      svc,
+		interSvc: interSvc,
+		l:        l,
+		biz:      "article",
+	}
+}
+
+func (h *CollectionHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/collection")
+	g.POST("/create", ginx.WrapBodyAndClaims(h.Create))
+	g.POST("/update", ginx.WrapBodyAndClaims(h.Update))
+	g.POST("/delete", ginx.WrapBodyAndClaims(h.Delete))
+	g.POST("/list", ginx.WrapBodyAndClaims(h.List))
+	g.POST("/articles", ginx.WrapBodyAndClaims(h.Articles))
+	g.POST("/move", ginx.WrapBodyAndClaims(h.Move))
+}
+
+func (h *CollectionHandler) Create(ctx *gin.Context, req EditCollectionReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	name, ok := validCollectionName(req.Name)
+	if !ok {
+		return ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Invalid name",
+		}, nil
+	}
+	id, err := h.svc.Create(ctx, domain.Collection{
+		Uid:    uc.Uid,
+		Name:   name,
+		Public: req.Public,
+	})
+	if err != nil {
+		return h.result(err, "failed to create collection")
+	}
+	return ginx.Result{
+		Data: id,
+	}, nil
+}
+
+func (h *CollectionHandler) Update(ctx *gin.Context, req EditCollectionReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	name, ok := validCollectionName(req.Name)
+	if !ok {
+		return ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Invalid name",
+		}, nil
+	}
+	err := h.svc.Update(ctx, domain.Collection{
+		Id:     req.Id,
+		Uid:    uc.Uid,
+		Name:   name,
+		Public: req.Public,
+	})
+	return h.result(err, "failed to update collection")
+}
+
+func (h *CollectionHandler) Delete(ctx *gin.Context, req CollectionReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	err := h.svc.Delete(ctx, uc.Uid, req.Id)
+	return h.result(err, "failed to delete collection")
+}
+
+func (h *CollectionHandler) List(ctx *gin.Context, req ListCollectionReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	owner := req.Uid
+	if owner == 0 {
+		owner = uc.Uid
+	}
+	cs, err := h.svc.List(ctx, uc.Uid, owner)
+	if err != nil {
+		return h.result(err, "failed to list collections")
+	}
+	vos := make([]CollectionVo, 0, len(cs))
+	for _, c := range cs {
+		vos = append(vos, toCollectionVo(c))
+	}
+	return ginx.Result{
+		Data: vos,
+	}, nil
+}
+
+func (h *CollectionHandler) Articles(ctx *gin.Context, req CollectionArticlesReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	owner := req.Uid
+	if owner == 0 {
+		owner = uc.Uid
+	}
+	artis, err := h.svc.ListArticles(ctx, uc.Uid, owner, req.Cid, req.Offset, req.Limit)
+	if err != nil {
+		return h.result(err, "failed to list collection articles")
+	}
+	inters, err := h.interSvc.GetByIds(ctx, h.biz, domain.ArticleList(artis).Ids(), uc.Uid)
+	if err != nil {
+		// The articles are still shown without the counts
+		h.l.Error("Failed to get interactives of collection",
+			logger.Error(err),
+			logger.Int64("cid", req.Cid))
+		inters = make(map[int64]domain.InteractiveCount)
+	}
+	return ginx.Result{
+		Data: toAbstractVos(artis, inters),
+	}, nil
+}
+
+func (h *CollectionHandler) Move(ctx *gin.Context, req MoveCollectionItemReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	err := h.svc.MoveArticle(ctx, uc.Uid, req.Aid, req.From, req.To)
+	return h.result(err, "failed to move collection item")
+}
+
+func (h *CollectionHandler) result(err error, msg string) (ginx.Result, error) {
+	switch {
+	case err == nil:
+		return ginx.Result{
+			Msg: "OK",
+		}, nil
+	case errors.Is(err, service.ErrCollectionNotFound):
+		return ginx.Result{
+			Code: errs.ArticleCollectionNotFound,
+			Msg:  "Collection not found",
+		}, nil
+	case errors.Is(err, service.ErrCollectionItemNotFound):
+		return ginx.Result{
+			Code: errs.ArticleCollectionItemNotFound,
+			Msg:  "Article is not in the collection",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("%s: %w", msg, err)
+	}
+}
+
+func validCollectionName(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	return name, name != "" && utf8.RuneCountInString(name) <= maxCollectionNameLen
+}
@@ -0,0 +1,53 @@
+package web
+
+import (
+	"time"
+	"webook/webook/internal/domain"
+)
+
+type CollectionVo struct {
+	Id     int64  `json:"id"`
+	Name   string `json:"name"`
+	Public bool   `json:"public"`
+	Ctime  string `json:"ctime"`
+}
+
+type EditCollectionReq struct {
+	// Id is ignored by create
+	Id     int64  `json:"id"`
+	Name   string `json:"name"`
+	Public bool   `json:"public"`
+}
+
+type CollectionReq struct {
+	Id int64 `json:"id"`
+}
+
+type ListCollectionReq struct {
+	// Uid is the owner, 0 for the current user
+	Uid int64 `json:"uid"`
+}
+
+type CollectionArticlesReq struct {
+	// Uid is the owner, 0 for the current user
+	Uid int64 `json:"uid"`
+	// Cid 0 is the default collection
+	Cid    int64 `json:"cid"`
+	Limit  int64 `json:"limit"`
+	Offset int64 `json:"offset"`
+}
+
+type MoveCollectionItemReq struct {
+	Aid  int64 `json:"aid"`
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+func toCollectionVo(c domain.Collection) CollectionVo {
+	return CollectionVo{
+		Id:     c.Id,
+		Name:   c.Name,
+		Public: c.Public,
+		Ctime:  c.Ctime.Format(time.DateTime),
+	}
+}
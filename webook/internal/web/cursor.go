@@ -0,0 +1,60 @@
+package web
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+	"webook/webook/internal/domain"
+)
+
+// maxCursorLimit is the max page size of the cursor mode
+const maxCursorLimit = 100
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// encodeCursor make the opaque next_cursor, clients should
+// only pass it back as is
+func encodeCursor(c domain.Cursor) string {
+	raw := fmt.Sprintf("%d:%d", c.Utime.UnixMilli(), c.Id)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursorPage parse the cursor and check the limit of a page,
+// the empty cursor is the first page
+func decodeCursorPage(s string, limit int64) (domain.Cursor, error) {
+	if limit <= 0 || limit > maxCursorLimit {
+		return domain.Cursor{}, fmt.Errorf("invalid limit %d", limit)
+	}
+	if s == "" {
+		return domain.Cursor{}, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return domain.Cursor{}, errInvalidCursor
+	}
+	var utime, id int64
+	_, err = fmt.Sscanf(string(raw), "%d:%d", &utime, &id)
+	if err != nil || id <= 0 {
+		return domain.Cursor{}, errInvalidCursor
+	}
+	return domain.Cursor{Utime: time.UnixMilli(utime), Id: id}, nil
+}
+
+// nextArticleCursor return the cursor after the last article,
+// empty if it's the last page
+func nextArticleCursor(artis []domain.Article, limit int64) string {
+	if len(artis) == 0 || int64(len(artis)) < limit {
+		return ""
+	}
+	last := artis[len(artis)-1]
+	return encodeCursor(domain.Cursor{Utime: last.Utime, Id: last.Id})
+}
+
+func nextCommentCursor(comments []domain.Comment, limit int64) string {
+	if len(comments) == 0 || int64(len(comments)) < limit {
+		return ""
+	}
+	last := comments[len(comments)-1]
+	return encodeCursor(domain.Cursor{Utime: last.Utime, Id: last.Id})
+}
@@ -0,0 +1,101 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/service"
+	"webook/webook/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+var feedContentTypes = map[domain.FeedFormat]string{
+	domain.FeedFormatRSS:  "application/rss+xml; charset=utf-8",
+	domain.FeedFormatAtom: "application/atom+xml; charset=utf-8",
+}
+
+// FeedHandler serve the feeds, they are public
+type FeedHandler struct {
+	svc service.FeedService
+	l   logger.Logger
+}
+
+func NewFeedHandler(l logger.Logger, svc service.FeedService) *FeedHandler {
+	return &FeedHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *FeedHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/feed")
+	g.GET("/rss.xml", h.Site(domain.FeedFormatRSS))
+	g.GET("/atom.xml", h.Site(domain.FeedFormatAtom))
+	g.GET("/author/:id/rss.xml", h.Author(domain.FeedFormatRSS))
+	g.GET("/author/:id/atom.xml", h.Author(domain.FeedFormatAtom))
+}
+
+func (h *FeedHandler) Site(format domain.FeedFormat) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		h.serve(ctx, format, 0)
+	}
+}
+
+func (h *FeedHandler) Author(format domain.FeedFormat) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		uid, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil || uid <= 0 {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		h.serve(ctx, format, uid)
+	}
+}
+
+func (h *FeedHandler) serve(ctx *gin.Context, format domain.FeedFormat, uid int64) {
+	f, err := h.svc.Feed(ctx, format, uid)
+	if errors.Is(err, service.ErrFeedAuthorNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		ctx.Status(http.StatusInternalServerError)
+		h.l.Error("Failed to get feed",
+			logger.Error(err),
+			logger.String("format", string(format)),
+			logger.Int64("uid", uid))
+		return
+	}
+
+	ctx.Header("ETag", f.ETag)
+	ctx.Header("Last-Modified", f.Utime.UTC().Format(http.TimeFormat))
+	ctx.Header("Cache-Control", "public, max-age=300")
+	if notModified(ctx.Request, f) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+	ctx.Data(http.StatusOK, feedContentTypes[format], f.Content)
+}
+
+// notModified check the conditional headers, If-None-Match wins
+// over If-Modified-Since if both are sent
+func notModified(req *http.Request, f domain.Feed) bool {
+	if inm := req.Header.Get("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == f.ETag || tag == "*" {
+				return true
+			}
+		}
+		return false
+	}
+	ims, err := http.ParseTime(req.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	// Utime is in seconds as the header
+	return !f.Utime.After(ims)
+}
@@ -0,0 +1,65 @@
+package web
+
+import (
+	"net/http"
+	"webook/webook/internal/errs"
+	"webook/webook/internal/job"
+	ijwt "webook/webook/internal/web/jwt"
+	"webook/webook/pkg/ginx"
+	"webook/webook/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InteractiveAdminHandler let the admins check the interactive counts
+type InteractiveAdminHandler struct {
+	reconcileJob *job.InteractiveReconcileJob
+	l            logger.Logger
+}
+
+func NewInteractiveAdminHandler(l logger.Logger,
+	reconcileJob *job.InteractiveReconcileJob) *InteractiveAdminHandler {
+	return &InteractiveAdminHandler{
+		reconcileJob: reconcileJob,
+		l:            l,
+	}
+}
+
+// RegisterRoutes register the routes on the admin group
+func (h *InteractiveAdminHandler) RegisterRoutes(admin *gin.RouterGroup) {
+	g := admin.Group("/interactive")
+	g.POST("/reconcile", ginx.WrapBodyAndClaims(h.Reconcile))
+	g.GET("/reconcile", h.ReconcileReport)
+}
+
+// Reconcile start checking all the counts in the background under the lock
+// of the job, it's a dry run unless Repair. The report is got by
+// ReconcileReport from the same instance when it's done
+func (h *InteractiveAdminHandler) Reconcile(ctx *gin.Context, req ReconcileReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	if !h.reconcileJob.Start(!req.Repair) {
+		return ginx.Result{
+			Code: errs.ArticleReconcileRunning,
+			Msg:  "Reconciling is running or held by another instance",
+		}, nil
+	}
+	h.l.Info("interactive counts reconciling started by admin",
+		logger.Int64("uid", uc.Uid),
+		logger.Bool("repair", req.Repair))
+	return ginx.Result{
+		Msg: "OK",
+	}, nil
+}
+
+func (h *InteractiveAdminHandler) ReconcileReport(ctx *gin.Context) {
+	report, ok := h.reconcileJob.LastReport()
+	if !ok {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: errs.ArticleReconcileNoReport,
+			Msg:  "No report yet",
+		})
+		return
+	}
+	ctx.JSON(http.StatusOK, ginx.Result{
+		Data: toReconcileReportVo(report),
+	})
+}
@@ -0,0 +1,44 @@
+package web
+
+import "webook/webook/internal/domain"
+
+type ReconcileReq struct {
+	// Repair false is a dry run, the diffs are only reported
+	Repair bool `json:"repair"`
+}
+
+type CounterDiffVo struct {
+	Biz     string `json:"biz"`
+	BizId   int64  `json:"biz_id"`
+	Counter string `json:"counter"`
+	Old     int64  `json:"old"`
+	New     int64  `json:"new"`
+}
+
+// ReconcileReportVo only has the first diffs, DiffCnts
+// is the number of the diffs of each counter
+type ReconcileReportVo struct {
+	DryRun   bool            `json:"dry_run"`
+	Scanned  int             `json:"scanned"`
+	DiffCnts map[string]int  `json:"diff_cnts"`
+	Diffs    []CounterDiffVo `json:"diffs"`
+}
+
+func toReconcileReportVo(report domain.ReconcileReport) ReconcileReportVo {
+	diffs := make([]CounterDiffVo, 0, len(report.Diffs))
+	for _, diff := range report.Diffs {
+		diffs = append(diffs, CounterDiffVo{
+			Biz:     diff.Biz,
+			BizId:   diff.BizId,
+			Counter: diff.Counter,
+			Old:     diff.Old,
+			New:     diff.New,
+		})
+	}
+	return ReconcileReportVo{
+		DryRun:   report.DryRun,
+		Scanned:  report.Scanned,
+		DiffCnts: report.DiffCnts,
+		Diffs:    diffs,
+	}
+}
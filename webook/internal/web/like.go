@@ -0,0 +1,120 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/errs"
+	"webook/webook/internal/service"
+	ijwt "webook/webook/internal/web/jwt"
+	"webook/webook/pkg/ginx"
+	"webook/webook/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LikeHandler struct {
+	svc      service.LikeService
+	interSvc service.InteractiveService
+	l        logger.Logger
+	biz      string
+}
+
+func NewLikeHandler(l logger.Logger,
+	svc service.LikeService,
+	interSvc service.InteractiveService) *LikeHandler {
+	return &LikeHandler{
+		svc:      svc,
+		interSvc: interSvc,
+		l:        l,
+		biz:      "article",
+	}
+}
+
+func (h *LikeHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/like")
+	g.POST("/likers", ginx.WrapBody(h.Likers))
+	g.POST("/articles", ginx.WrapBodyAndClaims(h.Articles))
+}
+
+func (h *LikeHandler) Likers(ctx *gin.Context, req LikersReq) (ginx.Result, error) {
+	cursor, err := decodeCursorPage(req.Cursor, req.Limit)
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Invalid parameter: " + err.Error(),
+		}, nil
+	}
+	likes, err := h.svc.ListLikers(ctx, req.Id, cursor, req.Limit)
+	if errors.Is(err, service.ErrArticleNotFound) {
+		return ginx.Result{
+			Code: errs.ArticleNotFound,
+			Msg:  "Article not found",
+		}, nil
+	}
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("failed to list likers: %w", err)
+	}
+
+	vos := make([]LikerVo, 0, len(likes))
+	for _, like := range likes {
+		vos = append(vos, toLikerVo(like))
+	}
+	var next string
+	if len(likes) > 0 && int64(len(likes)) == req.Limit {
+		last := likes[len(likes)-1]
+		next = encodeCursor(domain.Cursor{Utime: last.Utime, Id: last.Id})
+	}
+	return ginx.Result{
+		Data: LikerListVo{
+			Items:      vos,
+			NextCursor: next,
+		},
+	}, nil
+}
+
+// Articles list the articles the user likes with the counts
+func (h *LikeHandler) Articles(ctx *gin.Context, req LikedArticlesReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	cursor, err := decodeCursorPage(req.Cursor, req.Limit)
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Invalid parameter: " + err.Error(),
+		}, nil
+	}
+	liked, next, err := h.svc.ListLikedArticles(ctx, uc.Uid, cursor, req.Limit)
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("failed to list liked articles: %w", err)
+	}
+
+	artis := make([]domain.Article, 0, len(liked))
+	for _, item := range liked {
+		artis = append(artis, item.Article)
+	}
+	inters, err := h.interSvc.GetByIds(ctx, h.biz, domain.ArticleList(artis).Ids(), uc.Uid)
+	if err != nil {
+		// The articles are still shown without the counts
+		h.l.Error("Failed to get interactives of liked articles",
+			logger.Error(err),
+			logger.Int64("uid", uc.Uid))
+		inters = make(map[int64]domain.InteractiveCount)
+	}
+	vos := make([]ArticleVo, 0, len(liked))
+	for _, item := range liked {
+		vos = append(vos, _tovo(item.Article, inters[item.Article.Id], true))
+	}
+
+	res := ArticleListVo{Items: vos}
+	if !next.IsZero() {
+		res.NextCursor = encodeCursor(next)
+	}
+	return ginx.Result{
+		Data: res,
+	}, nil
+}
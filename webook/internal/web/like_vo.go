@@ -0,0 +1,42 @@
+package web
+
+import (
+	"time"
+	"webook/webook/internal/domain"
+)
+
+type LikerVo struct {
+	Uid      int64  `json:"uid"`
+	Nickname string `json:"nickname"`
+	Reaction string `json:"reaction"`
+	Utime    string `json:"utime"`
+}
+
+// LikerListVo is ArticleListVo for the users who like an article
+type LikerListVo struct {
+	Items      []LikerVo `json:"items"`
+	NextCursor string    `json:"next_cursor"`
+}
+
+type LikersReq struct {
+	// Id is the article
+	Id int64 `json:"id"`
+	// Cursor "" is the first page
+	Cursor string `json:"cursor"`
+	Limit  int64  `json:"limit"`
+}
+
+type LikedArticlesReq struct {
+	// Cursor "" is the first page
+	Cursor string `json:"cursor"`
+	Limit  int64  `json:"limit"`
+}
+
+func toLikerVo(like domain.Like) LikerVo {
+	return LikerVo{
+		Uid:      like.Uid,
+		Nickname: like.Nickname,
+		Reaction: like.Reaction,
+		Utime:    like.Utime.Format(time.DateTime),
+	}
+}
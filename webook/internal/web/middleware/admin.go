@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	ijwt "webook/webook/internal/web/jwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminMiddlewareBuilder only let the admins through,
+// it runs after the login check
+type AdminMiddlewareBuilder struct {
+	uids map[int64]struct{}
+}
+
+func NewAdminMiddlewareBuilder(uids []int64) *AdminMiddlewareBuilder {
+	m := &AdminMiddlewareBuilder{
+		uids: make(map[int64]struct{}, len(uids)),
+	}
+	for _, uid := range uids {
+		m.uids[uid] = struct{}{}
+	}
+	return m
+}
+
+func (m *AdminMiddlewareBuilder) Build() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		uc, ok := ctx.Get("userclaim")
+		if !ok {
+			ctx.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		claims, ok := uc.(ijwt.UserClaims)
+		if !ok {
+			ctx.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		if _, ok = m.uids[claims.Uid]; !ok {
+			ctx.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+	}
+}
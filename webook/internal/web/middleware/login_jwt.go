@@ -4,6 +4,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"net/http"
+	"strconv"
+	"strings"
 	ijwt "webook/webook/internal/web/jwt"
 )
 
@@ -26,10 +28,21 @@ func (m *LoginJWTMiddlewareBuilder) CheckLogin() gin.HandlerFunc {
 			path == "/user/login_sms/code/send" ||
 			path == "/user/login_sms/code/verify" ||
 			path == "/oauth2/wechat/callback" ||
-			path == "/oauth2/wechat/authurl" {
+			path == "/oauth2/wechat/authurl" ||
+			strings.HasPrefix(path, "/uploads/") ||
+			strings.HasPrefix(path, "/feed/") ||
+			strings.HasPrefix(path, "/share/") {
 			return
 		}
 
+		// The anonymous request goes on without the userclaim
+		optional := optionalLogin(ctx)
+		abort := func() {
+			if !optional {
+				ctx.AbortWithStatus(http.StatusUnauthorized)
+			}
+		}
+
 		// Get token
 		tokenStr := m.ExtractToken(ctx)
 		var uc ijwt.UserClaims
@@ -42,25 +55,39 @@ func (m *LoginJWTMiddlewareBuilder) CheckLogin() gin.HandlerFunc {
 
 		// Check if token is valid
 		if err != nil {
-			ctx.AbortWithStatus(http.StatusUnauthorized)
+			abort()
 			return
 		}
 		if !token.Valid {
-			ctx.AbortWithStatus(http.StatusUnauthorized)
+			abort()
 			return
 		}
 		if uc.UserAgent != ctx.GetHeader("User-Agent") {
-			ctx.AbortWithStatus(http.StatusUnauthorized)
+			abort()
 			return
 		}
 
 		// Check ssid
 		err = m.CheckSession(ctx, uc.Ssid)
 		if err != nil {
-			ctx.AbortWithStatus(http.StatusUnauthorized)
+			abort()
 			return
 		}
 
 		ctx.Set("userclaim", uc)
 	}
 }
+
+// optionalLogin return true for the published article detail,
+// it's readable without logging in
+func optionalLogin(ctx *gin.Context) bool {
+	if ctx.Request.Method != http.MethodGet {
+		return false
+	}
+	id, ok := strings.CutPrefix(ctx.Request.URL.Path, "/article/pub/")
+	if !ok {
+		return false
+	}
+	_, err := strconv.ParseInt(id, 10, 64)
+	return err == nil
+}
@@ -0,0 +1,115 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/errs"
+	"webook/webook/internal/service"
+	ijwt "webook/webook/internal/web/jwt"
+	"webook/webook/pkg/ginx"
+	"webook/webook/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ModerationHandler is the manual review queue for the admins
+type ModerationHandler struct {
+	svc        service.ModerationService
+	articleSvc service.ArticleService
+	commentSvc service.CommentService
+	l          logger.Logger
+}
+
+func NewModerationHandler(l logger.Logger, svc service.ModerationService,
+	articleSvc service.ArticleService, commentSvc service.CommentService) *ModerationHandler {
+	return &ModerationHandler{
+		svc:        svc,
+		articleSvc: articleSvc,
+		commentSvc: commentSvc,
+		l:          l,
+	}
+}
+
+// RegisterRoutes register the routes on the admin group
+func (h *ModerationHandler) RegisterRoutes(admin *gin.RouterGroup) {
+	g := admin.Group("/moderation")
+	g.POST("/list", ginx.WrapBodyAndClaims(h.List))
+	g.POST("/review", ginx.WrapBodyAndClaims(h.Review))
+}
+
+func (h *ModerationHandler) List(ctx *gin.Context, page Page, uc ijwt.UserClaims) (ginx.Result, error) {
+	items, err := h.svc.ListPending(ctx, page.Offset, page.Limit)
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("failed to list moderation items: %w", err)
+	}
+	vos := make([]ModerationItemVo, 0, len(items))
+	for _, item := range items {
+		vos = append(vos, toModerationItemVo(item))
+	}
+	return ginx.Result{
+		Data: vos,
+	}, nil
+}
+
+// Review approve or reject the item, the approved one is published or
+// created. The item is claimed by approving it, so it's published once,
+// and it's put back to pending if the publishing fails. An article edited
+// after submitting is left as a draft and its item stays closed, the
+// author's next publishing is checked again
+func (h *ModerationHandler) Review(ctx *gin.Context, req ReviewModerationReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	item, err := h.svc.Review(ctx, req.Id, uc.Uid, req.Approve)
+	if errors.Is(err, service.ErrModerationItemNotFound) {
+		return ginx.Result{
+			Code: errs.ArticleModerationNotFound,
+			Msg:  "Moderation item not found",
+		}, nil
+	}
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("failed to review moderation item: %w", err)
+	}
+	if !req.Approve {
+		return ginx.Result{
+			Msg: "OK",
+		}, nil
+	}
+
+	switch item.Biz {
+	case "article":
+		err = h.articleSvc.PublishReviewed(ctx, item.BizId, item.Version, item.Unlisted)
+	case "comment":
+		_, err = h.commentSvc.CreateReviewed(ctx, domain.Comment{
+			Content:   item.Content,
+			ArticleId: item.BizId,
+			User: domain.User{
+				Id: item.Uid,
+			},
+		})
+	}
+	if errors.Is(err, service.ErrArticleVersionConflict) {
+		return ginx.Result{
+			Code: errs.ArticleVersionConflict,
+			Msg:  "Article is edited after submitting",
+		}, nil
+	}
+	if err != nil {
+		er := h.svc.Reopen(ctx, item.Id)
+		if er != nil {
+			h.l.Error("Failed to reopen moderation item",
+				logger.Int64("id", item.Id), logger.Error(er))
+		}
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("failed to publish reviewed %s %d: %w", item.Biz, item.BizId, err)
+	}
+	return ginx.Result{
+		Msg: "OK",
+	}, nil
+}
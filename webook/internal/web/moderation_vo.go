@@ -0,0 +1,36 @@
+package web
+
+import (
+	"time"
+	"webook/webook/internal/domain"
+)
+
+type ModerationItemVo struct {
+	Id int64 `json:"id"`
+	// Biz is "article" or "comment", BizId is the article of both
+	Biz     string   `json:"biz"`
+	BizId   int64    `json:"biz_id"`
+	Uid     int64    `json:"uid"`
+	Title   string   `json:"title"`
+	Content string   `json:"content"`
+	Words   []string `json:"words"`
+	Ctime   string   `json:"ctime"`
+}
+
+type ReviewModerationReq struct {
+	Id      int64 `json:"id"`
+	Approve bool  `json:"approve"`
+}
+
+func toModerationItemVo(item domain.ModerationItem) ModerationItemVo {
+	return ModerationItemVo{
+		Id:      item.Id,
+		Biz:     item.Biz,
+		BizId:   item.BizId,
+		Uid:     item.Uid,
+		Title:   item.Title,
+		Content: item.Content,
+		Words:   item.Words,
+		Ctime:   item.Ctime.Format(time.DateTime),
+	}
+}
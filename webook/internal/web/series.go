@@ -0,0 +1,115 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+	"webook/webook/internal/errs"
+	"webook/webook/internal/service"
+	ijwt "webook/webook/internal/web/jwt"
+	"webook/webook/pkg/ginx"
+	"webook/webook/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxSeriesTitleLen = 256
+
+type SeriesHandler struct {
+	svc service.SeriesService
+	l   logger.Logger
+}
+
+func NewSeriesHandler(l logger.Logger, svc service.SeriesService) *SeriesHandler {
+	return &SeriesHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *SeriesHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/series")
+	g.POST("/create", ginx.WrapBodyAndClaims(h.Create))
+	g.POST("/list", ginx.WrapBodyAndClaims(h.List))
+	g.POST("/reorder", ginx.WrapBodyAndClaims(h.Reorder))
+	g.POST("/article/add", ginx.WrapBodyAndClaims(h.AddArticle))
+	g.POST("/article/remove", ginx.WrapBodyAndClaims(h.RemoveArticle))
+}
+
+func (h *SeriesHandler) Create(ctx *gin.Context, req CreateSeriesReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	title := strings.TrimSpace(req.Title)
+	if title == "" || utf8.RuneCountInString(title) > maxSeriesTitleLen {
+		return ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Invalid parameter: title",
+		}, nil
+	}
+	id, err := h.svc.Create(ctx, uc.Uid, title)
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("failed to create series: %w", err)
+	}
+	return ginx.Result{
+		Data: id,
+	}, nil
+}
+
+func (h *SeriesHandler) List(ctx *gin.Context, page Page, uc ijwt.UserClaims) (ginx.Result, error) {
+	series, err := h.svc.List(ctx, uc.Uid, page.Offset, page.Limit)
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("failed to list series: %w", err)
+	}
+	vos := make([]SeriesVo, 0, len(series))
+	for _, s := range series {
+		vos = append(vos, toSeriesVo(s))
+	}
+	return ginx.Result{
+		Data: vos,
+	}, nil
+}
+
+func (h *SeriesHandler) Reorder(ctx *gin.Context, req ReorderSeriesReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	err := h.svc.Reorder(ctx, uc.Uid, req.Id, req.Aids)
+	return h.result(err, "failed to reorder series")
+}
+
+func (h *SeriesHandler) AddArticle(ctx *gin.Context, req SeriesArticleReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	err := h.svc.AddArticle(ctx, uc.Uid, req.Id, req.Aid)
+	return h.result(err, "failed to add article to series")
+}
+
+func (h *SeriesHandler) RemoveArticle(ctx *gin.Context, req SeriesArticleReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	err := h.svc.RemoveArticle(ctx, uc.Uid, req.Id, req.Aid)
+	return h.result(err, "failed to remove article from series")
+}
+
+// result map the errors of the updates
+func (h *SeriesHandler) result(err error, msg string) (ginx.Result, error) {
+	switch {
+	case err == nil:
+		return ginx.Result{
+			Msg: "OK",
+		}, nil
+	case errors.Is(err, service.ErrSeriesNotFound):
+		return ginx.Result{
+			Code: errs.ArticleSeriesNotFound,
+			Msg:  "Series not found",
+		}, nil
+	case errors.Is(err, service.ErrSeriesInvalidArticles):
+		return ginx.Result{
+			Code: errs.ArticleSeriesInvalid,
+			Msg:  "Invalid articles for the series",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("%s: %w", msg, err)
+	}
+}
@@ -0,0 +1,68 @@
+package web
+
+import (
+	"time"
+	"webook/webook/internal/domain"
+)
+
+type SeriesVo struct {
+	Id         int64   `json:"id"`
+	Title      string  `json:"title"`
+	ArticleIds []int64 `json:"article_ids,omitempty"`
+	Ctime      string  `json:"ctime"`
+	Utime      string  `json:"utime"`
+}
+
+// SeriesNavVo is the position of an article in its series,
+// Prev and Next are nil at the ends
+type SeriesNavVo struct {
+	Id    int64            `json:"id"`
+	Title string           `json:"title"`
+	Prev  *SeriesChapterVo `json:"prev"`
+	Next  *SeriesChapterVo `json:"next"`
+}
+
+type SeriesChapterVo struct {
+	Id    int64  `json:"id"`
+	Title string `json:"title"`
+}
+
+type CreateSeriesReq struct {
+	Title string `json:"title"`
+}
+
+type SeriesArticleReq struct {
+	Id int64 `json:"id"`
+	// Aid is the article to add or remove
+	Aid int64 `json:"aid"`
+}
+
+type ReorderSeriesReq struct {
+	Id int64 `json:"id"`
+	// Aids is all the articles of the series in the new order
+	Aids []int64 `json:"aids"`
+}
+
+func toSeriesVo(s domain.Series) SeriesVo {
+	return SeriesVo{
+		Id:         s.Id,
+		Title:      s.Title,
+		ArticleIds: s.ArticleIds,
+		Ctime:      s.Ctime.Format(time.DateTime),
+		Utime:      s.Utime.Format(time.DateTime),
+	}
+}
+
+func toSeriesNavVo(nav domain.SeriesNav) *SeriesNavVo {
+	vo := &SeriesNavVo{
+		Id:    nav.SeriesId,
+		Title: nav.Title,
+	}
+	if nav.Prev.Id > 0 {
+		vo.Prev = &SeriesChapterVo{Id: nav.Prev.Id, Title: nav.Prev.Title}
+	}
+	if nav.Next.Id > 0 {
+		vo.Next = &SeriesChapterVo{Id: nav.Next.Id, Title: nav.Next.Title}
+	}
+	return vo
+}
@@ -0,0 +1,103 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"webook/webook/internal/errs"
+	"webook/webook/internal/service"
+	ijwt "webook/webook/internal/web/jwt"
+	"webook/webook/pkg/ginx"
+	"webook/webook/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxUploadBody is the hard limit of the request body,
+// the service checks the configured size of the file
+const maxUploadBody = 32 << 20
+
+type UploadHandler struct {
+	svc service.UploadService
+	l   logger.Logger
+}
+
+func NewUploadHandler(l logger.Logger, svc service.UploadService) *UploadHandler {
+	return &UploadHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *UploadHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/article")
+	g.POST("/:id/upload", ginx.WrapClaims(h.Upload))
+}
+
+// Upload save the multipart field "file" for the article
+func (h *UploadHandler) Upload(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	aid, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Invalid parameter: id",
+		}, nil
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxUploadBody)
+	fh, err := ctx.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return ginx.Result{
+				Code: errs.ArticleUploadTooLarge,
+				Msg:  "File is too large",
+			}, nil
+		}
+		return ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "Invalid parameter: file",
+		}, nil
+	}
+	file, err := fh.Open()
+	if err != nil {
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("failed to open upload: %w", err)
+	}
+	defer file.Close()
+
+	u, err := h.svc.Upload(ctx, uc.Uid, aid, fh.Size, file)
+	switch {
+	case err == nil:
+		return ginx.Result{
+			Data: UploadVo{
+				URL:         u.URL,
+				Size:        u.Size,
+				ContentType: u.ContentType,
+			},
+		}, nil
+	case errors.Is(err, service.ErrUploadTooLarge):
+		return ginx.Result{
+			Code: errs.ArticleUploadTooLarge,
+			Msg:  "File is too large",
+		}, nil
+	case errors.Is(err, service.ErrUploadTypeNotAllowed):
+		return ginx.Result{
+			Code: errs.ArticleUploadTypeInvalid,
+			Msg:  "File type is not allowed",
+		}, nil
+	case errors.Is(err, service.ErrUploadForbidden):
+		return ginx.Result{
+			Code: errs.ArticlePermissionDenied,
+			Msg:  "Permission denied",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: errs.ArticleInternalServerError,
+			Msg:  "System Error",
+		}, fmt.Errorf("failed to upload: %w", err)
+	}
+}
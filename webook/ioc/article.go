@@ -0,0 +1,128 @@
+package ioc
+
+import (
+	"github.com/bwmarrin/snowflake"
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"sync"
+	"time"
+	"webook/webook/internal/repository"
+	"webook/webook/internal/repository/dao"
+	"webook/webook/internal/service"
+	"webook/webook/pkg/logger"
+)
+
+// The storage of each side is picked by article.author.storage and
+// article.reader.storage, "gorm" (default) or "mongodb".
+// The gorm reader reads from article.reader.dsn if set, such as a read
+// replica, the writes and the migrations stay on the primary
+type articleStorageConfig struct {
+	Storage string
+	DSN     string
+}
+
+var (
+	articleMongoOnce sync.Once
+	articleMongoDB   *mongo.Database
+)
+
+func InitArticleAuthorDAO(db *gorm.DB) dao.ArticleAuthorDAO {
+	var cfg articleStorageConfig
+	err := viper.UnmarshalKey("article.author", &cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	switch cfg.Storage {
+	case "", "gorm":
+		return dao.NewGORMArticleAuthorDAO(db)
+	case "mongodb":
+		// NodeId is the snowflake node id used to generate article id,
+		// it must be unique among the instances
+		node, err := snowflake.NewNode(viper.GetInt64("article.nodeId"))
+		if err != nil {
+			panic(err)
+		}
+		return dao.NewMongoDBArticleAuthorDAO(initArticleMongoDB(), node)
+	default:
+		panic("unknown article author storage: " + cfg.Storage)
+	}
+}
+
+func InitArticleReaderDAO(db *gorm.DB, userDAO dao.UserDAO) dao.ArticleReaderDAO {
+	var cfg articleStorageConfig
+	err := viper.UnmarshalKey("article.reader", &cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	switch cfg.Storage {
+	case "", "gorm":
+		if cfg.DSN == "" {
+			return dao.NewGORMArticleReaderDAO(db)
+		}
+		replica, err := gorm.Open(mysql.Open(cfg.DSN))
+		if err != nil {
+			panic(err)
+		}
+		return dao.NewGORMArticleReaderDAOWithReplica(db, replica)
+	case "mongodb":
+		return dao.NewMongoDBArticleReaderDAO(initArticleMongoDB(), userDAO)
+	default:
+		panic("unknown article reader storage: " + cfg.Storage)
+	}
+}
+
+// initArticleMongoDB share the connection when both sides are on MongoDB
+func initArticleMongoDB() *mongo.Database {
+	articleMongoOnce.Do(func() {
+		articleMongoDB = InitMongoDB()
+		err := dao.InitCollection(articleMongoDB)
+		if err != nil {
+			panic(err)
+		}
+	})
+	return articleMongoDB
+}
+
+// InitArticleTrashService keep the deleted articles for
+// article.trash.retention, 30 days by default
+func InitArticleTrashService(authorRepo repository.ArticleAuthorRepository,
+	readerRepo repository.ArticleReaderRepository,
+	revRepo repository.ArticleRevisionRepository,
+	interRepo repository.InteractiveRepository,
+	commentRepo repository.CommentRepository,
+	uploadRepo repository.UploadRepository,
+	seriesRepo repository.SeriesRepository,
+	collabRepo repository.CollaboratorRepository,
+	shareRepo repository.ArticleShareRepository,
+	rankRepo repository.RankingRepository,
+	feedRepo repository.FeedRepository,
+	searchSvc service.SearchService,
+	l logger.Logger) service.ArticleTrashService {
+	retention := viper.GetDuration("article.trash.retention")
+	if retention <= 0 {
+		retention = 30 * 24 * time.Hour
+	}
+	return service.NewImplArticleTrashService(authorRepo, readerRepo, revRepo,
+		interRepo, commentRepo, uploadRepo, seriesRepo, collabRepo, shareRepo,
+		rankRepo, feedRepo, searchSvc, retention, l)
+}
+
+// InitArticleShareService sign the share links with share.secret,
+// it's required since the links are public
+func InitArticleShareService(repo repository.ArticleShareRepository,
+	authorRepo repository.ArticleAuthorRepository,
+	readerRepo repository.ArticleReaderRepository,
+	collabRepo repository.CollaboratorRepository,
+	userRepo repository.UserRepository,
+	moderator service.ModerationService) service.ArticleShareService {
+	secret := viper.GetString("share.secret")
+	if secret == "" {
+		panic("share.secret is not configured")
+	}
+	return service.NewImplArticleShareService(repo, authorRepo, readerRepo,
+		collabRepo, userRepo, moderator, []byte(secret))
+}
@@ -0,0 +1,34 @@
+package ioc
+
+import (
+	"strings"
+	"webook/webook/internal/repository"
+	"webook/webook/internal/service"
+	"webook/webook/pkg/logger"
+
+	"github.com/spf13/viper"
+)
+
+// InitFeedService read the site from feed.title and feed.link,
+// feed.size is how many articles in a feed, 20 by default
+func InitFeedService(svc service.ArticleService,
+	repo repository.FeedRepository,
+	userRepo repository.UserRepository,
+	l logger.Logger) service.FeedService {
+	type Config struct {
+		Title string
+		Link  string
+		Size  int64
+	}
+	cfg := Config{
+		Title: "webook",
+		Link:  "http://localhost:3000",
+		Size:  20,
+	}
+	err := viper.UnmarshalKey("feed", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return service.NewImplFeedService(svc, repo, userRepo,
+		cfg.Title, strings.TrimSuffix(cfg.Link, "/"), cfg.Size, l)
+}
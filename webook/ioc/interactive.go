@@ -0,0 +1,40 @@
+package ioc
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+	"time"
+	"webook/webook/internal/domain"
+	"webook/webook/internal/repository"
+	"webook/webook/internal/repository/cache"
+	"webook/webook/internal/repository/dao"
+	"webook/webook/internal/service"
+	"webook/webook/pkg/logger"
+)
+
+// InitViewCountAggregator merge the views in interactive.viewFlushWindow,
+// 1s by default
+func InitViewCountAggregator(d dao.InteractiveDAO, c cache.InteractiveCache,
+	l logger.Logger) *repository.ViewCountAggregator {
+	window := viper.GetDuration("interactive.viewFlushWindow")
+	if window <= 0 {
+		window = time.Second
+	}
+	views := repository.NewViewCountAggregator(d, c, window, l)
+	prometheus.MustRegister(views)
+	return views
+}
+
+// InitInteractiveService read the reactions of each biz from
+// interactive.reactions, articles have a few by default
+func InitInteractiveService(repo repository.InteractiveRepository,
+	collectRepo repository.CollectionRepository) service.InteractiveService {
+	reactions := map[string][]string{
+		"article": {domain.ReactionLike, "insightful", "funny"},
+	}
+	err := viper.UnmarshalKey("interactive.reactions", &reactions)
+	if err != nil {
+		panic(err)
+	}
+	return service.NewInteractiveService(repo, collectRepo, reactions)
+}
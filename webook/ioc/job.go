@@ -15,7 +15,42 @@ func InitRankingJob(svc service.RankingService,
 	return job.NewRankingJob(svc, 30*time.Second, lockClient, l)
 }
 
-func InitJobs(l logger.Logger, rjob *job.RankingJob) *cron.Cron {
+func InitScheduledPublishJob(svc service.ArticleService,
+	lockClient *rlock.Client, l logger.Logger) *job.ScheduledPublishJob {
+	return job.NewScheduledPublishJob(svc, 30*time.Second, lockClient, l)
+}
+
+func InitTrashPurgeJob(svc service.ArticleTrashService,
+	lockClient *rlock.Client, l logger.Logger) *job.TrashPurgeJob {
+	return job.NewTrashPurgeJob(svc, time.Minute, lockClient, l)
+}
+
+func InitUploadGCJob(svc service.UploadService,
+	lockClient *rlock.Client, l logger.Logger) *job.UploadGCJob {
+	return job.NewUploadGCJob(svc, 5*time.Minute, lockClient, l)
+}
+
+func InitUniqueViewJob(svc service.InteractiveService,
+	lockClient *rlock.Client, l logger.Logger) *job.UniqueViewJob {
+	return job.NewUniqueViewJob(svc, time.Minute, lockClient, l)
+}
+
+// InitInteractiveReconcileJob register the metrics of the job
+func InitInteractiveReconcileJob(svc service.InteractiveService,
+	lockClient *rlock.Client, l logger.Logger) *job.InteractiveReconcileJob {
+	rcjob := job.NewInteractiveReconcileJob(svc, 30*time.Minute, lockClient, l)
+	prometheus.MustRegister(rcjob)
+	return rcjob
+}
+
+func InitSearchIndexJob(svc service.SearchService) *job.SearchIndexJob {
+	return job.NewSearchIndexJob(svc, time.Minute)
+}
+
+func InitJobs(l logger.Logger, rjob *job.RankingJob,
+	pjob *job.ScheduledPublishJob, sjob *job.SearchIndexJob,
+	tjob *job.TrashPurgeJob, ujob *job.UploadGCJob,
+	uvjob *job.UniqueViewJob, rcjob *job.InteractiveReconcileJob) *cron.Cron {
 	builder := job.NewCronJobBuilder(l, prometheus.SummaryOpts{
 		Namespace: "webook",
 		Subsystem: "cronjob",
@@ -33,5 +68,29 @@ func InitJobs(l logger.Logger, rjob *job.RankingJob) *cron.Cron {
 	if err != nil {
 		panic(err)
 	}
+	_, err = expr.AddJob("@every 10s", builder.Build(pjob))
+	if err != nil {
+		panic(err)
+	}
+	_, err = expr.AddJob("@every 5m", builder.Build(sjob))
+	if err != nil {
+		panic(err)
+	}
+	_, err = expr.AddJob("@every 1h", builder.Build(tjob))
+	if err != nil {
+		panic(err)
+	}
+	_, err = expr.AddJob("@every 6h", builder.Build(ujob))
+	if err != nil {
+		panic(err)
+	}
+	_, err = expr.AddJob("@every 5m", builder.Build(uvjob))
+	if err != nil {
+		panic(err)
+	}
+	_, err = expr.AddJob("@daily", builder.Build(rcjob))
+	if err != nil {
+		panic(err)
+	}
 	return expr
 }
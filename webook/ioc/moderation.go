@@ -0,0 +1,28 @@
+package ioc
+
+import (
+	"webook/webook/pkg/logger"
+	"webook/webook/pkg/moderation"
+
+	"github.com/spf13/viper"
+)
+
+// InitModerationFilter load the sensitive words from moderation.file,
+// the file is watched and loaded again on changes.
+// Nothing is filtered if it's not configured
+func InitModerationFilter(l logger.Logger) *moderation.Filter {
+	f := moderation.NewFilter(nil)
+	path := viper.GetString("moderation.file")
+	if path == "" {
+		return f
+	}
+	err := moderation.WatchFile(path, f, func(err error) {
+		l.Error("Failed to reload moderation words",
+			logger.Error(err),
+			logger.String("file", path))
+	})
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
@@ -0,0 +1,31 @@
+package ioc
+
+import (
+	"context"
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+func InitMongoDB() *mongo.Database {
+	type Config struct {
+		URI      string
+		Database string
+	}
+	cfg := Config{
+		Database: "webook",
+	}
+	err := viper.UnmarshalKey("mongodb", &cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.URI))
+	if err != nil {
+		panic(err)
+	}
+	return client.Database(cfg.Database)
+}
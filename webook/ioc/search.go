@@ -0,0 +1,24 @@
+package ioc
+
+import (
+	"context"
+	"time"
+	"webook/webook/internal/repository"
+	"webook/webook/internal/service"
+	"webook/webook/pkg/logger"
+)
+
+func InitSearchService(repo repository.SearchRepository,
+	artiRepo repository.ArticleReaderRepository, l logger.Logger) service.SearchService {
+	svc := service.NewIndexSearchService(repo, artiRepo, l)
+	// Build the index in background, the search returns partial results until it's done
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		err := svc.Rebuild(ctx)
+		if err != nil {
+			l.Error("Failed to build search index", logger.Error(err))
+		}
+	}()
+	return svc
+}
@@ -0,0 +1,97 @@
+package ioc
+
+import (
+	"webook/webook/internal/repository"
+	"webook/webook/internal/repository/dao"
+	"webook/webook/internal/service"
+	"webook/webook/pkg/logger"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// localUploadPath is where the local storage is served
+const localUploadPath = "/uploads"
+
+// The storage is picked by upload.storage, "local" (default) or "s3".
+// Any S3 compatible storage such as MinIO works with upload.s3.endpoint
+type uploadConfig struct {
+	Storage string
+	// MaxSize is the max size of a file in bytes, 10MB by default
+	MaxSize int64
+	Local   struct {
+		Dir string
+		// BaseURL is the prefix of the urls, localUploadPath by default
+		BaseURL string
+	}
+	S3 struct {
+		Endpoint  string
+		Region    string
+		Bucket    string
+		AccessKey string
+		SecretKey string
+		BaseURL   string
+	}
+}
+
+func initUploadConfig() uploadConfig {
+	var cfg uploadConfig
+	err := viper.UnmarshalKey("upload", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = 10 << 20
+	}
+	if cfg.Local.Dir == "" {
+		cfg.Local.Dir = "./uploads"
+	}
+	if cfg.Local.BaseURL == "" {
+		cfg.Local.BaseURL = localUploadPath
+	}
+	return cfg
+}
+
+func InitObjectStorage() dao.ObjectStorage {
+	cfg := initUploadConfig()
+	switch cfg.Storage {
+	case "", "local":
+		return dao.NewLocalObjectStorage(cfg.Local.Dir, cfg.Local.BaseURL)
+	case "s3":
+		sess, err := session.NewSession(&aws.Config{
+			Endpoint: aws.String(cfg.S3.Endpoint),
+			Region:   aws.String(cfg.S3.Region),
+			Credentials: credentials.NewStaticCredentials(cfg.S3.AccessKey,
+				cfg.S3.SecretKey, ""),
+			// MinIO doesn't support the virtual hosted style
+			S3ForcePathStyle: aws.Bool(true),
+		})
+		if err != nil {
+			panic(err)
+		}
+		return dao.NewS3ObjectStorage(s3.New(sess), cfg.S3.Bucket, cfg.S3.BaseURL)
+	default:
+		panic("unknown upload storage: " + cfg.Storage)
+	}
+}
+
+func InitUploadService(repo repository.UploadRepository,
+	authorRepo repository.ArticleAuthorRepository,
+	readerRepo repository.ArticleReaderRepository,
+	collabRepo repository.CollaboratorRepository,
+	l logger.Logger) service.UploadService {
+	cfg := initUploadConfig()
+	return service.NewImplUploadService(repo, authorRepo, readerRepo, collabRepo, cfg.MaxSize, l)
+}
+
+// registerUploadStatic serve the files of the local storage
+func registerUploadStatic(server *gin.Engine) {
+	cfg := initUploadConfig()
+	if cfg.Storage == "" || cfg.Storage == "local" {
+		server.Static(localUploadPath, cfg.Local.Dir)
+	}
+}
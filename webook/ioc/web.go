@@ -16,20 +16,50 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
 	otelgin "go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 func InitWebServer(middlewareFuncs []gin.HandlerFunc,
 	userHandler *web.UserHandler, wechatHandler *web.OAuth2WechatHandler,
-	artiHandler *web.ArticleHandler) *gin.Engine {
+	artiHandler *web.ArticleHandler, uploadHandler *web.UploadHandler,
+	seriesHandler *web.SeriesHandler, collabHandler *web.CollaboratorHandler,
+	feedHandler *web.FeedHandler, moderationHandler *web.ModerationHandler,
+	archiveHandler *web.ArticleArchiveHandler,
+	shareHandler *web.ArticleShareHandler,
+	collectionHandler *web.CollectionHandler,
+	likeHandler *web.LikeHandler,
+	interAdminHandler *web.InteractiveAdminHandler) *gin.Engine {
 	server := gin.Default()
 	server.Use(middlewareFuncs...)
 	userHandler.RegisterRoutes(server)
 	wechatHandler.RegisterRoutes(server)
 	artiHandler.RegisterRoutes(server)
+	uploadHandler.RegisterRoutes(server)
+	seriesHandler.RegisterRoutes(server)
+	collabHandler.RegisterRoutes(server)
+	feedHandler.RegisterRoutes(server)
+	archiveHandler.RegisterRoutes(server)
+	shareHandler.RegisterRoutes(server)
+	collectionHandler.RegisterRoutes(server)
+	likeHandler.RegisterRoutes(server)
+	admin := server.Group("/admin", initAdminMiddleware())
+	moderationHandler.RegisterRoutes(admin)
+	interAdminHandler.RegisterRoutes(admin)
+	registerUploadStatic(server)
 	return server
 }
 
+// initAdminMiddleware read the admins from admin.uids
+func initAdminMiddleware() gin.HandlerFunc {
+	var uids []int64
+	err := viper.UnmarshalKey("admin.uids", &uids)
+	if err != nil {
+		panic(err)
+	}
+	return middleware.NewAdminMiddlewareBuilder(uids).Build()
+}
+
 func InitMiddleware(redisdb redis.Cmdable, hdl ijwt.Handler, lger logger.Logger) []gin.HandlerFunc {
 	ginx.InitCounter(prometheus.CounterOpts{
 		Namespace: "webook",
@@ -1,11 +1,19 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 	"webook/webook/config"
+	"webook/webook/internal/domain"
 	"webook/webook/ioc"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/viper"
 	"go.uber.org/zap"
 )
 
@@ -15,6 +23,7 @@ func main() {
 	// Init CONFIG, LOGGER, APP, PROMETHEUS
 	config.InitConfig()
 	initLogger()
+	initArticle()
 	app := InitWebServer()
 	initPrometheus()
 
@@ -35,11 +44,26 @@ func main() {
 	app.cron.Start()
 	defer app.cron.Stop()
 
-	// Run Server
-	server := app.server
-	err := server.Run(":8080")
-	if err != nil {
-		panic(err)
+	// Run Server until it's stopped
+	server := &http.Server{Addr: ":8080", Handler: app.server}
+	go func() {
+		err := server.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			panic(err)
+		}
+	}()
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		zap.L().Error("failed to shutdown server", zap.Error(err))
+	}
+	// The buffered views are written before exit
+	if err := app.views.Close(ctx); err != nil {
+		zap.L().Error("failed to flush view counts", zap.Error(err))
 	}
 }
 
@@ -51,6 +75,13 @@ func initLogger() {
 	zap.ReplaceGlobals(logger)
 }
 
+func initArticle() {
+	// The length of the abstracts in the lists
+	if n := viper.GetInt("article.abstractLength"); n > 0 {
+		domain.AbstractLength = n
+	}
+}
+
 func initPrometheus() {
 	go func() {
 		http.Handle("/metrics", promhttp.Handler())
@@ -0,0 +1,77 @@
+package feed
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var testFeed = Feed{
+	Title:       "webook",
+	Link:        "https://webook.example.com",
+	Description: "Latest articles",
+	Self:        "https://webook.example.com/feed/rss.xml",
+	Updated:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	Items: []Item{
+		{
+			Id:        "https://webook.example.com/articles/1",
+			Title:     "Go & <XML>",
+			Link:      "https://webook.example.com/articles/1",
+			Author:    "Tom",
+			Summary:   "a < b",
+			Published: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Updated:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+	},
+}
+
+func TestRSS(t *testing.T) {
+	res, err := RSS(testFeed)
+	assert.NoError(t, err)
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:atom="http://www.w3.org/2005/Atom">
+  <channel>
+    <title>webook</title>
+    <link>https://webook.example.com</link>
+    <description>Latest articles</description>
+    <atom:link href="https://webook.example.com/feed/rss.xml" rel="self" type="application/rss+xml"></atom:link>
+    <lastBuildDate>Tue, 02 Jan 2024 03:04:05 +0000</lastBuildDate>
+    <item>
+      <title>Go &amp; &lt;XML&gt;</title>
+      <link>https://webook.example.com/articles/1</link>
+      <guid isPermaLink="false">https://webook.example.com/articles/1</guid>
+      <dc:creator>Tom</dc:creator>
+      <description>a &lt; b</description>
+      <pubDate>Mon, 01 Jan 2024 00:00:00 +0000</pubDate>
+    </item>
+  </channel>
+</rss>`
+	assert.Equal(t, want, string(res))
+}
+
+func TestAtom(t *testing.T) {
+	f := testFeed
+	f.Self = "https://webook.example.com/feed/atom.xml"
+	res, err := Atom(f)
+	assert.NoError(t, err)
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>webook</title>
+  <id>https://webook.example.com/feed/atom.xml</id>
+  <link href="https://webook.example.com"></link>
+  <link href="https://webook.example.com/feed/atom.xml" rel="self" type="application/atom+xml"></link>
+  <updated>2024-01-02T03:04:05Z</updated>
+  <entry>
+    <title>Go &amp; &lt;XML&gt;</title>
+    <id>https://webook.example.com/articles/1</id>
+    <link href="https://webook.example.com/articles/1"></link>
+    <author>
+      <name>Tom</name>
+    </author>
+    <summary>a &lt; b</summary>
+    <published>2024-01-01T00:00:00Z</published>
+    <updated>2024-01-02T03:04:05Z</updated>
+  </entry>
+</feed>`
+	assert.Equal(t, want, string(res))
+}
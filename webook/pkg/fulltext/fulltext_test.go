@@ -0,0 +1,100 @@
+package fulltext
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	testCases := []struct {
+		name string
+		text string
+		// tokenize as a document
+		doc bool
+
+		want []string
+	}{
+		{
+			name: "latin",
+			text: "Hello, Go-Lang 2024!",
+			want: []string{"hello", "go", "lang", "2024"},
+		},
+		{
+			name: "cjk bigram",
+			text: "全文检索",
+			want: []string{"全文", "文检", "检索"},
+		},
+		{
+			name: "cjk document",
+			text: "全文检索",
+			doc:  true,
+			want: []string{"全", "全文", "文", "文检", "检", "检索", "索"},
+		},
+		{
+			name: "single cjk",
+			text: "用 Go",
+			want: []string{"用", "go"},
+		},
+		{
+			name: "mixed",
+			text: "Redis缓存",
+			want: []string{"redis", "缓存"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tokenizeFn := Tokenize
+			if tc.doc {
+				tokenizeFn = tokenizeDoc
+			}
+			var terms []string
+			for _, tok := range tokenizeFn(tc.text) {
+				terms = append(terms, tok.Term)
+				// offsets must point to the term in the original text
+				assert.Equal(t, tok.Term, strings.ToLower(tc.text[tok.Start:tok.End]))
+			}
+			assert.Equal(t, tc.want, terms)
+		})
+	}
+}
+
+func TestIndex_Search(t *testing.T) {
+	idx := NewIndex()
+	idx.Put(Document{Id: 1, Title: "Go 并发编程", Content: "goroutine 和 channel 的使用"})
+	idx.Put(Document{Id: 2, Title: "Redis 缓存", Content: "在 Go 中使用 Redis 做缓存"})
+	idx.Put(Document{Id: 3, Title: "MySQL 索引", Content: "B+ 树 <b>索引</b>"})
+
+	hits, total := idx.Search("go", 0, 10)
+	assert.Equal(t, 2, total)
+	// title matches rank higher
+	assert.Equal(t, int64(1), hits[0].Id)
+	assert.Equal(t, "<em>Go</em> 并发编程", hits[0].Title)
+	assert.Equal(t, int64(2), hits[1].Id)
+	assert.Equal(t, "在 <em>Go</em> 中使用 Redis 做缓存", hits[1].Snippet)
+
+	hits, total = idx.Search("索引", 0, 10)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "B+ 树 &lt;b&gt;<em>索引</em>&lt;/b&gt;", hits[0].Snippet)
+
+	// a single CJK character matches inside the words
+	hits, total = idx.Search("存", 0, 10)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "Redis 缓<em>存</em>", hits[0].Title)
+
+	// pagination
+	hits, total = idx.Search("go redis", 1, 1)
+	assert.Equal(t, 2, total)
+	assert.Len(t, hits, 1)
+	assert.Equal(t, int64(1), hits[0].Id)
+
+	// replace and delete
+	idx.Put(Document{Id: 2, Title: "Kafka", Content: "消息队列"})
+	_, total = idx.Search("redis", 0, 10)
+	assert.Equal(t, 0, total)
+	idx.Delete(1)
+	_, total = idx.Search("go", 0, 10)
+	assert.Equal(t, 0, total)
+	assert.Equal(t, 2, idx.Len())
+}
@@ -0,0 +1,239 @@
+package fulltext
+
+import (
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// BM25 parameters
+	k1 = 1.2
+	b  = 0.75
+
+	titleWeight   = 3
+	contentWeight = 1
+
+	snippetBefore = 30
+	snippetLen    = 120
+)
+
+type Document struct {
+	Id      int64
+	Title   string
+	Content string
+	// Payload is returned as it is in the hits
+	Payload any
+}
+
+type Hit struct {
+	Id    int64
+	Score float64
+	// Title and Snippet are html escaped, the matched terms are in <em>
+	Title   string
+	Snippet string
+	Payload any
+}
+
+type entry struct {
+	doc Document
+	tf  map[string]float64
+	len float64
+}
+
+// Index is an in-memory inverted index ranking documents by BM25,
+// title terms weigh more than content terms. It is safe for concurrent use.
+type Index struct {
+	mu       sync.RWMutex
+	docs     map[int64]*entry
+	postings map[string]map[int64]float64
+	totalLen float64
+}
+
+func NewIndex() *Index {
+	return &Index{
+		docs:     make(map[int64]*entry),
+		postings: make(map[string]map[int64]float64),
+	}
+}
+
+// Put add the document, or replace it if the id exists
+func (idx *Index) Put(doc Document) {
+	e := &entry{
+		doc: doc,
+		tf:  make(map[string]float64),
+	}
+	for _, tok := range tokenizeDoc(doc.Title) {
+		e.tf[tok.Term] += titleWeight
+		e.len += titleWeight
+	}
+	for _, tok := range tokenizeDoc(doc.Content) {
+		e.tf[tok.Term] += contentWeight
+		e.len += contentWeight
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.delete(doc.Id)
+	idx.docs[doc.Id] = e
+	idx.totalLen += e.len
+	for term, tf := range e.tf {
+		posting, ok := idx.postings[term]
+		if !ok {
+			posting = make(map[int64]float64)
+			idx.postings[term] = posting
+		}
+		posting[doc.Id] = tf
+	}
+}
+
+func (idx *Index) Delete(id int64) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.delete(id)
+}
+
+// Replace drop all the documents and index docs instead
+func (idx *Index) Replace(docs []Document) {
+	fresh := NewIndex()
+	for _, doc := range docs {
+		fresh.Put(doc)
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.docs = fresh.docs
+	idx.postings = fresh.postings
+	idx.totalLen = fresh.totalLen
+}
+
+func (idx *Index) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.docs)
+}
+
+// Search return a page of the documents matching any term of the query,
+// and the total number of the matched documents
+func (idx *Index) Search(query string, offset, limit int) ([]Hit, int) {
+	terms := make(map[string]struct{})
+	for _, tok := range Tokenize(query) {
+		terms[tok.Term] = struct{}{}
+	}
+	if len(terms) == 0 {
+		return nil, 0
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	n := float64(len(idx.docs))
+	avgLen := idx.totalLen / math.Max(n, 1)
+	scores := make(map[int64]float64)
+	for term := range terms {
+		posting := idx.postings[term]
+		df := float64(len(posting))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for id, tf := range posting {
+			dl := idx.docs[id].len
+			scores[id] += idf * tf * (k1 + 1) / (tf + k1*(1-b+b*dl/avgLen))
+		}
+	}
+
+	ids := make([]int64, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] > ids[j]
+	})
+
+	total := len(ids)
+	if offset >= total || limit <= 0 {
+		return []Hit{}, total
+	}
+	ids = ids[offset:min(offset+limit, total)]
+
+	hits := make([]Hit, 0, len(ids))
+	for _, id := range ids {
+		doc := idx.docs[id].doc
+		hits = append(hits, Hit{
+			Id:      id,
+			Score:   scores[id],
+			Title:   Highlight(doc.Title, terms),
+			Snippet: snippet(doc.Content, terms),
+			Payload: doc.Payload,
+		})
+	}
+	return hits, total
+}
+
+func (idx *Index) delete(id int64) {
+	e, ok := idx.docs[id]
+	if !ok {
+		return
+	}
+	for term := range e.tf {
+		posting := idx.postings[term]
+		delete(posting, id)
+		if len(posting) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+	idx.totalLen -= e.len
+	delete(idx.docs, id)
+}
+
+// Highlight escape text and wrap the terms in <em></em>
+func Highlight(text string, terms map[string]struct{}) string {
+	var sb strings.Builder
+	last := 0
+	for _, span := range matchedSpans(text, terms) {
+		sb.WriteString(html.EscapeString(text[last:span[0]]))
+		sb.WriteString("<em>")
+		sb.WriteString(html.EscapeString(text[span[0]:span[1]]))
+		sb.WriteString("</em>")
+		last = span[1]
+	}
+	sb.WriteString(html.EscapeString(text[last:]))
+	return sb.String()
+}
+
+// snippet cut a piece of content around the first matched term
+func snippet(content string, terms map[string]struct{}) string {
+	start := 0
+	if spans := matchedSpans(content, terms); len(spans) > 0 {
+		start = runeOffset(content, spans[0][0], -snippetBefore)
+	}
+	end := runeOffset(content, start, snippetLen)
+
+	res := Highlight(content[start:end], terms)
+	if start > 0 {
+		res = "..." + res
+	}
+	if end < len(content) {
+		res = res + "..."
+	}
+	return res
+}
+
+// matchedSpans return the merged byte ranges of the matched tokens
+func matchedSpans(text string, terms map[string]struct{}) [][2]int {
+	var spans [][2]int
+	for _, tok := range tokenizeDoc(text) {
+		if _, ok := terms[tok.Term]; !ok {
+			continue
+		}
+		if l := len(spans); l > 0 && tok.Start <= spans[l-1][1] {
+			spans[l-1][1] = max(spans[l-1][1], tok.End)
+			continue
+		}
+		spans = append(spans, [2]int{tok.Start, tok.End})
+	}
+	return spans
+}
@@ -0,0 +1,124 @@
+package fulltext
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Token is a term with its byte offsets in the original text
+type Token struct {
+	Term  string
+	Start int
+	End   int
+}
+
+// Tokenize split text into lower-cased terms. Latin letters and digits are
+// split into words, CJK characters are split into overlapping bigrams, so
+// "全文检索" matches both "全文" and "检索" without a dictionary.
+func Tokenize(text string) []Token {
+	return tokenize(text, false)
+}
+
+// tokenizeDoc is Tokenize with the single CJK characters as well,
+// the documents are indexed with them so a one character query matches
+func tokenizeDoc(text string) []Token {
+	return tokenize(text, true)
+}
+
+func tokenize(text string, unigrams bool) []Token {
+	var tokens []Token
+
+	// start of the current latin word, -1 means not in a word
+	wordStart := -1
+	// runes of the current CJK run, with their byte offsets
+	var cjk []int
+
+	flushWord := func(end int) {
+		if wordStart >= 0 {
+			tokens = append(tokens, Token{
+				Term:  strings.ToLower(text[wordStart:end]),
+				Start: wordStart,
+				End:   end,
+			})
+			wordStart = -1
+		}
+	}
+	flushCJK := func(end int) {
+		switch len(cjk) {
+		case 0:
+			return
+		case 1:
+			tokens = append(tokens, Token{
+				Term:  text[cjk[0]:end],
+				Start: cjk[0],
+				End:   end,
+			})
+		default:
+			for i := range cjk {
+				// end of the i-th and the (i+1)-th rune
+				e1, e2 := end, end
+				if i+1 < len(cjk) {
+					e1 = cjk[i+1]
+				}
+				if i+2 < len(cjk) {
+					e2 = cjk[i+2]
+				}
+				if unigrams {
+					tokens = append(tokens, Token{
+						Term:  text[cjk[i]:e1],
+						Start: cjk[i],
+						End:   e1,
+					})
+				}
+				if i+1 < len(cjk) {
+					tokens = append(tokens, Token{
+						Term:  text[cjk[i]:e2],
+						Start: cjk[i],
+						End:   e2,
+					})
+				}
+			}
+		}
+		cjk = cjk[:0]
+	}
+
+	for i, r := range text {
+		switch {
+		case isCJK(r):
+			flushWord(i)
+			cjk = append(cjk, i)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK(i)
+			if wordStart < 0 {
+				wordStart = i
+			}
+		default:
+			flushWord(i)
+			flushCJK(i)
+		}
+	}
+	flushWord(len(text))
+	flushCJK(len(text))
+	return tokens
+}
+
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		unicode.Is(unicode.Hangul, r)
+}
+
+// runeOffset return the byte offset of the n-th rune before or after pos
+func runeOffset(text string, pos int, n int) int {
+	for ; n < 0 && pos > 0; n++ {
+		_, size := utf8.DecodeLastRuneInString(text[:pos])
+		pos -= size
+	}
+	for ; n > 0 && pos < len(text); n-- {
+		_, size := utf8.DecodeRuneInString(text[pos:])
+		pos += size
+	}
+	return pos
+}
@@ -0,0 +1,108 @@
+package linediff
+
+import (
+	"errors"
+	"strings"
+)
+
+type Op string
+
+const (
+	OpEqual  Op = "equal"
+	OpInsert Op = "insert"
+	OpDelete Op = "delete"
+)
+
+type Line struct {
+	Op   Op     `json:"op"`
+	Text string `json:"text"`
+}
+
+// ErrTooLarge is returned when the lines changed are too many to diff
+var ErrTooLarge = errors.New("too large to diff")
+
+// maxCells caps the LCS table of the changed lines, 16MB of int32
+const maxCells = 1 << 22
+
+// Diff compares src and dst line by line and returns the edit script
+// that turns src into dst, based on the longest common subsequence.
+// The common prefix and suffix are trimmed first, ErrTooLarge is
+// returned if the lines left still need a too large table
+func Diff(src, dst string) ([]Line, error) {
+	a, b := split(src), split(dst)
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	n, m := len(a)-prefix-suffix, len(b)-prefix-suffix
+	if int64(n+1)*int64(m+1) > maxCells {
+		return nil, ErrTooLarge
+	}
+
+	res := make([]Line, 0, max(len(a), len(b)))
+	for _, line := range a[:prefix] {
+		res = append(res, Line{Op: OpEqual, Text: line})
+	}
+	res = appendLCS(res, a[prefix:prefix+n], b[prefix:prefix+m])
+	for _, line := range a[prefix+n:] {
+		res = append(res, Line{Op: OpEqual, Text: line})
+	}
+	return res, nil
+}
+
+// appendLCS append the edit script of a into b to res
+func appendLCS(res []Line, a, b []string) []Line {
+	n, m := len(a), len(b)
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int32, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			res = append(res, Line{Op: OpEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			res = append(res, Line{Op: OpDelete, Text: a[i]})
+			i++
+		default:
+			res = append(res, Line{Op: OpInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		res = append(res, Line{Op: OpDelete, Text: a[i]})
+	}
+	for ; j < m; j++ {
+		res = append(res, Line{Op: OpInsert, Text: b[j]})
+	}
+	return res
+}
+
+func split(s string) []string {
+	if s == "" {
+		return nil
+	}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.Split(s, "\n")
+}
@@ -0,0 +1,85 @@
+package linediff
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  string
+		dst  string
+
+		want []Line
+	}{
+		{
+			name: "same",
+			src:  "a\nb",
+			dst:  "a\nb",
+			want: []Line{
+				{Op: OpEqual, Text: "a"},
+				{Op: OpEqual, Text: "b"},
+			},
+		},
+		{
+			name: "empty src",
+			src:  "",
+			dst:  "a",
+			want: []Line{
+				{Op: OpInsert, Text: "a"},
+			},
+		},
+		{
+			name: "empty dst",
+			src:  "a\r\nb",
+			dst:  "",
+			want: []Line{
+				{Op: OpDelete, Text: "a"},
+				{Op: OpDelete, Text: "b"},
+			},
+		},
+		{
+			name: "modify middle line",
+			src:  "a\nb\nc",
+			dst:  "a\nx\nc\nd",
+			want: []Line{
+				{Op: OpEqual, Text: "a"},
+				{Op: OpDelete, Text: "b"},
+				{Op: OpInsert, Text: "x"},
+				{Op: OpEqual, Text: "c"},
+				{Op: OpInsert, Text: "d"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Diff(tc.src, tc.dst)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func TestDiff_TooLarge(t *testing.T) {
+	lines := func(prefix string, n int) string {
+		res := make([]string, n)
+		for i := range res {
+			res[i] = prefix + strconv.Itoa(i)
+		}
+		return strings.Join(res, "\n")
+	}
+	same := lines("same", 10000)
+
+	// Only the changed lines count
+	res, err := Diff(same+"\na", same+"\nb")
+	require.NoError(t, err)
+	assert.Len(t, res, 10002)
+
+	_, err = Diff(lines("a", 3000), lines("b", 3000))
+	assert.ErrorIs(t, err, ErrTooLarge)
+}
@@ -27,3 +27,10 @@ func String(key string, value string) Field {
 		Value: value,
 	}
 }
+
+func Bool(key string, value bool) Field {
+	return Field{
+		Key:   key,
+		Value: value,
+	}
+}
@@ -0,0 +1,58 @@
+package markdown
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+var (
+	md = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+	// policy is the allowlist of the rendered html, based on the
+	// user generated content policy of bluemonday
+	policy = newPolicy()
+	// textPolicy strips all the tags
+	textPolicy = bluemonday.StrictPolicy().AddSpaceWhenStrippingTag(true)
+)
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	// The language of fenced code blocks, for highlighting
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).
+		OnElements("code")
+	// The checkboxes of task lists
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	return p
+}
+
+// Render convert the markdown to html, which is safe to be put into pages.
+// Raw html in the markdown is dropped
+func Render(src string) string {
+	var buf bytes.Buffer
+	// Writing to a buffer never fails
+	_ = md.Convert([]byte(src), &buf)
+	return policy.Sanitize(buf.String())
+}
+
+// PlainText return the text of the rendered markdown,
+// the whitespaces are collapsed into single spaces
+func PlainText(src string) string {
+	text := html.UnescapeString(textPolicy.Sanitize(Render(src)))
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// Abstract return the first n runes of the plain text
+func Abstract(src string, n int) string {
+	text := []rune(PlainText(src))
+	if len(text) > n {
+		return string(text[:n])
+	}
+	return string(text)
+}
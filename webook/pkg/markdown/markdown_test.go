@@ -0,0 +1,57 @@
+package markdown
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "basic",
+			src:  "# Title\n\nSome **bold** text",
+			want: "<h1>Title</h1>\n<p>Some <strong>bold</strong> text</p>\n",
+		},
+		{
+			name: "code block",
+			src:  "```go\nfmt.Println(\"<hi>\")\n```",
+			want: "<pre><code class=\"language-go\">fmt.Println(&#34;&lt;hi&gt;&#34;)\n</code></pre>\n",
+		},
+		{
+			name: "raw html",
+			src:  "hello <script>alert(1)</script>",
+			want: "<p>hello alert(1)</p>\n",
+		},
+		{
+			name: "javascript link",
+			src:  "[click](javascript:alert(1))",
+			want: "<p>click</p>\n",
+		},
+		{
+			name: "image event handler",
+			src:  "![x](/a.png \"t\" onerror=alert(1))",
+			want: "<p>![x](/a.png &#34;t&#34; onerror=alert(1))</p>\n",
+		},
+		{
+			name: "link",
+			src:  "[webook](https://example.com)",
+			want: "<p><a href=\"https://example.com\" rel=\"nofollow\">webook</a></p>\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Render(tc.src))
+		})
+	}
+}
+
+func TestAbstract(t *testing.T) {
+	src := "# Title\n\nSome **bold** text & more\n\n```go\nfunc main() {}\n```\n\n- a\n- b"
+	assert.Equal(t, "Title Some bold text & more func main() {} a b", PlainText(src))
+	assert.Equal(t, "Title Some", Abstract(src, 10))
+	assert.Equal(t, "你好 世界", Abstract("**你好**\n\n世界", 10))
+}
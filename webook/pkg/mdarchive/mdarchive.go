@@ -0,0 +1,248 @@
+// Package mdarchive read and write zip archives of Markdown files with
+// YAML front matter, the format most blog platforms import and export
+package mdarchive
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrFrontMatter  = errors.New("invalid front matter")
+	ErrFileTooLarge = errors.New("file is too large")
+	ErrNotMarkdown  = errors.New("not a markdown file")
+	ErrTooManyFiles = errors.New("too many files")
+)
+
+const delimiter = "---"
+
+type FrontMatter struct {
+	Title  string    `yaml:"title"`
+	Status string    `yaml:"status,omitempty"`
+	Ctime  time.Time `yaml:"ctime,omitempty"`
+	Utime  time.Time `yaml:"utime,omitempty"`
+	Tags   []string  `yaml:"tags,omitempty"`
+}
+
+type Document struct {
+	FrontMatter
+	Content string
+}
+
+// Marshal put the front matter before the content
+func Marshal(doc Document) ([]byte, error) {
+	meta, err := yaml.Marshal(doc.FrontMatter)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteString(delimiter + "\n")
+	buf.Write(meta)
+	buf.WriteString(delimiter + "\n\n")
+	buf.WriteString(doc.Content)
+	return buf.Bytes(), nil
+}
+
+// Unmarshal split the front matter from the content, a file without
+// front matter is all content
+func Unmarshal(data []byte) (Document, error) {
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if !strings.HasPrefix(text, delimiter+"\n") {
+		return Document{Content: text}, nil
+	}
+	meta, content, ok := cutFrontMatter(text[len(delimiter)+1:])
+	if !ok {
+		return Document{}, fmt.Errorf("%w: no closing %s", ErrFrontMatter, delimiter)
+	}
+
+	var doc Document
+	err := yaml.Unmarshal([]byte(meta), &doc.FrontMatter)
+	if err != nil {
+		return Document{}, fmt.Errorf("%w: %v", ErrFrontMatter, err)
+	}
+	doc.Content = strings.TrimPrefix(content, "\n")
+	return doc, nil
+}
+
+// cutFrontMatter split the text after the opening delimiter
+// at the closing one
+func cutFrontMatter(text string) (meta, content string, ok bool) {
+	if text == delimiter || strings.HasPrefix(text, delimiter+"\n") {
+		return "", strings.TrimPrefix(text, delimiter), true
+	}
+	meta, content, ok = strings.Cut(text, "\n"+delimiter+"\n")
+	if ok {
+		return meta, content, true
+	}
+	meta, ok = strings.CutSuffix(text, "\n"+delimiter)
+	return meta, "", ok
+}
+
+// Writer write the documents into a zip one by one,
+// so an archive of any size is streamed
+type Writer struct {
+	zw    *zip.Writer
+	names map[string]struct{}
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{
+		zw:    zip.NewWriter(w),
+		names: make(map[string]struct{}),
+	}
+}
+
+// Write add the document as the slug of name with ".md",
+// a number is appended to the duplicate names
+func (w *Writer) Write(name string, doc Document) error {
+	data, err := Marshal(doc)
+	if err != nil {
+		return err
+	}
+	name = w.uniqueName(Slug(name))
+	fw, err := w.zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: doc.Utime,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fw.Write(data)
+	return err
+}
+
+func (w *Writer) uniqueName(name string) string {
+	res := name + ".md"
+	for i := 2; ; i++ {
+		if _, ok := w.names[res]; !ok {
+			break
+		}
+		res = fmt.Sprintf("%s-%d.md", name, i)
+	}
+	w.names[res] = struct{}{}
+	return res
+}
+
+// Close finish the zip, it doesn't close the underlying writer
+func (w *Writer) Close() error {
+	return w.zw.Close()
+}
+
+// File is a file read from the archive, Err is why it can't be read
+type File struct {
+	Name string
+	Doc  Document
+	Err  error
+}
+
+// Walk read the markdown files in the archive one by one and pass them to
+// fn, so only one of them is in memory at a time. The other files are
+// passed with ErrNotMarkdown, directories and hidden files such as
+// __MACOSX are skipped. Files larger than maxSize are not read.
+// ErrTooManyFiles is returned before any file is read if there are
+// more than maxFiles, an error of fn stops the walk and is returned
+func Walk(r io.ReaderAt, size int64, maxFiles int, maxSize int64, fn func(File) error) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+	files := make([]*zip.File, 0, len(zr.File))
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || hidden(f.Name) {
+			continue
+		}
+		if len(files) == maxFiles {
+			return ErrTooManyFiles
+		}
+		files = append(files, f)
+	}
+	for _, f := range files {
+		file := File{Name: f.Name}
+		file.Doc, file.Err = readFile(f, maxSize)
+		err = fn(file)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read is Walk returning all the files, it's for the small archives
+func Read(r io.ReaderAt, size int64, maxFiles int, maxSize int64) ([]File, error) {
+	var res []File
+	err := Walk(r, size, maxFiles, maxSize, func(f File) error {
+		res = append(res, f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func readFile(f *zip.File, maxSize int64) (Document, error) {
+	ext := strings.ToLower(path.Ext(f.Name))
+	if ext != ".md" && ext != ".markdown" {
+		return Document{}, ErrNotMarkdown
+	}
+	// The size in the header may lie, so the reading is limited as well
+	if f.UncompressedSize64 > uint64(maxSize) {
+		return Document{}, ErrFileTooLarge
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return Document{}, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, maxSize+1))
+	if err != nil {
+		return Document{}, err
+	}
+	if int64(len(data)) > maxSize {
+		return Document{}, ErrFileTooLarge
+	}
+	return Unmarshal(data)
+}
+
+func hidden(name string) bool {
+	for _, part := range strings.Split(name, "/") {
+		if strings.HasPrefix(part, ".") || strings.HasPrefix(part, "__") {
+			return true
+		}
+	}
+	return false
+}
+
+// Slug turn the title into a file name, keeping the letters and digits
+// of any language
+func Slug(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	res := strings.TrimSuffix(b.String(), "-")
+	if res == "" {
+		return "untitled"
+	}
+	return res
+}
@@ -0,0 +1,168 @@
+package mdarchive
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestUnmarshal(t *testing.T) {
+	testCases := []struct {
+		name    string
+		src     string
+		want    Document
+		wantErr error
+	}{
+		{
+			name: "front matter",
+			src:  "---\ntitle: Hello\ntags: [go, redis]\n---\n\n# Hello\n",
+			want: Document{
+				FrontMatter: FrontMatter{Title: "Hello", Tags: []string{"go", "redis"}},
+				Content:     "# Hello\n",
+			},
+		},
+		{
+			name: "crlf and bom",
+			src:  "\ufeff---\r\ntitle: Hello\r\n---\r\nbody",
+			want: Document{
+				FrontMatter: FrontMatter{Title: "Hello"},
+				Content:     "body",
+			},
+		},
+		{
+			name: "empty front matter",
+			src:  "---\n---\nbody",
+			want: Document{Content: "body"},
+		},
+		{
+			name: "no content",
+			src:  "---\ntitle: Hello\n---",
+			want: Document{FrontMatter: FrontMatter{Title: "Hello"}},
+		},
+		{
+			name: "no front matter",
+			src:  "# Hello\n---\n",
+			want: Document{Content: "# Hello\n---\n"},
+		},
+		{
+			name:    "not closed",
+			src:     "---\ntitle: Hello\n\nbody",
+			wantErr: ErrFrontMatter,
+		},
+		{
+			name:    "bad yaml",
+			src:     "---\ntitle: [Hello\n---\nbody",
+			wantErr: ErrFrontMatter,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := Unmarshal([]byte(tc.src))
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, doc)
+		})
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	utime := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	doc := Document{
+		FrontMatter: FrontMatter{
+			Title:  "Hello: World",
+			Status: "published",
+			Ctime:  utime.Add(-time.Hour),
+			Utime:  utime,
+			Tags:   []string{"go"},
+		},
+		Content: "---\nnot front matter\n",
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	require.NoError(t, w.Write(doc.Title, doc))
+	require.NoError(t, w.Write(doc.Title, Document{}))
+	require.NoError(t, w.Close())
+
+	files, err := Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()), 10, 1<<10)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, "hello-world.md", files[0].Name)
+	assert.NoError(t, files[0].Err)
+	assert.Equal(t, doc.Title, files[0].Doc.Title)
+	assert.Equal(t, doc.Tags, files[0].Doc.Tags)
+	assert.True(t, utime.Equal(files[0].Doc.Utime))
+	assert.Equal(t, doc.Content, files[0].Doc.Content)
+	assert.Equal(t, "hello-world-2.md", files[1].Name)
+
+	_, err = Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()), 1, 1<<10)
+	assert.ErrorIs(t, err, ErrTooManyFiles)
+}
+
+func TestRead(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range map[string]string{
+		"posts/":              "",
+		"posts/a.md":          "hello",
+		"posts/big.md":        string(bytes.Repeat([]byte("a"), 100)),
+		"posts/image.png":     "png",
+		"__MACOSX/posts/a.md": "junk",
+		".DS_Store":           "junk",
+	} {
+		fw, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	files, err := Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()), 10, 10)
+	require.NoError(t, err)
+	res := make(map[string]File, len(files))
+	for _, f := range files {
+		res[f.Name] = f
+	}
+	assert.Len(t, res, 3)
+	assert.Equal(t, "hello", res["posts/a.md"].Doc.Content)
+	assert.ErrorIs(t, res["posts/big.md"].Err, ErrFileTooLarge)
+	assert.ErrorIs(t, res["posts/image.png"].Err, ErrNotMarkdown)
+}
+
+func TestWalk(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for _, title := range []string{"a", "b", "c"} {
+		require.NoError(t, w.Write(title, Document{Content: title}))
+	}
+	require.NoError(t, w.Close())
+	r, size := bytes.NewReader(buf.Bytes()), int64(buf.Len())
+
+	var names []string
+	stop := errors.New("stop")
+	err := Walk(r, size, 10, 1<<10, func(f File) error {
+		names = append(names, f.Name)
+		if len(names) == 2 {
+			return stop
+		}
+		return nil
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, []string{"a.md", "b.md"}, names)
+
+	// Nothing is passed if there are too many
+	err = Walk(r, size, 2, 1<<10, func(f File) error {
+		t.Fatal("unexpected file", f.Name)
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrTooManyFiles)
+}
+
+func TestSlug(t *testing.T) {
+	assert.Equal(t, "hello-world", Slug(" Hello, World! "))
+	assert.Equal(t, "你好-go", Slug("你好 Go"))
+	assert.Equal(t, "untitled", Slug("!!!"))
+}
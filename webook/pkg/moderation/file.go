@@ -0,0 +1,66 @@
+package moderation
+
+import (
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// WatchFile load the categories from the file into the filter, and load
+// them again when the file changes. The file can be in any format viper
+// reads, such as
+//
+//	categories:
+//	  ads:
+//	    action: mask
+//	    words: ["buy now"]
+//
+// The categories loaded before are kept if the file is broken,
+// onError is called if it has an unknown action
+func WatchFile(path string, f *Filter, onError func(err error)) error {
+	v := viper.New()
+	v.SetConfigFile(path)
+	err := v.ReadInConfig()
+	if err != nil {
+		return err
+	}
+	err = load(v, f)
+	if err != nil {
+		return err
+	}
+	// viper reads the file again before calling back
+	v.OnConfigChange(func(in fsnotify.Event) {
+		err := load(v, f)
+		if err != nil {
+			onError(err)
+		}
+	})
+	v.WatchConfig()
+	return nil
+}
+
+func load(v *viper.Viper, f *Filter) error {
+	type Config struct {
+		Categories map[string]struct {
+			Action string
+			Words  []string
+		}
+	}
+	var cfg Config
+	err := v.Unmarshal(&cfg)
+	if err != nil {
+		return err
+	}
+	categories := make(map[string]Category, len(cfg.Categories))
+	for name, c := range cfg.Categories {
+		action, err := ParseAction(c.Action)
+		if err != nil {
+			return err
+		}
+		categories[name] = Category{
+			Action: action,
+			Words:  c.Words,
+		}
+	}
+	f.Load(categories)
+	return nil
+}
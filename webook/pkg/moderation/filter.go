@@ -0,0 +1,133 @@
+package moderation
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// Action is what to do with a text having the words of a category,
+// the bigger the stricter
+type Action uint8
+
+const (
+	ActionPass Action = iota
+	// ActionMask replace the words with '*'
+	ActionMask
+	// ActionReview send the text to the manual review
+	ActionReview
+	ActionReject
+)
+
+func ParseAction(s string) (Action, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "mask":
+		return ActionMask, nil
+	case "review":
+		return ActionReview, nil
+	case "reject":
+		return ActionReject, nil
+	default:
+		return ActionPass, fmt.Errorf("unknown moderation action %q", s)
+	}
+}
+
+func (a Action) String() string {
+	switch a {
+	case ActionMask:
+		return "mask"
+	case ActionReview:
+		return "review"
+	case ActionReject:
+		return "reject"
+	default:
+		return "pass"
+	}
+}
+
+type Category struct {
+	Action Action
+	Words  []string
+}
+
+type Result struct {
+	// Action is the strictest one of the matched categories
+	Action Action
+	// Text is the text with the words of the mask categories masked
+	Text string
+	// Words is the matched words without duplicates
+	Words []string
+}
+
+// Filter check the texts against the categories of words,
+// the categories can be replaced while it's in use
+type Filter struct {
+	rules atomic.Pointer[rules]
+}
+
+type rules struct {
+	matcher *Matcher
+	actions map[string]Action
+}
+
+func NewFilter(categories map[string]Category) *Filter {
+	f := &Filter{}
+	f.Load(categories)
+	return f
+}
+
+// Load replace the categories, the checks in progress use the old ones
+func (f *Filter) Load(categories map[string]Category) {
+	r := &rules{
+		actions: make(map[string]Action, len(categories)),
+	}
+	var words []Word
+	for name, c := range categories {
+		r.actions[name] = c.Action
+		for _, w := range c.Words {
+			w = strings.TrimSpace(w)
+			if w != "" {
+				words = append(words, Word{Text: w, Category: name})
+			}
+		}
+	}
+	r.matcher = NewMatcher(words)
+	f.rules.Store(r)
+}
+
+func (f *Filter) Check(text string) Result {
+	r := f.rules.Load()
+	res := Result{
+		Action: ActionPass,
+		Text:   text,
+	}
+	matches := r.matcher.Match(text)
+	if len(matches) == 0 {
+		return res
+	}
+
+	var masked []rune
+	seen := make(map[string]struct{}, len(matches))
+	for _, m := range matches {
+		action := r.actions[m.Category]
+		if action > res.Action {
+			res.Action = action
+		}
+		if _, ok := seen[m.Text]; !ok {
+			seen[m.Text] = struct{}{}
+			res.Words = append(res.Words, m.Text)
+		}
+		if action == ActionMask {
+			if masked == nil {
+				masked = []rune(text)
+			}
+			for i := m.Start; i < m.End; i++ {
+				masked[i] = '*'
+			}
+		}
+	}
+	if masked != nil {
+		res.Text = string(masked)
+	}
+	return res
+}
@@ -0,0 +1,125 @@
+// Package moderation find the sensitive words in texts, and decide what
+// to do with the texts by the categories of the words
+package moderation
+
+import "unicode"
+
+// Word is a sensitive word of a category
+type Word struct {
+	Text     string
+	Category string
+}
+
+// Match is a word found in the text, Start and End are the rune offsets
+type Match struct {
+	Word
+	Start int
+	End   int
+}
+
+// Matcher is an Aho-Corasick automaton over the words, it finds all the
+// words in one pass of the text. Words are matched case-insensitively
+type Matcher struct {
+	nodes []node
+	words []Word
+	// lens is the rune count of the words
+	lens []int
+}
+
+type node struct {
+	next map[rune]int
+	fail int
+	// out is the words ending here, including the ones of the fail links
+	out []int
+}
+
+func NewMatcher(words []Word) *Matcher {
+	m := &Matcher{
+		nodes: []node{{next: map[rune]int{}}},
+	}
+	for _, w := range words {
+		runes := lowerRunes(w.Text)
+		if len(runes) == 0 {
+			continue
+		}
+		cur := 0
+		for _, r := range runes {
+			nxt, ok := m.nodes[cur].next[r]
+			if !ok {
+				nxt = len(m.nodes)
+				m.nodes = append(m.nodes, node{next: map[rune]int{}})
+				m.nodes[cur].next[r] = nxt
+			}
+			cur = nxt
+		}
+		m.nodes[cur].out = append(m.nodes[cur].out, len(m.words))
+		m.words = append(m.words, w)
+		m.lens = append(m.lens, len(runes))
+	}
+	m.buildFailLinks()
+	return m
+}
+
+// buildFailLinks link every node to the longest proper suffix of it
+// in the trie, level by level
+func (m *Matcher) buildFailLinks() {
+	var queue []int
+	for _, child := range m.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for r, child := range m.nodes[cur].next {
+			f := m.nodes[cur].fail
+			for f > 0 {
+				if _, ok := m.nodes[f].next[r]; ok {
+					break
+				}
+				f = m.nodes[f].fail
+			}
+			if nxt, ok := m.nodes[f].next[r]; ok {
+				m.nodes[child].fail = nxt
+			}
+			m.nodes[child].out = append(m.nodes[child].out,
+				m.nodes[m.nodes[child].fail].out...)
+			queue = append(queue, child)
+		}
+	}
+}
+
+// Match return all the words in the text in the order of their ends,
+// overlapping ones included
+func (m *Matcher) Match(text string) []Match {
+	var res []Match
+	cur := 0
+	for i, r := range []rune(text) {
+		r = unicode.ToLower(r)
+		for cur > 0 {
+			if _, ok := m.nodes[cur].next[r]; ok {
+				break
+			}
+			cur = m.nodes[cur].fail
+		}
+		if nxt, ok := m.nodes[cur].next[r]; ok {
+			cur = nxt
+		}
+		for _, w := range m.nodes[cur].out {
+			res = append(res, Match{
+				Word:  m.words[w],
+				Start: i + 1 - m.lens[w],
+				End:   i + 1,
+			})
+		}
+	}
+	return res
+}
+
+// lowerRunes lower the runes one by one, so the offsets stay the same
+func lowerRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
@@ -0,0 +1,127 @@
+package moderation
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMatcher_Match(t *testing.T) {
+	m := NewMatcher([]Word{
+		{Text: "he", Category: "a"},
+		{Text: "she", Category: "a"},
+		{Text: "his", Category: "b"},
+		{Text: "hers", Category: "b"},
+		{Text: "敏感", Category: "c"},
+	})
+	testCases := []struct {
+		name string
+		text string
+		want []Match
+	}{
+		{
+			name: "nothing",
+			text: "nothing to see",
+			want: nil,
+		},
+		{
+			name: "overlapping",
+			text: "ushers",
+			want: []Match{
+				{Word: Word{Text: "she", Category: "a"}, Start: 1, End: 4},
+				{Word: Word{Text: "he", Category: "a"}, Start: 2, End: 4},
+				{Word: Word{Text: "hers", Category: "b"}, Start: 2, End: 6},
+			},
+		},
+		{
+			name: "case insensitive",
+			text: "HIS",
+			want: []Match{
+				{Word: Word{Text: "his", Category: "b"}, Start: 0, End: 3},
+			},
+		},
+		{
+			name: "runes",
+			text: "这是敏感词",
+			want: []Match{
+				{Word: Word{Text: "敏感", Category: "c"}, Start: 2, End: 4},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, m.Match(tc.text))
+		})
+	}
+}
+
+func TestFilter_Check(t *testing.T) {
+	f := NewFilter(map[string]Category{
+		"ads":      {Action: ActionMask, Words: []string{"buy now", "cheap"}},
+		"abuse":    {Action: ActionReview, Words: []string{"idiot"}},
+		"politics": {Action: ActionReject, Words: []string{"forbidden"}},
+	})
+	testCases := []struct {
+		name string
+		text string
+		want Result
+	}{
+		{
+			name: "pass",
+			text: "hello world",
+			want: Result{Action: ActionPass, Text: "hello world"},
+		},
+		{
+			name: "mask",
+			text: "Buy now, cheap and cheap",
+			want: Result{
+				Action: ActionMask,
+				Text:   "*******, ***** and *****",
+				Words:  []string{"buy now", "cheap"},
+			},
+		},
+		{
+			name: "the strictest wins",
+			text: "cheap idiot, forbidden",
+			want: Result{
+				Action: ActionReject,
+				Text:   "***** idiot, forbidden",
+				Words:  []string{"cheap", "idiot", "forbidden"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, f.Check(tc.text))
+		})
+	}
+}
+
+func TestWatchFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.yaml")
+	err := os.WriteFile(path, []byte(`
+categories:
+  ads:
+    action: mask
+    words: ["cheap"]
+`), 0644)
+	assert.NoError(t, err)
+
+	f := NewFilter(nil)
+	err = WatchFile(path, f, func(err error) {})
+	assert.NoError(t, err)
+	assert.Equal(t, ActionMask, f.Check("cheap").Action)
+
+	err = os.WriteFile(path, []byte(`
+categories:
+  ads:
+    action: reject
+    words: ["cheap"]
+`), 0644)
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return f.Check("cheap").Action == ActionReject
+	}, 3*time.Second, 10*time.Millisecond)
+}
@@ -18,8 +18,12 @@ import (
 var interactiveSet = wire.NewSet(
 	dao.NewGORMInteractiveDAO,
 	cache.NewRedisInteractiveCache,
+	cache.NewRedisUniqueViewCache,
+	ioc.InitViewCountAggregator,
 	repository.NewCachedInteractiveRepository,
-	service.NewInteractiveService,
+	dao.NewGORMCollectionDAO,
+	repository.NewCollectionRepo,
+	ioc.InitInteractiveService,
 )
 
 var rankingSvcSet = wire.NewSet(
@@ -39,6 +43,12 @@ func InitWebServer() *App {
 		ioc.InitConsumers,
 		ioc.InitJobs,
 		ioc.InitRankingJob,
+		ioc.InitScheduledPublishJob,
+		ioc.InitSearchIndexJob,
+		ioc.InitTrashPurgeJob,
+		ioc.InitUploadGCJob,
+		ioc.InitUniqueViewJob,
+		ioc.InitInteractiveReconcileJob,
 		ioc.InitRlockClient,
 
 		interactiveSet,
@@ -48,17 +58,36 @@ func InitWebServer() *App {
 		article.NewInteractiveReadEventConsumer,
 
 		dao.NewGORMUserDAO,
-		dao.NewGORMArticleDAO,
+		ioc.InitArticleAuthorDAO,
+		ioc.InitArticleReaderDAO,
+		dao.NewGORMArticleRevisionDAO,
 		dao.NewGORMCommentDAO,
+		dao.NewGORMUploadDAO,
+		dao.NewGORMSeriesDAO,
+		dao.NewGORMCollaboratorDAO,
+		dao.NewGORMModerationDAO,
+		dao.NewGORMArticleShareDAO,
+		ioc.InitObjectStorage,
 
 		cache.NewRedisUserCache,
 		cache.NewRedisCodeCache,
-		cache.NewRedisArticleCache,
+		cache.NewRedisArticleAuthorCache,
+		cache.NewRedisArticleReaderCache,
+		cache.NewRedisFeedCache,
 
 		repository.NewCachedUserRepository,
 		repository.NewCachedCodeRepository,
-		repository.NewCachedArticleRepository,
+		repository.NewCachedArticleAuthorRepository,
+		repository.NewCachedArticleReaderRepository,
+		repository.NewArticleRevisionRepo,
 		repository.NewCommentRepo,
+		repository.NewUploadRepo,
+		repository.NewSeriesRepo,
+		repository.NewCollaboratorRepo,
+		repository.NewModerationRepo,
+		repository.NewArticleShareRepo,
+		repository.NewCachedFeedRepository,
+		repository.NewMemorySearchRepository,
 
 		ioc.InitSMSService,
 		ioc.InitWechatService,
@@ -66,11 +95,33 @@ func InitWebServer() *App {
 		service.NewCachedUserService,
 		service.NewImplArticleService,
 		service.NewCommentServiceImpl,
+		ioc.InitSearchService,
+		ioc.InitArticleTrashService,
+		ioc.InitUploadService,
+		service.NewImplSeriesService,
+		service.NewImplCollaboratorService,
+		ioc.InitFeedService,
+		ioc.InitModerationFilter,
+		service.NewImplModerationService,
+		service.NewImplArticleArchiveService,
+		ioc.InitArticleShareService,
+		service.NewImplCollectionService,
+		service.NewImplLikeService,
 
 		ijwt.NewRedisJWTHandler,
 		web.NewUserHandler,
 		web.NewOAuth2WechatHandler,
 		web.NewArticleHandler,
+		web.NewUploadHandler,
+		web.NewSeriesHandler,
+		web.NewCollaboratorHandler,
+		web.NewFeedHandler,
+		web.NewModerationHandler,
+		web.NewArticleArchiveHandler,
+		web.NewArticleShareHandler,
+		web.NewCollectionHandler,
+		web.NewLikeHandler,
+		web.NewInteractiveAdminHandler,
 
 		ioc.InitWebServer,
 		ioc.InitMiddleware,
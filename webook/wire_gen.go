@@ -37,41 +37,93 @@ func InitWebServer() *App {
 	userHandler := web.NewUserHandler(userService, codeService, handler)
 	wechatService := ioc.InitWechatService()
 	oAuth2WechatHandler := web.NewOAuth2WechatHandler(wechatService, userService, handler)
-	articleDAO := dao.NewGORMArticleDAO(db)
-	articleCache := cache.NewRedisArticleCache(cmdable)
-	articleRepository := repository.NewCachedArticleRepository(articleDAO, articleCache, userRepository)
+	articleAuthorDAO := ioc.InitArticleAuthorDAO(db)
+	articleAuthorCache := cache.NewRedisArticleAuthorCache(cmdable)
+	articleAuthorRepository := repository.NewCachedArticleAuthorRepository(articleAuthorDAO, articleAuthorCache)
+	articleReaderDAO := ioc.InitArticleReaderDAO(db, userDAO)
+	articleReaderCache := cache.NewRedisArticleReaderCache(cmdable)
+	articleReaderRepository := repository.NewCachedArticleReaderRepository(articleReaderDAO, articleReaderCache, userRepository)
+	articleRevisionDAO := dao.NewGORMArticleRevisionDAO(db)
+	articleRevisionRepository := repository.NewArticleRevisionRepo(articleRevisionDAO)
+	collaboratorDAO := dao.NewGORMCollaboratorDAO(db)
+	collaboratorRepository := repository.NewCollaboratorRepo(collaboratorDAO)
+	feedCache := cache.NewRedisFeedCache(cmdable)
+	feedRepository := repository.NewCachedFeedRepository(feedCache)
+	searchRepository := repository.NewMemorySearchRepository()
+	searchService := ioc.InitSearchService(searchRepository, articleReaderRepository, logger)
+	moderationDAO := dao.NewGORMModerationDAO(db)
+	moderationRepository := repository.NewModerationRepo(moderationDAO)
+	filter := ioc.InitModerationFilter(logger)
+	moderationService := service.NewImplModerationService(moderationRepository, filter)
 	client := ioc.InitSaramaClient()
 	syncProducer := ioc.InitSyncProducer(client)
 	producer := article.NewSaramaSyncProducer(syncProducer)
-	articleService := service.NewImplArticleService(articleRepository, producer, logger)
+	articleService := service.NewImplArticleService(articleAuthorRepository, articleReaderRepository, articleRevisionRepository, collaboratorRepository, feedRepository, searchService, moderationService, producer, logger)
 	interactiveDAO := dao.NewGORMInteractiveDAO(db)
 	interactiveCache := cache.NewRedisInteractiveCache(cmdable)
-	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDAO, interactiveCache, logger)
-	interactiveService := service.NewInteractiveService(interactiveRepository)
+	uniqueViewCache := cache.NewRedisUniqueViewCache(cmdable)
+	viewCountAggregator := ioc.InitViewCountAggregator(interactiveDAO, interactiveCache, logger)
+	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDAO, interactiveCache, uniqueViewCache, viewCountAggregator, logger)
+	collectionDAO := dao.NewGORMCollectionDAO(db)
+	collectionRepository := repository.NewCollectionRepo(collectionDAO, interactiveCache, logger)
+	interactiveService := ioc.InitInteractiveService(interactiveRepository, collectionRepository)
 	localRankingCache := cache.NewRankingLocalCache()
 	redisRankingCache := cache.NewRedisRankingCache(cmdable)
 	rankingRepository := repository.NewCachedRankingRepository(localRankingCache, redisRankingCache)
 	rankingService := service.NewBatchRankingService(rankingRepository, interactiveService, articleService)
 	commentDAO := dao.NewGORMCommentDAO(db)
 	commentRepository := repository.NewCommentRepo(commentDAO)
-	commentService := service.NewCommentServiceImpl(commentRepository, userRepository)
-	articleHandler := web.NewArticleHandler(logger, articleService, interactiveService, rankingService, commentService)
-	engine := ioc.InitWebServer(v, userHandler, oAuth2WechatHandler, articleHandler)
+	commentService := service.NewCommentServiceImpl(commentRepository, userRepository, moderationService)
+	uploadDAO := dao.NewGORMUploadDAO(db)
+	objectStorage := ioc.InitObjectStorage()
+	uploadRepository := repository.NewUploadRepo(uploadDAO, objectStorage)
+	seriesDAO := dao.NewGORMSeriesDAO(db)
+	seriesRepository := repository.NewSeriesRepo(seriesDAO)
+	articleShareDAO := dao.NewGORMArticleShareDAO(db)
+	articleShareRepository := repository.NewArticleShareRepo(articleShareDAO)
+	articleTrashService := ioc.InitArticleTrashService(articleAuthorRepository, articleReaderRepository, articleRevisionRepository, interactiveRepository, commentRepository, uploadRepository, seriesRepository, collaboratorRepository, articleShareRepository, rankingRepository, feedRepository, searchService, logger)
+	seriesService := service.NewImplSeriesService(seriesRepository, articleAuthorRepository, articleReaderRepository, logger)
+	articleHandler := web.NewArticleHandler(logger, articleService, interactiveService, rankingService, commentService, searchService, articleTrashService, seriesService)
+	uploadService := ioc.InitUploadService(uploadRepository, articleAuthorRepository, articleReaderRepository, collaboratorRepository, logger)
+	uploadHandler := web.NewUploadHandler(logger, uploadService)
+	seriesHandler := web.NewSeriesHandler(logger, seriesService)
+	collaboratorService := service.NewImplCollaboratorService(collaboratorRepository, articleAuthorRepository)
+	collaboratorHandler := web.NewCollaboratorHandler(logger, collaboratorService)
+	feedService := ioc.InitFeedService(articleService, feedRepository, userRepository, logger)
+	feedHandler := web.NewFeedHandler(logger, feedService)
+	moderationHandler := web.NewModerationHandler(logger, moderationService, articleService, commentService)
+	articleArchiveService := service.NewImplArticleArchiveService(articleService, articleAuthorRepository, logger)
+	articleArchiveHandler := web.NewArticleArchiveHandler(logger, articleArchiveService)
+	articleShareService := ioc.InitArticleShareService(articleShareRepository, articleAuthorRepository, articleReaderRepository, collaboratorRepository, userRepository, moderationService)
+	articleShareHandler := web.NewArticleShareHandler(logger, articleShareService)
+	collectionService := service.NewImplCollectionService(collectionRepository, articleReaderRepository)
+	collectionHandler := web.NewCollectionHandler(logger, collectionService, interactiveService)
+	likeService := service.NewImplLikeService(interactiveRepository, articleReaderRepository, userRepository)
+	likeHandler := web.NewLikeHandler(logger, likeService, interactiveService)
+	rlockClient := ioc.InitRlockClient(cmdable)
+	interactiveReconcileJob := ioc.InitInteractiveReconcileJob(interactiveService, rlockClient, logger)
+	interactiveAdminHandler := web.NewInteractiveAdminHandler(logger, interactiveReconcileJob)
+	engine := ioc.InitWebServer(v, userHandler, oAuth2WechatHandler, articleHandler, uploadHandler, seriesHandler, collaboratorHandler, feedHandler, moderationHandler, articleArchiveHandler, articleShareHandler, collectionHandler, likeHandler, interactiveAdminHandler)
 	interactiveReadEventConsumer := article.NewInteractiveReadEventConsumer(interactiveRepository, client, logger)
 	v2 := ioc.InitConsumers(interactiveReadEventConsumer)
-	rlockClient := ioc.InitRlockClient(cmdable)
 	rankingJob := ioc.InitRankingJob(rankingService, rlockClient, logger)
-	cron := ioc.InitJobs(logger, rankingJob)
+	scheduledPublishJob := ioc.InitScheduledPublishJob(articleService, rlockClient, logger)
+	searchIndexJob := ioc.InitSearchIndexJob(searchService)
+	trashPurgeJob := ioc.InitTrashPurgeJob(articleTrashService, rlockClient, logger)
+	uploadGCJob := ioc.InitUploadGCJob(uploadService, rlockClient, logger)
+	uniqueViewJob := ioc.InitUniqueViewJob(interactiveService, rlockClient, logger)
+	cron := ioc.InitJobs(logger, rankingJob, scheduledPublishJob, searchIndexJob, trashPurgeJob, uploadGCJob, uniqueViewJob, interactiveReconcileJob)
 	app := &App{
 		server:    engine,
 		consumers: v2,
 		cron:      cron,
+		views:     viewCountAggregator,
 	}
 	return app
 }
 
 // wire.go:
 
-var interactiveSet = wire.NewSet(dao.NewGORMInteractiveDAO, cache.NewRedisInteractiveCache, repository.NewCachedInteractiveRepository, service.NewInteractiveService)
+var interactiveSet = wire.NewSet(dao.NewGORMInteractiveDAO, cache.NewRedisInteractiveCache, cache.NewRedisUniqueViewCache, ioc.InitViewCountAggregator, repository.NewCachedInteractiveRepository, dao.NewGORMCollectionDAO, repository.NewCollectionRepo, ioc.InitInteractiveService)
 
 var rankingSvcSet = wire.NewSet(cache.NewRedisRankingCache, cache.NewRankingLocalCache, repository.NewCachedRankingRepository, service.NewBatchRankingService)